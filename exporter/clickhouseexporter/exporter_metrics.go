@@ -0,0 +1,98 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	_ "github.com/ClickHouse/clickhouse-go/v2" // For register database driver.
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+)
+
+type metricsExporter struct {
+	client *sql.DB
+
+	logger *zap.Logger
+	cfg    *Config
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*metricsExporter, error) {
+
+	if err := createDatabase(cfg); err != nil {
+		return nil, err
+	}
+
+	client, err := newClickhouseClient(cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = internal.NewMetricsTables(context.Background(), cfg.MetricsTableName, cfg.TTLDays, client); err != nil {
+		return nil, err
+	}
+
+	return &metricsExporter{
+		client: client,
+		logger: logger,
+		cfg:    cfg,
+	}, nil
+}
+
+// Shutdown will shutdown the exporter.
+func (e *metricsExporter) Shutdown(_ context.Context) error {
+	if e.client != nil {
+		return e.client.Close()
+	}
+	return nil
+}
+
+func (e *metricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	models := internal.NewMetricsModels(e.cfg.MetricsTableName)
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		metrics := md.ResourceMetrics().At(i)
+		res := metrics.Resource()
+		for j := 0; j < metrics.ScopeMetrics().Len(); j++ {
+			scopeMetrics := metrics.ScopeMetrics().At(j)
+			metaData := internal.NewMetricsMetaData(res, metrics.SchemaUrl(), scopeMetrics.Scope(), scopeMetrics.SchemaUrl())
+			rs := scopeMetrics.Metrics()
+			for k := 0; k < rs.Len(); k++ {
+				r := rs.At(k)
+				model, ok := models[r.Type()]
+				if !ok {
+					e.logger.Debug("unsupported metric type", zap.String("name", r.Name()),
+						zap.String("type", r.Type().String()))
+					continue
+				}
+				model.Add(metaData, r)
+			}
+		}
+	}
+
+	start := time.Now()
+	err := internal.InsertMetrics(ctx, e.client, models)
+	duration := time.Since(start)
+	e.logger.Info("insert metrics", zap.Int("records", md.DataPointCount()),
+		zap.String("cost", duration.String()))
+	if err != nil {
+		return fmt.Errorf("insert metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package clickhouseexporter
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/zap/zaptest"
+)
+
+func TestMetricsExporter_New(t *testing.T) {
+	var tables []string
+	initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+		if strings.HasPrefix(query, "CREATE TABLE") {
+			tables = append(tables, strings.Fields(query)[5])
+		}
+		return nil
+	})
+
+	newTestMetricsExporter(t, defaultDSN)
+	require.ElementsMatch(t, []string{
+		"otel_metrics_gauge",
+		"otel_metrics_sum",
+		"otel_metrics_histogram",
+		"otel_metrics_exponential_histogram",
+		"otel_metrics_summary",
+	}, tables)
+}
+
+func TestExporter_pushMetricsData(t *testing.T) {
+	t.Run("push success", func(t *testing.T) {
+		items := map[string]int{}
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			t.Logf("values:%+v", values)
+			if strings.HasPrefix(query, "INSERT") {
+				items[strings.Fields(query)[2]]++
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+		mustPushMetricsData(t, exporter, simpleMetrics(2))
+
+		require.Equal(t, map[string]int{
+			"otel_metrics_gauge":                 3,
+			"otel_metrics_sum":                   3,
+			"otel_metrics_histogram":             3,
+			"otel_metrics_exponential_histogram": 3,
+			"otel_metrics_summary":               3,
+		}, items)
+	})
+	t.Run("push values", func(t *testing.T) {
+		var values []driver.Value
+		initClickhouseTestServer(t, func(query string, v []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT INTO otel_metrics_sum ") {
+				values = v
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		mustPushMetricsData(t, exporter, simpleMetrics(1))
+
+		require.Len(t, values, 23)
+		require.Equal(t, map[string]string{conventions.AttributeServiceName: "demo"}, values[0])
+		require.Equal(t, "demo", values[7])
+		require.Equal(t, "sum metric", values[8])
+		require.Equal(t, map[string]string{"sum_label": "1"}, values[11])
+		require.Equal(t, float64(11), values[14])
+		require.Equal(t, []float64{54}, values[18])
+		require.Equal(t, []string{"0102030405060708"}, values[19])
+		require.Equal(t, int32(pmetric.AggregationTemporalityCumulative), values[21])
+		require.Equal(t, true, values[22])
+	})
+	t.Run("push failure", func(t *testing.T) {
+		initClickhouseTestServer(t, func(query string, values []driver.Value) error {
+			if strings.HasPrefix(query, "INSERT INTO otel_metrics_summary ") {
+				return errors.New("insert failed")
+			}
+			return nil
+		})
+
+		exporter := newTestMetricsExporter(t, defaultDSN)
+		err := exporter.pushMetricsData(context.TODO(), simpleMetrics(1))
+		require.ErrorContains(t, err, "insert failed")
+	})
+}
+
+func newTestMetricsExporter(t *testing.T, dsn string, fns ...func(*Config)) *metricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(dsn))
+	require.NoError(t, err)
+
+	t.Cleanup(func() { _ = exporter.Shutdown(context.TODO()) })
+	return exporter
+}
+
+func simpleMetrics(count int) pmetric.Metrics {
+	metrics := pmetric.NewMetrics()
+	rm := metrics.ResourceMetrics().AppendEmpty()
+	rm.SetSchemaUrl("https://opentelemetry.io/schemas/1.4.0")
+	rm.Resource().Attributes().PutStr(conventions.AttributeServiceName, "demo")
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.SetSchemaUrl("https://opentelemetry.io/schemas/1.7.0")
+	sm.Scope().SetName("Scope name")
+	sm.Scope().SetVersion("Scope version")
+	timestamp := pcommon.NewTimestampFromTime(time.Now())
+	for i := 0; i < count; i++ {
+		// gauge
+		m := sm.Metrics().AppendEmpty()
+		m.SetName("gauge metric")
+		m.SetUnit("count")
+		dp := m.SetEmptyGauge().DataPoints().AppendEmpty()
+		dp.SetIntValue(int64(i))
+		dp.Attributes().PutStr("gauge_label", "1")
+		dp.SetTimestamp(timestamp)
+		exemplar := dp.Exemplars().AppendEmpty()
+		exemplar.SetIntValue(54)
+		exemplar.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+		// sum
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("sum metric")
+		m.SetUnit("count")
+		sum := m.SetEmptySum()
+		sum.SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		sum.SetIsMonotonic(true)
+		dp = sum.DataPoints().AppendEmpty()
+		dp.SetDoubleValue(11)
+		dp.Attributes().PutInt("sum_label", 1)
+		dp.SetStartTimestamp(timestamp)
+		dp.SetTimestamp(timestamp)
+		exemplar = dp.Exemplars().AppendEmpty()
+		exemplar.SetDoubleValue(54)
+		exemplar.SetSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+		// histogram
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("histogram metric")
+		m.SetUnit("ms")
+		histogram := m.SetEmptyHistogram()
+		histogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		hdp := histogram.DataPoints().AppendEmpty()
+		hdp.SetCount(3)
+		hdp.SetSum(12)
+		hdp.SetMin(1)
+		hdp.SetMax(8)
+		hdp.BucketCounts().FromRaw([]uint64{1, 1, 1})
+		hdp.ExplicitBounds().FromRaw([]float64{2, 5})
+		hdp.SetTimestamp(timestamp)
+
+		// exponential histogram
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("exponential histogram metric")
+		m.SetUnit("ms")
+		expHistogram := m.SetEmptyExponentialHistogram()
+		expHistogram.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+		edp := expHistogram.DataPoints().AppendEmpty()
+		edp.SetScale(1)
+		edp.SetCount(4)
+		edp.SetSum(10)
+		edp.SetZeroCount(1)
+		edp.Positive().SetOffset(1)
+		edp.Positive().BucketCounts().FromRaw([]uint64{1, 2})
+		edp.SetTimestamp(timestamp)
+
+		// summary
+		m = sm.Metrics().AppendEmpty()
+		m.SetName("summary metric")
+		m.SetUnit("ms")
+		sdp := m.SetEmptySummary().DataPoints().AppendEmpty()
+		sdp.SetCount(2)
+		sdp.SetSum(5)
+		quantile := sdp.QuantileValues().AppendEmpty()
+		quantile.SetQuantile(0.99)
+		quantile.SetValue(4)
+		sdp.SetTimestamp(timestamp)
+	}
+	return metrics
+}
+
+func mustPushMetricsData(t *testing.T, exporter *metricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
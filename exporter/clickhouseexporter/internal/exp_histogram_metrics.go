@@ -0,0 +1,105 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// language=ClickHouse SQL
+const expHistogramColumnsSQL = commonColumnsSQL + `
+    Count UInt64 CODEC(Delta, ZSTD(1)),
+    Sum Float64 CODEC(ZSTD(1)),
+    Scale Int32 CODEC(ZSTD(1)),
+    ZeroCount UInt64 CODEC(ZSTD(1)),
+    PositiveOffset Int32 CODEC(ZSTD(1)),
+    PositiveBucketCounts Array(UInt64) CODEC(ZSTD(1)),
+    NegativeOffset Int32 CODEC(ZSTD(1)),
+    NegativeBucketCounts Array(UInt64) CODEC(ZSTD(1)),` + exemplarsColumnSQL + `
+    Flags UInt32 CODEC(ZSTD(1)),
+    Min Float64 CODEC(ZSTD(1)),
+    Max Float64 CODEC(ZSTD(1)),
+    AggTemp Int32 CODEC(ZSTD(1)),`
+
+var expHistogramInsertColumns = append(append(append(append([]string{}, commonInsertColumns...),
+	"Count",
+	"Sum",
+	"Scale",
+	"ZeroCount",
+	"PositiveOffset",
+	"PositiveBucketCounts",
+	"NegativeOffset",
+	"NegativeBucketCounts",
+), exemplarsInsertColumns...),
+	"Flags",
+	"Min",
+	"Max",
+	"AggTemp",
+)
+
+type expHistogramRecord struct {
+	metricsRecord
+	aggTemp pmetric.AggregationTemporality
+	dp      pmetric.ExponentialHistogramDataPoint
+}
+
+type expHistogramMetrics struct {
+	insertSQL string
+	records   []expHistogramRecord
+}
+
+func (e *expHistogramMetrics) Add(metaData *MetricsMetaData, metric pmetric.Metric) {
+	record := newMetricsRecord(metaData, metric)
+	expHistogram := metric.ExponentialHistogram()
+	dps := expHistogram.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		e.records = append(e.records, expHistogramRecord{
+			metricsRecord: record,
+			aggTemp:       expHistogram.AggregationTemporality(),
+			dp:            dps.At(i),
+		})
+	}
+}
+
+func (e *expHistogramMetrics) insert(ctx context.Context, db *sql.DB) error {
+	return insertRows(ctx, db, e.insertSQL, len(e.records), func(i int) []interface{} {
+		r := e.records[i]
+		args := r.args(r.dp.Attributes(), r.dp.StartTimestamp(), r.dp.Timestamp())
+		args = append(args,
+			r.dp.Count(),
+			r.dp.Sum(),
+			r.dp.Scale(),
+			r.dp.ZeroCount(),
+			r.dp.Positive().Offset(),
+			r.dp.Positive().BucketCounts().AsRaw(),
+			r.dp.Negative().Offset(),
+			r.dp.Negative().BucketCounts().AsRaw(),
+		)
+		args = append(args, convertExemplars(r.dp.Exemplars())...)
+		return append(args,
+			uint32(r.dp.Flags()),
+			r.dp.Min(),
+			r.dp.Max(),
+			int32(r.aggTemp),
+		)
+	})
+}
+
+func (e *expHistogramMetrics) count() int {
+	return len(e.records)
+}
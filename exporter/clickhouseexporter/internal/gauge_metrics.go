@@ -0,0 +1,66 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// language=ClickHouse SQL
+const gaugeColumnsSQL = commonColumnsSQL + `
+    Value Float64 CODEC(ZSTD(1)),
+    Flags UInt32 CODEC(ZSTD(1)),` + exemplarsColumnSQL
+
+var gaugeInsertColumns = append(append(append([]string{}, commonInsertColumns...),
+	"Value",
+	"Flags",
+), exemplarsInsertColumns...)
+
+type gaugeRecord struct {
+	metricsRecord
+	dp pmetric.NumberDataPoint
+}
+
+type gaugeMetrics struct {
+	insertSQL string
+	records   []gaugeRecord
+}
+
+func (g *gaugeMetrics) Add(metaData *MetricsMetaData, metric pmetric.Metric) {
+	record := newMetricsRecord(metaData, metric)
+	dps := metric.Gauge().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		g.records = append(g.records, gaugeRecord{metricsRecord: record, dp: dps.At(i)})
+	}
+}
+
+func (g *gaugeMetrics) insert(ctx context.Context, db *sql.DB) error {
+	return insertRows(ctx, db, g.insertSQL, len(g.records), func(i int) []interface{} {
+		r := g.records[i]
+		args := r.args(r.dp.Attributes(), r.dp.StartTimestamp(), r.dp.Timestamp())
+		args = append(args,
+			numberValue(r.dp),
+			uint32(r.dp.Flags()),
+		)
+		return append(args, convertExemplars(r.dp.Exemplars())...)
+	})
+}
+
+func (g *gaugeMetrics) count() int {
+	return len(g.records)
+}
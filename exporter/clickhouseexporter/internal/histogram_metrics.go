@@ -0,0 +1,93 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// language=ClickHouse SQL
+const histogramColumnsSQL = commonColumnsSQL + `
+    Count UInt64 CODEC(Delta, ZSTD(1)),
+    Sum Float64 CODEC(ZSTD(1)),
+    BucketCounts Array(UInt64) CODEC(ZSTD(1)),
+    ExplicitBounds Array(Float64) CODEC(ZSTD(1)),` + exemplarsColumnSQL + `
+    Flags UInt32 CODEC(ZSTD(1)),
+    Min Float64 CODEC(ZSTD(1)),
+    Max Float64 CODEC(ZSTD(1)),
+    AggTemp Int32 CODEC(ZSTD(1)),`
+
+var histogramInsertColumns = append(append(append(append([]string{}, commonInsertColumns...),
+	"Count",
+	"Sum",
+	"BucketCounts",
+	"ExplicitBounds",
+), exemplarsInsertColumns...),
+	"Flags",
+	"Min",
+	"Max",
+	"AggTemp",
+)
+
+type histogramRecord struct {
+	metricsRecord
+	aggTemp pmetric.AggregationTemporality
+	dp      pmetric.HistogramDataPoint
+}
+
+type histogramMetrics struct {
+	insertSQL string
+	records   []histogramRecord
+}
+
+func (h *histogramMetrics) Add(metaData *MetricsMetaData, metric pmetric.Metric) {
+	record := newMetricsRecord(metaData, metric)
+	histogram := metric.Histogram()
+	dps := histogram.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		h.records = append(h.records, histogramRecord{
+			metricsRecord: record,
+			aggTemp:       histogram.AggregationTemporality(),
+			dp:            dps.At(i),
+		})
+	}
+}
+
+func (h *histogramMetrics) insert(ctx context.Context, db *sql.DB) error {
+	return insertRows(ctx, db, h.insertSQL, len(h.records), func(i int) []interface{} {
+		r := h.records[i]
+		args := r.args(r.dp.Attributes(), r.dp.StartTimestamp(), r.dp.Timestamp())
+		args = append(args,
+			r.dp.Count(),
+			r.dp.Sum(),
+			r.dp.BucketCounts().AsRaw(),
+			r.dp.ExplicitBounds().AsRaw(),
+		)
+		args = append(args, convertExemplars(r.dp.Exemplars())...)
+		return append(args,
+			uint32(r.dp.Flags()),
+			r.dp.Min(),
+			r.dp.Max(),
+			int32(r.aggTemp),
+		)
+	})
+}
+
+func (h *histogramMetrics) count() int {
+	return len(h.records)
+}
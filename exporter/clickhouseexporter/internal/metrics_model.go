@@ -0,0 +1,303 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/traceutil"
+)
+
+const (
+	gaugeTableSuffix        = "_gauge"
+	sumTableSuffix          = "_sum"
+	histogramTableSuffix    = "_histogram"
+	expHistogramTableSuffix = "_exponential_histogram"
+	summaryTableSuffix      = "_summary"
+)
+
+// language=ClickHouse SQL
+const commonColumnsSQL = `
+    ResourceAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+    ResourceSchemaUrl String CODEC(ZSTD(1)),
+    ScopeName String CODEC(ZSTD(1)),
+    ScopeVersion String CODEC(ZSTD(1)),
+    ScopeAttributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+    ScopeDroppedAttrCount UInt32 CODEC(ZSTD(1)),
+    ScopeSchemaUrl String CODEC(ZSTD(1)),
+    ServiceName LowCardinality(String) CODEC(ZSTD(1)),
+    MetricName String CODEC(ZSTD(1)),
+    MetricDescription String CODEC(ZSTD(1)),
+    MetricUnit String CODEC(ZSTD(1)),
+    Attributes Map(LowCardinality(String), String) CODEC(ZSTD(1)),
+    StartTimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),
+    TimeUnix DateTime64(9) CODEC(Delta, ZSTD(1)),`
+
+// language=ClickHouse SQL
+const exemplarsColumnSQL = `
+    Exemplars Nested (
+        FilteredAttributes Map(LowCardinality(String), String),
+        TimeUnix DateTime64(9),
+        Value Float64,
+        SpanId String,
+        TraceId String
+    ) CODEC(ZSTD(1)),`
+
+// language=ClickHouse SQL
+const tableSettingsSQL = `
+    INDEX idx_res_attr_key mapKeys(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+    INDEX idx_res_attr_value mapValues(ResourceAttributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+    INDEX idx_attr_key mapKeys(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1,
+    INDEX idx_attr_value mapValues(Attributes) TYPE bloom_filter(0.01) GRANULARITY 1
+) ENGINE MergeTree()
+%s
+PARTITION BY toDate(TimeUnix)
+ORDER BY (ServiceName, MetricName, Attributes, toUnixTimestamp64Nano(TimeUnix))
+SETTINGS index_granularity=8192, ttl_only_drop_parts = 1;
+`
+
+// commonInsertColumns lists the columns shared by all metric tables, in the order
+// written by metricsMetaData.args.
+var commonInsertColumns = []string{
+	"ResourceAttributes",
+	"ResourceSchemaUrl",
+	"ScopeName",
+	"ScopeVersion",
+	"ScopeAttributes",
+	"ScopeDroppedAttrCount",
+	"ScopeSchemaUrl",
+	"ServiceName",
+	"MetricName",
+	"MetricDescription",
+	"MetricUnit",
+	"Attributes",
+	"StartTimeUnix",
+	"TimeUnix",
+}
+
+var exemplarsInsertColumns = []string{
+	"Exemplars.FilteredAttributes",
+	"Exemplars.TimeUnix",
+	"Exemplars.Value",
+	"Exemplars.SpanId",
+	"Exemplars.TraceId",
+}
+
+// MetricsModel is used to group metric data points of one type and insert them into ClickHouse.
+type MetricsModel interface {
+	// Add appends the data points of a metric to the model.
+	Add(metaData *MetricsMetaData, metric pmetric.Metric)
+	// insert inserts all data points held by the model into ClickHouse.
+	insert(ctx context.Context, db *sql.DB) error
+	// count returns the number of data points held by the model.
+	count() int
+}
+
+// MetricsMetaData contains the resource and scope information shared by all metrics
+// of a single pmetric.ScopeMetrics.
+type MetricsMetaData struct {
+	ResAttr     map[string]string
+	ResURL      string
+	ServiceName string
+	ScopeURL    string
+	ScopeInstr  pcommon.InstrumentationScope
+}
+
+// NewMetricsMetaData creates the MetricsMetaData for the given resource and scope.
+func NewMetricsMetaData(res pcommon.Resource, resURL string, scope pcommon.InstrumentationScope, scopeURL string) *MetricsMetaData {
+	var serviceName string
+	if v, ok := res.Attributes().Get(conventions.AttributeServiceName); ok {
+		serviceName = v.AsString()
+	}
+	return &MetricsMetaData{
+		ResAttr:     AttributesToMap(res.Attributes()),
+		ResURL:      resURL,
+		ServiceName: serviceName,
+		ScopeURL:    scopeURL,
+		ScopeInstr:  scope,
+	}
+}
+
+// NewMetricsModels creates one MetricsModel per supported metric type, writing to
+// tables named after tableName.
+func NewMetricsModels(tableName string) map[pmetric.MetricType]MetricsModel {
+	return map[pmetric.MetricType]MetricsModel{
+		pmetric.MetricTypeGauge:                &gaugeMetrics{insertSQL: renderInsertSQL(tableName+gaugeTableSuffix, gaugeInsertColumns)},
+		pmetric.MetricTypeSum:                  &sumMetrics{insertSQL: renderInsertSQL(tableName+sumTableSuffix, sumInsertColumns)},
+		pmetric.MetricTypeHistogram:            &histogramMetrics{insertSQL: renderInsertSQL(tableName+histogramTableSuffix, histogramInsertColumns)},
+		pmetric.MetricTypeExponentialHistogram: &expHistogramMetrics{insertSQL: renderInsertSQL(tableName+expHistogramTableSuffix, expHistogramInsertColumns)},
+		pmetric.MetricTypeSummary:              &summaryMetrics{insertSQL: renderInsertSQL(tableName+summaryTableSuffix, summaryInsertColumns)},
+	}
+}
+
+// NewMetricsTables creates the tables for all supported metric types if they do not exist yet.
+func NewMetricsTables(ctx context.Context, tableName string, ttlDays uint, db *sql.DB) error {
+	for suffix, columns := range map[string]string{
+		gaugeTableSuffix:        gaugeColumnsSQL,
+		sumTableSuffix:          sumColumnsSQL,
+		histogramTableSuffix:    histogramColumnsSQL,
+		expHistogramTableSuffix: expHistogramColumnsSQL,
+		summaryTableSuffix:      summaryColumnsSQL,
+	} {
+		if _, err := db.ExecContext(ctx, renderCreateTableSQL(tableName+suffix, columns, ttlDays)); err != nil {
+			return fmt.Errorf("exec create metrics table %s%s sql: %w", tableName, suffix, err)
+		}
+	}
+	return nil
+}
+
+// InsertMetrics inserts the data points of all models into ClickHouse, one transaction per metric type.
+func InsertMetrics(ctx context.Context, db *sql.DB, models map[pmetric.MetricType]MetricsModel) error {
+	var errs error
+	for _, model := range models {
+		if model.count() == 0 {
+			continue
+		}
+		errs = multierr.Append(errs, model.insert(ctx, db))
+	}
+	return errs
+}
+
+func renderCreateTableSQL(tableName string, columns string, ttlDays uint) string {
+	var ttlExpr string
+	if ttlDays > 0 {
+		ttlExpr = fmt.Sprintf(`TTL toDateTime(TimeUnix) + toIntervalDay(%d)`, ttlDays)
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s%s", tableName, columns, fmt.Sprintf(tableSettingsSQL, ttlExpr))
+}
+
+func renderInsertSQL(tableName string, columns []string) string {
+	placeholders := strings.TrimSuffix(strings.Repeat("?,", len(columns)), ",")
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES (%s)", tableName, strings.Join(columns, ","), placeholders)
+}
+
+// args returns the values of the common columns for a data point.
+func (m *MetricsMetaData) args(name, description, unit string, attrs pcommon.Map, start, ts pcommon.Timestamp) []interface{} {
+	return []interface{}{
+		m.ResAttr,
+		m.ResURL,
+		m.ScopeInstr.Name(),
+		m.ScopeInstr.Version(),
+		AttributesToMap(m.ScopeInstr.Attributes()),
+		m.ScopeInstr.DroppedAttributesCount(),
+		m.ScopeURL,
+		m.ServiceName,
+		name,
+		description,
+		unit,
+		AttributesToMap(attrs),
+		start.AsTime(),
+		ts.AsTime(),
+	}
+}
+
+// metricsRecord holds a single data point to insert along with its metric metadata.
+type metricsRecord struct {
+	metaData    *MetricsMetaData
+	name        string
+	description string
+	unit        string
+}
+
+func newMetricsRecord(metaData *MetricsMetaData, metric pmetric.Metric) metricsRecord {
+	return metricsRecord{
+		metaData:    metaData,
+		name:        metric.Name(),
+		description: metric.Description(),
+		unit:        metric.Unit(),
+	}
+}
+
+func (r metricsRecord) args(attrs pcommon.Map, start, ts pcommon.Timestamp) []interface{} {
+	return r.metaData.args(r.name, r.description, r.unit, attrs, start, ts)
+}
+
+// insertRows prepares insertSQL within a transaction and executes it once per row.
+func insertRows(ctx context.Context, db *sql.DB, insertSQL string, rows int, rowArgs func(i int) []interface{}) error {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("db.BeginTx: %w", err)
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	statement, err := tx.PrepareContext(ctx, insertSQL)
+	if err != nil {
+		return fmt.Errorf("PrepareContext:%w", err)
+	}
+	defer func() {
+		_ = statement.Close()
+	}()
+	for i := 0; i < rows; i++ {
+		if _, err = statement.ExecContext(ctx, rowArgs(i)...); err != nil {
+			return fmt.Errorf("ExecContext:%w", err)
+		}
+	}
+	return tx.Commit()
+}
+
+// AttributesToMap converts attributes to a string map, stringifying non-string values.
+func AttributesToMap(attributes pcommon.Map) map[string]string {
+	m := make(map[string]string, attributes.Len())
+	attributes.Range(func(k string, v pcommon.Value) bool {
+		m[k] = v.AsString()
+		return true
+	})
+	return m
+}
+
+// convertExemplars converts exemplars to the values of the Exemplars nested columns.
+func convertExemplars(exemplars pmetric.ExemplarSlice) []interface{} {
+	var (
+		attrs    []map[string]string
+		times    []time.Time
+		values   []float64
+		spanIDs  []string
+		traceIDs []string
+	)
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplar := exemplars.At(i)
+		attrs = append(attrs, AttributesToMap(exemplar.FilteredAttributes()))
+		times = append(times, exemplar.Timestamp().AsTime())
+		values = append(values, exemplarValue(exemplar))
+		spanIDs = append(spanIDs, traceutil.SpanIDToHexOrEmptyString(exemplar.SpanID()))
+		traceIDs = append(traceIDs, traceutil.TraceIDToHexOrEmptyString(exemplar.TraceID()))
+	}
+	return []interface{}{attrs, times, values, spanIDs, traceIDs}
+}
+
+func exemplarValue(exemplar pmetric.Exemplar) float64 {
+	if exemplar.ValueType() == pmetric.ExemplarValueTypeInt {
+		return float64(exemplar.IntValue())
+	}
+	return exemplar.DoubleValue()
+}
+
+func numberValue(dp pmetric.NumberDataPoint) float64 {
+	if dp.ValueType() == pmetric.NumberDataPointValueTypeInt {
+		return float64(dp.IntValue())
+	}
+	return dp.DoubleValue()
+}
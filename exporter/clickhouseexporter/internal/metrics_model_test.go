@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRenderInsertSQL(t *testing.T) {
+	require.Equal(t, "INSERT INTO otel_metrics_gauge (Value,Flags) VALUES (?,?)",
+		renderInsertSQL("otel_metrics_gauge", []string{"Value", "Flags"}))
+}
+
+func TestRenderCreateTableSQL(t *testing.T) {
+	withTTL := renderCreateTableSQL("otel_metrics_sum", sumColumnsSQL, 3)
+	require.True(t, strings.HasPrefix(withTTL, "CREATE TABLE IF NOT EXISTS otel_metrics_sum ("))
+	require.Contains(t, withTTL, "TTL toDateTime(TimeUnix) + toIntervalDay(3)")
+
+	withoutTTL := renderCreateTableSQL("otel_metrics_sum", sumColumnsSQL, 0)
+	require.NotContains(t, withoutTTL, "TTL")
+}
+
+func TestInsertColumnsMatchTableColumns(t *testing.T) {
+	for columnsSQL, insertColumns := range map[string][]string{
+		gaugeColumnsSQL:        gaugeInsertColumns,
+		sumColumnsSQL:          sumInsertColumns,
+		histogramColumnsSQL:    histogramInsertColumns,
+		expHistogramColumnsSQL: expHistogramInsertColumns,
+		summaryColumnsSQL:      summaryInsertColumns,
+	} {
+		for _, column := range insertColumns {
+			column = column[strings.LastIndex(column, ".")+1:]
+			require.Contains(t, columnsSQL, "    "+column+" ")
+		}
+	}
+}
+
+func TestAttributesToMap(t *testing.T) {
+	attrs := pcommon.NewMap()
+	attrs.PutStr("str", "v")
+	attrs.PutInt("int", 1)
+	attrs.PutBool("bool", true)
+	require.Equal(t, map[string]string{"str": "v", "int": "1", "bool": "true"}, AttributesToMap(attrs))
+}
+
+// unusedConnector fails the connections, which the canceled inserts must not open.
+type unusedConnector struct{}
+
+func (unusedConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("unexpected connection")
+}
+
+func (unusedConnector) Driver() driver.Driver {
+	return nil
+}
+
+func TestInsertRowsCanceled(t *testing.T) {
+	db := sql.OpenDB(unusedConnector{})
+	defer db.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := insertRows(ctx, db, "INSERT INTO otel_metrics_gauge (Value) VALUES (?)", 1, func(int) []interface{} {
+		return []interface{}{1}
+	})
+	require.ErrorIs(t, err, context.Canceled)
+}
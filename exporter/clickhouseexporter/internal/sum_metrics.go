@@ -0,0 +1,83 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// language=ClickHouse SQL
+const sumColumnsSQL = commonColumnsSQL + `
+    Value Float64 CODEC(ZSTD(1)),
+    Flags UInt32 CODEC(ZSTD(1)),` + exemplarsColumnSQL + `
+    AggTemp Int32 CODEC(ZSTD(1)),
+    IsMonotonic Boolean CODEC(Delta, ZSTD(1)),`
+
+var sumInsertColumns = append(append(append(append([]string{}, commonInsertColumns...),
+	"Value",
+	"Flags",
+), exemplarsInsertColumns...),
+	"AggTemp",
+	"IsMonotonic",
+)
+
+type sumRecord struct {
+	metricsRecord
+	aggTemp     pmetric.AggregationTemporality
+	isMonotonic bool
+	dp          pmetric.NumberDataPoint
+}
+
+type sumMetrics struct {
+	insertSQL string
+	records   []sumRecord
+}
+
+func (s *sumMetrics) Add(metaData *MetricsMetaData, metric pmetric.Metric) {
+	record := newMetricsRecord(metaData, metric)
+	sum := metric.Sum()
+	dps := sum.DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		s.records = append(s.records, sumRecord{
+			metricsRecord: record,
+			aggTemp:       sum.AggregationTemporality(),
+			isMonotonic:   sum.IsMonotonic(),
+			dp:            dps.At(i),
+		})
+	}
+}
+
+func (s *sumMetrics) insert(ctx context.Context, db *sql.DB) error {
+	return insertRows(ctx, db, s.insertSQL, len(s.records), func(i int) []interface{} {
+		r := s.records[i]
+		args := r.args(r.dp.Attributes(), r.dp.StartTimestamp(), r.dp.Timestamp())
+		args = append(args,
+			numberValue(r.dp),
+			uint32(r.dp.Flags()),
+		)
+		args = append(args, convertExemplars(r.dp.Exemplars())...)
+		return append(args,
+			int32(r.aggTemp),
+			r.isMonotonic,
+		)
+	})
+}
+
+func (s *sumMetrics) count() int {
+	return len(s.records)
+}
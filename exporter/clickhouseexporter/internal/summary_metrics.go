@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/clickhouseexporter/internal"
+
+import (
+	"context"
+	"database/sql"
+
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// language=ClickHouse SQL
+const summaryColumnsSQL = commonColumnsSQL + `
+    Count UInt64 CODEC(Delta, ZSTD(1)),
+    Sum Float64 CODEC(ZSTD(1)),
+    ValueAtQuantiles Nested(
+        Quantile Float64,
+        Value Float64
+    ) CODEC(ZSTD(1)),
+    Flags UInt32 CODEC(ZSTD(1)),`
+
+var summaryInsertColumns = append(append([]string{}, commonInsertColumns...),
+	"Count",
+	"Sum",
+	"ValueAtQuantiles.Quantile",
+	"ValueAtQuantiles.Value",
+	"Flags",
+)
+
+type summaryRecord struct {
+	metricsRecord
+	dp pmetric.SummaryDataPoint
+}
+
+type summaryMetrics struct {
+	insertSQL string
+	records   []summaryRecord
+}
+
+func (s *summaryMetrics) Add(metaData *MetricsMetaData, metric pmetric.Metric) {
+	record := newMetricsRecord(metaData, metric)
+	dps := metric.Summary().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		s.records = append(s.records, summaryRecord{metricsRecord: record, dp: dps.At(i)})
+	}
+}
+
+func (s *summaryMetrics) insert(ctx context.Context, db *sql.DB) error {
+	return insertRows(ctx, db, s.insertSQL, len(s.records), func(i int) []interface{} {
+		r := s.records[i]
+		quantiles, values := convertValueAtQuantiles(r.dp.QuantileValues())
+		args := r.args(r.dp.Attributes(), r.dp.StartTimestamp(), r.dp.Timestamp())
+		return append(args,
+			r.dp.Count(),
+			r.dp.Sum(),
+			quantiles,
+			values,
+			uint32(r.dp.Flags()),
+		)
+	})
+}
+
+func (s *summaryMetrics) count() int {
+	return len(s.records)
+}
+
+func convertValueAtQuantiles(valueAtQuantiles pmetric.SummaryDataPointValueAtQuantileSlice) ([]float64, []float64) {
+	var (
+		quantiles []float64
+		values    []float64
+	)
+	for i := 0; i < valueAtQuantiles.Len(); i++ {
+		value := valueAtQuantiles.At(i)
+		quantiles = append(quantiles, value.Quantile())
+		values = append(values, value.Value())
+	}
+	return quantiles, values
+}
@@ -53,9 +53,24 @@ type Config struct {
 	// This setting is required when logging pipelines used.
 	LogsIndex string `mapstructure:"logs_index"`
 
+	// LogsDynamicIndex configures routing of log records to data streams based on
+	// the `data_stream.dataset` and `data_stream.namespace` attributes.
+	LogsDynamicIndex DynamicIndexSetting `mapstructure:"logs_dynamic_index"`
+
+	// This setting is required when metrics pipelines used.
+	MetricsIndex string `mapstructure:"metrics_index"`
+
+	// MetricsDynamicIndex configures routing of metric data points to data streams based on
+	// the `data_stream.dataset` and `data_stream.namespace` attributes.
+	MetricsDynamicIndex DynamicIndexSetting `mapstructure:"metrics_dynamic_index"`
+
 	// This setting is required when traces pipelines used.
 	TracesIndex string `mapstructure:"traces_index"`
 
+	// TracesDynamicIndex configures routing of spans to data streams based on
+	// the `data_stream.dataset` and `data_stream.namespace` attributes.
+	TracesDynamicIndex DynamicIndexSetting `mapstructure:"traces_dynamic_index"`
+
 	// Pipeline configures the ingest node pipeline name that should be used to process the
 	// events.
 	//
@@ -88,6 +103,18 @@ type HTTPClientSettings struct {
 	configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
 }
 
+// DynamicIndexSetting configures the dynamic index mode of a signal.
+//
+// When enabled, the static index of the signal is ignored and every event is indexed into
+// the data stream `<type>-<dataset>-<namespace>`. The dataset and namespace are read from the
+// `data_stream.dataset` and `data_stream.namespace` attributes of the record, its scope or its
+// resource, in that order, and default to `generic` and `default`.
+//
+// https://www.elastic.co/guide/en/fleet/current/data-streams.html#data-streams-naming-scheme
+type DynamicIndexSetting struct {
+	Enabled bool `mapstructure:"enabled"`
+}
+
 // AuthenticationSettings defines user authentication related settings.
 type AuthenticationSettings struct {
 	// User is used to configure HTTP Basic Authentication.
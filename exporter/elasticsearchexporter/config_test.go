@@ -36,12 +36,13 @@ func TestLoad_DeprecatedIndexConfigOption(t *testing.T) {
 	require.NoError(t, component.UnmarshalConfig(sub, cfg))
 
 	assert.Equal(t, cfg, &Config{
-		Endpoints:   []string{"http://localhost:9200"},
-		CloudID:     "TRNMxjXlNJEt",
-		Index:       "my_log_index",
-		LogsIndex:   "logs-generic-default",
-		TracesIndex: "traces-generic-default",
-		Pipeline:    "mypipeline",
+		Endpoints:    []string{"http://localhost:9200"},
+		CloudID:      "TRNMxjXlNJEt",
+		Index:        "my_log_index",
+		LogsIndex:    "logs-generic-default",
+		MetricsIndex: "metrics-generic-default",
+		TracesIndex:  "traces-generic-default",
+		Pipeline:     "mypipeline",
 		HTTPClientSettings: HTTPClientSettings{
 			Authentication: AuthenticationSettings{
 				User:     "elastic",
@@ -93,12 +94,13 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, "trace"),
 			expected: &Config{
-				Endpoints:   []string{"https://elastic.example.com:9200"},
-				CloudID:     "TRNMxjXlNJEt",
-				Index:       "",
-				LogsIndex:   "logs-generic-default",
-				TracesIndex: "trace_index",
-				Pipeline:    "mypipeline",
+				Endpoints:    []string{"https://elastic.example.com:9200"},
+				CloudID:      "TRNMxjXlNJEt",
+				Index:        "",
+				LogsIndex:    "logs-generic-default",
+				MetricsIndex: "metrics-generic-default",
+				TracesIndex:  "trace_index",
+				Pipeline:     "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -132,12 +134,13 @@ func TestLoadConfig(t *testing.T) {
 		{
 			id: component.NewIDWithName(typeStr, "log"),
 			expected: &Config{
-				Endpoints:   []string{"http://localhost:9200"},
-				CloudID:     "TRNMxjXlNJEt",
-				Index:       "",
-				LogsIndex:   "my_log_index",
-				TracesIndex: "traces-generic-default",
-				Pipeline:    "mypipeline",
+				Endpoints:    []string{"http://localhost:9200"},
+				CloudID:      "TRNMxjXlNJEt",
+				Index:        "",
+				LogsIndex:    "my_log_index",
+				MetricsIndex: "metrics-generic-default",
+				TracesIndex:  "traces-generic-default",
+				Pipeline:     "mypipeline",
 				HTTPClientSettings: HTTPClientSettings{
 					Authentication: AuthenticationSettings{
 						User:     "elastic",
@@ -168,6 +171,16 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "metric"),
+			expected: withDefaultConfig(func(cfg *Config) {
+				cfg.Endpoints = []string{"http://localhost:9200"}
+				cfg.MetricsIndex = "my_metric_index"
+				cfg.LogsDynamicIndex.Enabled = true
+				cfg.MetricsDynamicIndex.Enabled = true
+				cfg.TracesDynamicIndex.Enabled = true
+			}),
+		},
 	}
 
 	for _, tt := range tests {
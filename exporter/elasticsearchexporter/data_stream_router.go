@@ -0,0 +1,103 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
+)
+
+const (
+	dataStreamTypeAttribute      = "data_stream.type"
+	dataStreamDatasetAttribute   = "data_stream.dataset"
+	dataStreamNamespaceAttribute = "data_stream.namespace"
+
+	dataStreamTypeLogs    = "logs"
+	dataStreamTypeMetrics = "metrics"
+	dataStreamTypeTraces  = "traces"
+
+	defaultDataStreamDataset   = "generic"
+	defaultDataStreamNamespace = "default"
+
+	// maxDataStreamFieldLength is the maximum length of the dataset and namespace,
+	// keeping the resulting index name well below the 255 bytes limit of Elasticsearch.
+	maxDataStreamFieldLength = 100
+)
+
+// dataStream identifies the data stream an event is routed to. The zero value
+// is used when dynamic indexing is disabled.
+type dataStream struct {
+	typ       string
+	dataset   string
+	namespace string
+}
+
+// routeDataStream resolves the data stream of an event of the given type. The dataset and
+// namespace are read from the first attribute map that contains them, so attributes of the
+// record take precedence over the attributes of its scope and resource.
+func routeDataStream(typ string, attributes ...pcommon.Map) dataStream {
+	return dataStream{
+		typ:       typ,
+		dataset:   lookupDataStreamField(dataStreamDatasetAttribute, defaultDataStreamDataset, attributes),
+		namespace: lookupDataStreamField(dataStreamNamespaceAttribute, defaultDataStreamNamespace, attributes),
+	}
+}
+
+func lookupDataStreamField(key string, defaultValue string, attributes []pcommon.Map) string {
+	for _, attrs := range attributes {
+		if v, ok := attrs.Get(key); ok {
+			if sanitized := sanitizeDataStreamField(v.AsString()); sanitized != "" {
+				return sanitized
+			}
+		}
+	}
+	return defaultValue
+}
+
+// sanitizeDataStreamField makes the value usable as part of a data stream name. Elasticsearch
+// requires lowercase names without `\/*?"<>|,#:` and spaces, and the data stream naming
+// scheme does not allow `-` in the dataset or namespace.
+func sanitizeDataStreamField(value string) string {
+	value = strings.Map(func(r rune) rune {
+		switch r {
+		case '\\', '/', '*', '?', '"', '<', '>', '|', ' ', ',', '#', ':', '-':
+			return '_'
+		}
+		return r
+	}, strings.ToLower(value))
+	if len(value) > maxDataStreamFieldLength {
+		value = value[:maxDataStreamFieldLength]
+	}
+	return value
+}
+
+// index returns the name of the data stream.
+func (ds dataStream) index() string {
+	return ds.typ + "-" + ds.dataset + "-" + ds.namespace
+}
+
+// addFields adds the data stream fields to the document, so that they match the target
+// data stream. Nothing is added for the zero value.
+func (ds dataStream) addFields(document *objmodel.Document) {
+	if ds.typ == "" {
+		return
+	}
+	document.AddString(dataStreamTypeAttribute, ds.typ)
+	document.AddString(dataStreamDatasetAttribute, ds.dataset)
+	document.AddString(dataStreamNamespaceAttribute, ds.namespace)
+}
@@ -0,0 +1,79 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestRouteDataStream(t *testing.T) {
+	newMap := func(kv ...string) pcommon.Map {
+		m := pcommon.NewMap()
+		for i := 0; i < len(kv); i += 2 {
+			m.PutStr(kv[i], kv[i+1])
+		}
+		return m
+	}
+
+	tests := map[string]struct {
+		attributes []pcommon.Map
+		want       string
+	}{
+		"defaults": {
+			attributes: []pcommon.Map{newMap(), newMap()},
+			want:       "logs-generic-default",
+		},
+		"from resource": {
+			attributes: []pcommon.Map{
+				newMap(),
+				newMap(dataStreamDatasetAttribute, "nginx", dataStreamNamespaceAttribute, "prod"),
+			},
+			want: "logs-nginx-prod",
+		},
+		"record takes precedence": {
+			attributes: []pcommon.Map{
+				newMap(dataStreamDatasetAttribute, "nginx.access"),
+				newMap(dataStreamDatasetAttribute, "nginx", dataStreamNamespaceAttribute, "prod"),
+			},
+			want: "logs-nginx.access-prod",
+		},
+		"sanitized": {
+			attributes: []pcommon.Map{
+				newMap(dataStreamDatasetAttribute, "My-App:Errors", dataStreamNamespaceAttribute, "team a"),
+			},
+			want: "logs-my_app_errors-team_a",
+		},
+		"empty value uses default": {
+			attributes: []pcommon.Map{
+				newMap(dataStreamDatasetAttribute, ""),
+			},
+			want: "logs-generic-default",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, test.want, routeDataStream(dataStreamTypeLogs, test.attributes...).index())
+		})
+	}
+}
+
+func TestSanitizeDataStreamField_MaxLength(t *testing.T) {
+	assert.Len(t, sanitizeDataStreamField(strings.Repeat("a", 200)), maxDataStreamFieldLength)
+}
@@ -26,9 +26,10 @@ import (
 
 const (
 	// The value of "type" key in configuration.
-	typeStr            = "elasticsearch"
-	defaultLogsIndex   = "logs-generic-default"
-	defaultTracesIndex = "traces-generic-default"
+	typeStr             = "elasticsearch"
+	defaultLogsIndex    = "logs-generic-default"
+	defaultMetricsIndex = "metrics-generic-default"
+	defaultTracesIndex  = "traces-generic-default"
 	// The stability level of the exporter.
 	stability = component.StabilityLevelBeta
 )
@@ -39,6 +40,7 @@ func NewFactory() exporter.Factory {
 		typeStr,
 		createDefaultConfig,
 		exporter.WithLogs(createLogsExporter, stability),
+		exporter.WithMetrics(createMetricsExporter, stability),
 		exporter.WithTraces(createTracesExporter, stability),
 	)
 }
@@ -48,9 +50,10 @@ func createDefaultConfig() component.Config {
 		HTTPClientSettings: HTTPClientSettings{
 			Timeout: 90 * time.Second,
 		},
-		Index:       "",
-		LogsIndex:   defaultLogsIndex,
-		TracesIndex: defaultTracesIndex,
+		Index:        "",
+		LogsIndex:    defaultLogsIndex,
+		MetricsIndex: defaultMetricsIndex,
+		TracesIndex:  defaultTracesIndex,
 		Retry: RetrySettings{
 			Enabled:         true,
 			MaxRequests:     3,
@@ -91,6 +94,29 @@ func createLogsExporter(
 	)
 }
 
+// createMetricsExporter creates a new exporter for metrics.
+//
+// Metric data points sharing the same timestamp and attributes are grouped into a
+// single document before being indexed into Elasticsearch.
+func createMetricsExporter(
+	ctx context.Context,
+	set exporter.CreateSettings,
+	cfg component.Config,
+) (exporter.Metrics, error) {
+	exporter, err := newMetricsExporter(set.Logger, cfg.(*Config))
+	if err != nil {
+		return nil, fmt.Errorf("cannot configure Elasticsearch metrics exporter: %w", err)
+	}
+
+	return exporterhelper.NewMetricsExporter(
+		ctx,
+		set,
+		cfg,
+		exporter.pushMetricsData,
+		exporterhelper.WithShutdown(exporter.Shutdown),
+	)
+}
+
 func createTracesExporter(ctx context.Context,
 	set exporter.CreateSettings,
 	cfg component.Config) (exporter.Traces, error) {
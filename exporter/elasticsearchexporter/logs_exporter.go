@@ -29,8 +29,9 @@ import (
 type elasticsearchLogsExporter struct {
 	logger *zap.Logger
 
-	index       string
-	maxAttempts int
+	index        string
+	dynamicIndex bool
+	maxAttempts  int
 
 	client      *esClientCurrent
 	bulkIndexer esBulkIndexerCurrent
@@ -69,12 +70,13 @@ func newLogsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchLogsExporte
 		indexStr = cfg.Index
 	}
 	esLogsExp := &elasticsearchLogsExporter{
-		logger:      logger,
-		client:      client,
-		bulkIndexer: bulkIndexer,
-		index:       indexStr,
-		maxAttempts: maxAttempts,
-		model:       model,
+		logger:       logger,
+		client:       client,
+		bulkIndexer:  bulkIndexer,
+		index:        indexStr,
+		dynamicIndex: cfg.LogsDynamicIndex.Enabled,
+		maxAttempts:  maxAttempts,
+		model:        model,
 	}
 	return esLogsExp, nil
 }
@@ -92,9 +94,10 @@ func (e *elasticsearchLogsExporter) pushLogsData(ctx context.Context, ld plog.Lo
 		resource := rl.Resource()
 		ills := rl.ScopeLogs()
 		for j := 0; j < ills.Len(); j++ {
+			scope := ills.At(j).Scope()
 			logs := ills.At(j).LogRecords()
 			for k := 0; k < logs.Len(); k++ {
-				if err := e.pushLogRecord(ctx, resource, logs.At(k)); err != nil {
+				if err := e.pushLogRecord(ctx, resource, scope, logs.At(k)); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
 						return cerr
 					}
@@ -108,10 +111,17 @@ func (e *elasticsearchLogsExporter) pushLogsData(ctx context.Context, ld plog.Lo
 	return multierr.Combine(errs...)
 }
 
-func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, record plog.LogRecord) error {
-	document, err := e.model.encodeLog(resource, record)
+func (e *elasticsearchLogsExporter) pushLogRecord(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, record plog.LogRecord) error {
+	var ds dataStream
+	index := e.index
+	if e.dynamicIndex {
+		ds = routeDataStream(dataStreamTypeLogs, record.Attributes(), scope.Attributes(), resource.Attributes())
+		index = ds.index()
+	}
+
+	document, err := e.model.encodeLog(resource, record, ds)
 	if err != nil {
 		return fmt.Errorf("Failed to encode log event: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
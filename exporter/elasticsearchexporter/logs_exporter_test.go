@@ -26,6 +26,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/plog"
 	"go.uber.org/atomic"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zaptest"
@@ -324,6 +325,39 @@ func TestExporter_PushEvent(t *testing.T) {
 	})
 }
 
+func TestExporter_PushLogsDataDynamicIndex(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+
+	rec := newBulkRecorder()
+	server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+		rec.Record(docs)
+		return itemsAllOK(docs)
+	})
+
+	exporter := newTestExporter(t, server.URL, func(cfg *Config) {
+		cfg.LogsDynamicIndex.Enabled = true
+	})
+
+	logs := plog.NewLogs()
+	rl := logs.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr(dataStreamNamespaceAttribute, "team1")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Attributes().PutStr(dataStreamDatasetAttribute, "nginx")
+	records.AppendEmpty()
+	require.NoError(t, exporter.pushLogsData(context.TODO(), logs))
+
+	rec.WaitItems(2)
+	var indices []string
+	for _, doc := range sortedDocuments(t, rec.Items()) {
+		indices = append(indices, doc.index)
+		assert.Equal(t, "logs", doc.fields[dataStreamTypeAttribute])
+		assert.Equal(t, "team1", doc.fields[dataStreamNamespaceAttribute])
+	}
+	assert.ElementsMatch(t, []string{"logs-nginx-team1", "logs-generic-team1"}, indices)
+}
+
 func newTestExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchLogsExporter {
 	exporter, err := newLogsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
 	require.NoError(t, err)
@@ -0,0 +1,334 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"sort"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
+)
+
+type elasticsearchMetricsExporter struct {
+	logger *zap.Logger
+
+	index        string
+	dynamicIndex bool
+	maxAttempts  int
+
+	client      *esClientCurrent
+	bulkIndexer esBulkIndexerCurrent
+	model       mappingModel
+}
+
+func newMetricsExporter(logger *zap.Logger, cfg *Config) (*elasticsearchMetricsExporter, error) {
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+
+	client, err := newElasticsearchClient(logger, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	bulkIndexer, err := newBulkIndexer(logger, client, cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	maxAttempts := 1
+	if cfg.Retry.Enabled {
+		maxAttempts = cfg.Retry.MaxRequests
+	}
+
+	// TODO: Apply encoding and field mapping settings.
+	model := &encodeModel{dedup: true, dedot: false}
+
+	return &elasticsearchMetricsExporter{
+		logger:      logger,
+		client:      client,
+		bulkIndexer: bulkIndexer,
+
+		index:        cfg.MetricsIndex,
+		dynamicIndex: cfg.MetricsDynamicIndex.Enabled,
+		maxAttempts:  maxAttempts,
+		model:        model,
+	}, nil
+}
+
+func (e *elasticsearchMetricsExporter) Shutdown(ctx context.Context) error {
+	return e.bulkIndexer.Close(ctx)
+}
+
+func (e *elasticsearchMetricsExporter) pushMetricsData(ctx context.Context, md pmetric.Metrics) error {
+	var errs []error
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		rm := rms.At(i)
+		resource := rm.Resource()
+
+		documents := newMetricsDocuments()
+		scopeMetrics := rm.ScopeMetrics()
+		for j := 0; j < scopeMetrics.Len(); j++ {
+			scope := scopeMetrics.At(j).Scope()
+			metrics := scopeMetrics.At(j).Metrics()
+			for k := 0; k < metrics.Len(); k++ {
+				e.addMetric(documents, resource, scope, metrics.At(k))
+			}
+		}
+
+		for _, document := range documents.sorted() {
+			if err := e.pushMetricsDocument(ctx, resource, document); err != nil {
+				if cerr := ctx.Err(); cerr != nil {
+					return cerr
+				}
+				errs = append(errs, err)
+			}
+		}
+	}
+
+	return multierr.Combine(errs...)
+}
+
+func (e *elasticsearchMetricsExporter) addMetric(documents *metricsDocuments, resource pcommon.Resource, scope pcommon.InstrumentationScope, metric pmetric.Metric) {
+	add := func(timestamp pcommon.Timestamp, attributes pcommon.Map, value objmodel.Value) {
+		var ds dataStream
+		index := e.index
+		if e.dynamicIndex {
+			ds = routeDataStream(dataStreamTypeMetrics, attributes, scope.Attributes(), resource.Attributes())
+			index = ds.index()
+		}
+		documents.add(index, ds, timestamp, attributes, metric.Name(), value)
+	}
+
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			add(dp.Timestamp(), dp.Attributes(), numberValue(dp))
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			add(dp.Timestamp(), dp.Attributes(), numberValue(dp))
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if value, ok := histogramValue(dp); ok {
+				add(dp.Timestamp(), dp.Attributes(), value)
+			}
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			if value, ok := exponentialHistogramValue(dp); ok {
+				add(dp.Timestamp(), dp.Attributes(), value)
+			}
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			add(dp.Timestamp(), dp.Attributes(), summaryValue(dp))
+		}
+	default:
+		e.logger.Debug("Drop metric: unsupported metric type",
+			zap.String("name", metric.Name()),
+			zap.String("type", metric.Type().String()))
+	}
+}
+
+func (e *elasticsearchMetricsExporter) pushMetricsDocument(ctx context.Context, resource pcommon.Resource, document *metricsDocument) error {
+	body, err := e.model.encodeMetrics(resource, document)
+	if err != nil {
+		return fmt.Errorf("Failed to encode metrics: %w", err)
+	}
+	return pushDocuments(ctx, e.logger, document.index, body, e.bulkIndexer, e.maxAttempts)
+}
+
+// metricsDocument holds the values of all metrics of a resource that share the same
+// index, timestamp and attributes.
+type metricsDocument struct {
+	index      string
+	dataStream dataStream
+	timestamp  pcommon.Timestamp
+	attributes pcommon.Map
+	fields     []metricField
+
+	key string
+}
+
+type metricField struct {
+	name  string
+	value objmodel.Value
+}
+
+// metricsDocuments groups metric data points into documents.
+type metricsDocuments struct {
+	documents map[string]*metricsDocument
+}
+
+func newMetricsDocuments() *metricsDocuments {
+	return &metricsDocuments{documents: map[string]*metricsDocument{}}
+}
+
+func (d *metricsDocuments) add(index string, ds dataStream, timestamp pcommon.Timestamp, attributes pcommon.Map, name string, value objmodel.Value) {
+	key := metricsDocumentKey(index, timestamp, attributes)
+	document, ok := d.documents[key]
+	if !ok {
+		document = &metricsDocument{
+			index:      index,
+			dataStream: ds,
+			timestamp:  timestamp,
+			attributes: attributes,
+			key:        key,
+		}
+		d.documents[key] = document
+	}
+	document.fields = append(document.fields, metricField{name: name, value: value})
+}
+
+// sorted returns the documents in a stable order.
+func (d *metricsDocuments) sorted() []*metricsDocument {
+	documents := make([]*metricsDocument, 0, len(d.documents))
+	for _, document := range d.documents {
+		documents = append(documents, document)
+	}
+	sort.Slice(documents, func(i, j int) bool {
+		return documents[i].key < documents[j].key
+	})
+	return documents
+}
+
+func metricsDocumentKey(index string, timestamp pcommon.Timestamp, attributes pcommon.Map) string {
+	// encoding/json sorts map keys, so the encoding does not depend on the attributes order.
+	attrs, _ := json.Marshal(attributes.AsRaw())
+	return fmt.Sprintf("%s\x00%d\x00%s", index, timestamp, attrs)
+}
+
+func numberValue(dp pmetric.NumberDataPoint) objmodel.Value {
+	switch dp.ValueType() {
+	case pmetric.NumberDataPointValueTypeInt:
+		return objmodel.IntValue(dp.IntValue())
+	case pmetric.NumberDataPointValueTypeDouble:
+		return objmodel.DoubleValue(dp.DoubleValue())
+	}
+	return objmodel.Value{}
+}
+
+// histogramValue converts an explicit bucket histogram into the format of the Elasticsearch
+// histogram field type. Each bucket is represented by its midpoint, the overflow
+// bucket by the last explicit bound.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/histogram.html
+func histogramValue(dp pmetric.HistogramDataPoint) (objmodel.Value, bool) {
+	bucketCounts := dp.BucketCounts()
+	explicitBounds := dp.ExplicitBounds()
+	if bucketCounts.Len() == 0 {
+		return objmodel.Value{}, false
+	}
+
+	var values, counts []objmodel.Value
+	for i := 0; i < bucketCounts.Len(); i++ {
+		count := bucketCounts.At(i)
+		if count == 0 {
+			continue
+		}
+
+		var value float64
+		switch {
+		case explicitBounds.Len() == 0:
+			// A single bucket without bounds, fall back to the mean.
+			value = dp.Sum() / float64(dp.Count())
+		case i == 0:
+			value = explicitBounds.At(0)
+			if value > 0 {
+				value /= 2
+			}
+		case i >= explicitBounds.Len():
+			value = explicitBounds.At(explicitBounds.Len() - 1)
+		default:
+			value = explicitBounds.At(i-1) + (explicitBounds.At(i)-explicitBounds.At(i-1))/2
+		}
+		values = append(values, objmodel.DoubleValue(value))
+		counts = append(counts, objmodel.IntValue(int64(count)))
+	}
+	return histogramFieldValue(values, counts)
+}
+
+// exponentialHistogramValue converts an exponential histogram into the format of the
+// Elasticsearch histogram field type, representing each bucket by its midpoint.
+func exponentialHistogramValue(dp pmetric.ExponentialHistogramDataPoint) (objmodel.Value, bool) {
+	base := math.Exp2(math.Exp2(-float64(dp.Scale())))
+	midpoint := func(index int32) float64 {
+		lower := math.Pow(base, float64(index))
+		return lower + (lower*base-lower)/2
+	}
+
+	var values, counts []objmodel.Value
+	negative := dp.Negative()
+	for i := negative.BucketCounts().Len() - 1; i >= 0; i-- {
+		if count := negative.BucketCounts().At(i); count > 0 {
+			values = append(values, objmodel.DoubleValue(-midpoint(negative.Offset()+int32(i))))
+			counts = append(counts, objmodel.IntValue(int64(count)))
+		}
+	}
+	if dp.ZeroCount() > 0 {
+		values = append(values, objmodel.DoubleValue(0))
+		counts = append(counts, objmodel.IntValue(int64(dp.ZeroCount())))
+	}
+	positive := dp.Positive()
+	for i := 0; i < positive.BucketCounts().Len(); i++ {
+		if count := positive.BucketCounts().At(i); count > 0 {
+			values = append(values, objmodel.DoubleValue(midpoint(positive.Offset()+int32(i))))
+			counts = append(counts, objmodel.IntValue(int64(count)))
+		}
+	}
+	return histogramFieldValue(values, counts)
+}
+
+func histogramFieldValue(values, counts []objmodel.Value) (objmodel.Value, bool) {
+	if len(values) == 0 {
+		return objmodel.Value{}, false
+	}
+	var document objmodel.Document
+	document.Add("values", objmodel.ArrValue(values...))
+	document.Add("counts", objmodel.ArrValue(counts...))
+	return objmodel.ObjectValue(document), true
+}
+
+// summaryValue converts a summary into the format of the Elasticsearch aggregate_metric_double
+// field type.
+//
+// https://www.elastic.co/guide/en/elasticsearch/reference/current/aggregate-metric-double.html
+func summaryValue(dp pmetric.SummaryDataPoint) objmodel.Value {
+	var document objmodel.Document
+	document.AddDouble("sum", dp.Sum())
+	document.AddInt("value_count", int64(dp.Count()))
+	return objmodel.ObjectValue(document)
+}
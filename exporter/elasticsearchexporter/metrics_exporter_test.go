@@ -0,0 +1,223 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package elasticsearchexporter
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/zap/zaptest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter/internal/objmodel"
+)
+
+func TestExporter_PushMetricsData(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("skipping test on Windows, see https://github.com/open-telemetry/opentelemetry-collector-contrib/issues/10178")
+	}
+
+	t.Run("group data points into documents", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL)
+		mustSendMetrics(t, exporter, testMetrics())
+
+		rec.WaitItems(2)
+		docs := sortedDocuments(t, rec.Items())
+		require.Len(t, docs, 2)
+
+		for _, doc := range docs {
+			assert.Equal(t, "metrics-generic-default", doc.index)
+		}
+		assert.Equal(t, map[string]interface{}{
+			"@timestamp":          "1970-01-01T00:00:01.000000000Z",
+			"Attributes.state":    "idle",
+			"Resource.host.name":  "host1",
+			"system.cpu.time":     1.5,
+			"system.cpu.count":    float64(4),
+			"Attributes.cpu":      "cpu0",
+			"http.server.latency": map[string]interface{}{"values": []interface{}{0.5, 3.5, 5.0}, "counts": []interface{}{float64(1), float64(2), float64(3)}},
+			"http.server.summary": map[string]interface{}{"sum": float64(10), "value_count": float64(2)},
+		}, docs[0].fields)
+		assert.Equal(t, map[string]interface{}{
+			"@timestamp":         "1970-01-01T00:00:01.000000000Z",
+			"Attributes.state":   "busy",
+			"Resource.host.name": "host1",
+			"system.cpu.time":    2.5,
+		}, docs[1].fields)
+	})
+
+	t.Run("dynamic index", func(t *testing.T) {
+		rec := newBulkRecorder()
+		server := newESTestServer(t, func(docs []itemRequest) ([]itemResponse, error) {
+			rec.Record(docs)
+			return itemsAllOK(docs)
+		})
+
+		exporter := newTestMetricsExporter(t, server.URL, func(cfg *Config) {
+			cfg.MetricsDynamicIndex.Enabled = true
+		})
+		md := testMetrics()
+		md.ResourceMetrics().At(0).Resource().Attributes().PutStr(dataStreamNamespaceAttribute, "Team-A")
+		md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints().At(1).
+			Attributes().PutStr(dataStreamDatasetAttribute, "cpu")
+		mustSendMetrics(t, exporter, md)
+
+		rec.WaitItems(2)
+		var indices []string
+		for _, doc := range sortedDocuments(t, rec.Items()) {
+			indices = append(indices, doc.index)
+			assert.Equal(t, "metrics", doc.fields[dataStreamTypeAttribute])
+			assert.Equal(t, "team_a", doc.fields[dataStreamNamespaceAttribute])
+		}
+		assert.ElementsMatch(t, []string{"metrics-generic-team_a", "metrics-cpu-team_a"}, indices)
+	})
+}
+
+func TestExponentialHistogramValue(t *testing.T) {
+	dp := pmetric.NewExponentialHistogramDataPoint()
+	dp.SetScale(0)
+	dp.SetZeroCount(1)
+	dp.Positive().SetOffset(0)
+	dp.Positive().BucketCounts().FromRaw([]uint64{2, 0, 3})
+	dp.Negative().SetOffset(1)
+	dp.Negative().BucketCounts().FromRaw([]uint64{4})
+
+	value, ok := exponentialHistogramValue(dp)
+	require.True(t, ok)
+
+	assert.Equal(t, map[string]interface{}{
+		"values": []interface{}{-3.0, 0.0, 1.5, 6.0},
+		"counts": []interface{}{float64(4), float64(1), float64(2), float64(3)},
+	}, encodeValue(t, value))
+}
+
+func TestHistogramValue_Empty(t *testing.T) {
+	_, ok := histogramValue(pmetric.NewHistogramDataPoint())
+	assert.False(t, ok)
+}
+
+type testDocument struct {
+	index  string
+	fields map[string]interface{}
+}
+
+// sortedDocuments decodes the bulk items and sorts them by the number of fields, descending.
+func sortedDocuments(t *testing.T, items []itemRequest) []testDocument {
+	var docs []testDocument
+	for _, item := range items {
+		var action struct {
+			Create struct {
+				Index string `json:"_index"`
+			} `json:"create"`
+		}
+		require.NoError(t, json.Unmarshal(item.Action, &action))
+		var fields map[string]interface{}
+		require.NoError(t, json.Unmarshal(item.Document, &fields))
+		docs = append(docs, testDocument{index: action.Create.Index, fields: fields})
+	}
+	sort.Slice(docs, func(i, j int) bool {
+		return len(docs[i].fields) > len(docs[j].fields)
+	})
+	return docs
+}
+
+func encodeValue(t *testing.T, value objmodel.Value) interface{} {
+	var document objmodel.Document
+	document.Add("value", value)
+	var buf bytes.Buffer
+	require.NoError(t, document.Serialize(&buf, false))
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &fields))
+	return fields["value"]
+}
+
+func testMetrics() pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("host.name", "host1")
+	metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+	ts := pcommon.NewTimestampFromTime(time.Unix(1, 0))
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("system.cpu.time")
+	sum.SetEmptySum().SetIsMonotonic(true)
+	dp := sum.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(1.5)
+	dp.Attributes().PutStr("cpu", "cpu0")
+	dp.Attributes().PutStr("state", "idle")
+	dp = sum.Sum().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetDoubleValue(2.5)
+	dp.Attributes().PutStr("state", "busy")
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("system.cpu.count")
+	dp = gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(4)
+	dp.Attributes().PutStr("state", "idle")
+	dp.Attributes().PutStr("cpu", "cpu0")
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("http.server.latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetTimestamp(ts)
+	hdp.BucketCounts().FromRaw([]uint64{1, 0, 2, 3})
+	hdp.ExplicitBounds().FromRaw([]float64{1, 2, 5})
+	hdp.Attributes().PutStr("cpu", "cpu0")
+	hdp.Attributes().PutStr("state", "idle")
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("http.server.summary")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetTimestamp(ts)
+	sdp.SetCount(2)
+	sdp.SetSum(10)
+	sdp.Attributes().PutStr("cpu", "cpu0")
+	sdp.Attributes().PutStr("state", "idle")
+
+	return md
+}
+
+func newTestMetricsExporter(t *testing.T, url string, fns ...func(*Config)) *elasticsearchMetricsExporter {
+	exporter, err := newMetricsExporter(zaptest.NewLogger(t), withTestExporterConfig(fns...)(url))
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		require.NoError(t, exporter.Shutdown(context.TODO()))
+	})
+	return exporter
+}
+
+func mustSendMetrics(t *testing.T, exporter *elasticsearchMetricsExporter, md pmetric.Metrics) {
+	err := exporter.pushMetricsData(context.TODO(), md)
+	require.NoError(t, err)
+}
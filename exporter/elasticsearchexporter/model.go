@@ -27,8 +27,9 @@ import (
 )
 
 type mappingModel interface {
-	encodeLog(pcommon.Resource, plog.LogRecord) ([]byte, error)
-	encodeSpan(pcommon.Resource, ptrace.Span) ([]byte, error)
+	encodeLog(pcommon.Resource, plog.LogRecord, dataStream) ([]byte, error)
+	encodeSpan(pcommon.Resource, ptrace.Span, dataStream) ([]byte, error)
+	encodeMetrics(pcommon.Resource, *metricsDocument) ([]byte, error)
 }
 
 // encodeModel tries to keep the event as close to the original open telemetry semantics as is.
@@ -48,7 +49,7 @@ const (
 	attributeField = "attribute"
 )
 
-func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord) ([]byte, error) {
+func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord, ds dataStream) ([]byte, error) {
 	var document objmodel.Document
 	document.AddTimestamp("@timestamp", record.Timestamp()) // We use @timestamp in order to ensure that we can index if the default data stream logs template is used.
 	document.AddTraceID("TraceId", record.TraceID())
@@ -59,19 +60,12 @@ func (m *encodeModel) encodeLog(resource pcommon.Resource, record plog.LogRecord
 	document.AddAttribute("Body", record.Body())
 	document.AddAttributes("Attributes", record.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
+	ds.addFields(&document)
 
-	if m.dedup {
-		document.Dedup()
-	} else if m.dedot {
-		document.Sort()
-	}
-
-	var buf bytes.Buffer
-	err := document.Serialize(&buf, m.dedot)
-	return buf.Bytes(), err
+	return m.serialize(document)
 }
 
-func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([]byte, error) {
+func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span, ds dataStream) ([]byte, error) {
 	var document objmodel.Document
 	document.AddTimestamp("@timestamp", span.StartTimestamp()) // We use @timestamp in order to ensure that we can index if the default data stream logs template is used.
 	document.AddTimestamp("EndTimestamp", span.EndTimestamp())
@@ -84,7 +78,28 @@ func (m *encodeModel) encodeSpan(resource pcommon.Resource, span ptrace.Span) ([
 	document.AddString("Link", spanLinksToString(span.Links()))
 	document.AddAttributes("Attributes", span.Attributes())
 	document.AddAttributes("Resource", resource.Attributes())
+	ds.addFields(&document)
+
+	return m.serialize(document)
+}
+
+// encodeMetrics encodes all metric values sharing the timestamp and attributes of the
+// metricsDocument. Each metric is added as a field named after the metric, which makes the
+// document suitable for time series data streams (TSDB).
+func (m *encodeModel) encodeMetrics(resource pcommon.Resource, metrics *metricsDocument) ([]byte, error) {
+	var document objmodel.Document
+	document.AddTimestamp("@timestamp", metrics.timestamp)
+	document.AddAttributes("Attributes", metrics.attributes)
+	document.AddAttributes("Resource", resource.Attributes())
+	metrics.dataStream.addFields(&document)
+	for _, f := range metrics.fields {
+		document.Add(f.name, f.value)
+	}
+
+	return m.serialize(document)
+}
 
+func (m *encodeModel) serialize(document objmodel.Document) ([]byte, error) {
 	if m.dedup {
 		document.Dedup()
 	} else if m.dedot {
@@ -29,8 +29,9 @@ import (
 type elasticsearchTracesExporter struct {
 	logger *zap.Logger
 
-	index       string
-	maxAttempts int
+	index        string
+	dynamicIndex bool
+	maxAttempts  int
 
 	client      *esClientCurrent
 	bulkIndexer esBulkIndexerCurrent
@@ -65,9 +66,10 @@ func newTracesExporter(logger *zap.Logger, cfg *Config) (*elasticsearchTracesExp
 		client:      client,
 		bulkIndexer: bulkIndexer,
 
-		index:       cfg.TracesIndex,
-		maxAttempts: maxAttempts,
-		model:       model,
+		index:        cfg.TracesIndex,
+		dynamicIndex: cfg.TracesDynamicIndex.Enabled,
+		maxAttempts:  maxAttempts,
+		model:        model,
 	}, nil
 }
 
@@ -86,9 +88,10 @@ func (e *elasticsearchTracesExporter) pushTraceData(
 		resource := il.Resource()
 		scopeSpans := il.ScopeSpans()
 		for j := 0; j < scopeSpans.Len(); j++ {
+			scope := scopeSpans.At(j).Scope()
 			spans := scopeSpans.At(j).Spans()
 			for k := 0; k < spans.Len(); k++ {
-				if err := e.pushTraceRecord(ctx, resource, spans.At(k)); err != nil {
+				if err := e.pushTraceRecord(ctx, resource, scope, spans.At(k)); err != nil {
 					if cerr := ctx.Err(); cerr != nil {
 						return cerr
 					}
@@ -101,10 +104,17 @@ func (e *elasticsearchTracesExporter) pushTraceData(
 	return multierr.Combine(errs...)
 }
 
-func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, span ptrace.Span) error {
-	document, err := e.model.encodeSpan(resource, span)
+func (e *elasticsearchTracesExporter) pushTraceRecord(ctx context.Context, resource pcommon.Resource, scope pcommon.InstrumentationScope, span ptrace.Span) error {
+	var ds dataStream
+	index := e.index
+	if e.dynamicIndex {
+		ds = routeDataStream(dataStreamTypeTraces, span.Attributes(), scope.Attributes(), resource.Attributes())
+		index = ds.index()
+	}
+
+	document, err := e.model.encodeSpan(resource, span, ds)
 	if err != nil {
 		return fmt.Errorf("Failed to encode trace record: %w", err)
 	}
-	return pushDocuments(ctx, e.logger, e.index, document, e.bulkIndexer, e.maxAttempts)
+	return pushDocuments(ctx, e.logger, index, document, e.bulkIndexer, e.maxAttempts)
 }
@@ -0,0 +1,127 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// MessageMode sends every log record as its own event.
+	MessageMode = "message"
+	// ForwardMode sends the log records sharing a tag as a single event.
+	ForwardMode = "forward"
+	// PackedForwardMode sends the log records sharing a tag as a single event
+	// holding the entries as a binary blob, optionally compressed.
+	PackedForwardMode = "packed_forward"
+
+	// CompressionGzip compresses the entries of the packed forward events with gzip.
+	CompressionGzip = "gzip"
+
+	defaultTag = "otelcol"
+)
+
+// Config defines configuration for the Fluent Forward exporter.
+type Config struct {
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the host and port of the fluentd or fluent-bit server.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// TLSSetting enables TLS on the connection when set.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// Mode is the carrier mode of the events: "message", "forward" or "packed_forward".
+	Mode string `mapstructure:"mode"`
+
+	// Compression compresses the entries of the packed forward events, only "gzip" is supported.
+	Compression string `mapstructure:"compression"`
+
+	// Tag is the template of the tag of the events, e.g. `app.{{ attributes["service"] }}`.
+	// The expressions between double braces can be `attributes["<name>"]` for a log record
+	// attribute or `resource["<name>"]` for a resource attribute.
+	Tag string `mapstructure:"tag"`
+
+	// DefaultTag is the tag of the events whose tag template renders as an empty string.
+	DefaultTag string `mapstructure:"default_tag"`
+
+	// RequireAck sets the `chunk` option on the events and waits for the server to acknowledge them.
+	RequireAck bool `mapstructure:"require_ack"`
+
+	// Security enables the shared key handshake when set.
+	Security *SecurityConfig `mapstructure:"security,omitempty"`
+}
+
+// SecurityConfig defines the credentials used to authenticate with the server during the handshake.
+type SecurityConfig struct {
+	// SharedKey is the key shared with the server.
+	SharedKey configopaque.String `mapstructure:"shared_key"`
+
+	// SelfHostname is the hostname sent to the server, it defaults to the hostname of the machine.
+	SelfHostname string `mapstructure:"self_hostname"`
+
+	// Username and Password authenticate the client when the server requires user authentication.
+	Username string              `mapstructure:"username"`
+	Password configopaque.String `mapstructure:"password"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" must be specified")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid \"endpoint\": %w", err)
+	}
+
+	switch cfg.Mode {
+	case MessageMode, ForwardMode:
+		if cfg.Compression != "" {
+			return fmt.Errorf("compression is only supported by the '%s' mode", PackedForwardMode)
+		}
+	case PackedForwardMode:
+		if cfg.Compression != "" && cfg.Compression != CompressionGzip {
+			return fmt.Errorf("invalid compression '%s', only 'gzip' is supported", cfg.Compression)
+		}
+	default:
+		return fmt.Errorf("invalid mode '%s', must be one of 'message', 'forward' or 'packed_forward'", cfg.Mode)
+	}
+
+	if _, err := parseTagTemplate(cfg.Tag); err != nil {
+		return fmt.Errorf("invalid \"tag\": %w", err)
+	}
+	if cfg.DefaultTag == "" {
+		return errors.New("\"default_tag\" must not be empty")
+	}
+
+	if cfg.Security != nil {
+		if cfg.Security.SharedKey == "" {
+			return errors.New("\"security.shared_key\" must be specified")
+		}
+		if cfg.Security.Password != "" && cfg.Security.Username == "" {
+			return errors.New("\"security.username\" must be specified with \"security.password\"")
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,185 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(typeStr, ""),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = "fluentd.example.com:24224"
+				return cfg
+			}(),
+		},
+		{
+			id: component.NewIDWithName(typeStr, "allsettings"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutSettings{
+					Timeout: 10 * time.Second,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 2,
+					QueueSize:    10,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:         true,
+					InitialInterval: 10 * time.Second,
+					MaxInterval:     1 * time.Minute,
+					MaxElapsedTime:  10 * time.Minute,
+				},
+				Endpoint: "fluentd.example.com:24224",
+				TLSSetting: &configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "/var/lib/mycert.pem",
+					},
+					ServerName: "fluentd.example.com",
+				},
+				Mode:        PackedForwardMode,
+				Compression: CompressionGzip,
+				Tag:         `app.{{ resource["service.name"] }}`,
+				DefaultTag:  "app.unknown",
+				RequireAck:  true,
+				Security: &SecurityConfig{
+					SharedKey:    "secret",
+					SelfHostname: "collector.example.com",
+					Username:     "otel",
+					Password:     "password",
+				},
+			},
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "invalid_mode"),
+			errorMessage: "invalid mode 'compressed', must be one of 'message', 'forward' or 'packed_forward'",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "invalid_tag"),
+			errorMessage: "invalid \"tag\": unsupported expression \"body\", expected resource[\"<name>\"] or attributes[\"<name>\"]",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "missing_endpoint",
+			modify:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: "\"endpoint\" must be specified",
+		},
+		{
+			name:    "endpoint_without_port",
+			modify:  func(cfg *Config) { cfg.Endpoint = "localhost" },
+			wantErr: "invalid \"endpoint\": address localhost: missing port in address",
+		},
+		{
+			name:    "forward_with_compression",
+			modify:  func(cfg *Config) { cfg.Compression = CompressionGzip },
+			wantErr: "compression is only supported by the 'packed_forward' mode",
+		},
+		{
+			name: "invalid_compression",
+			modify: func(cfg *Config) {
+				cfg.Mode = PackedForwardMode
+				cfg.Compression = "zstd"
+			},
+			wantErr: "invalid compression 'zstd', only 'gzip' is supported",
+		},
+		{
+			name:    "unclosed_tag_expression",
+			modify:  func(cfg *Config) { cfg.Tag = `app.{{ attributes["service"]` },
+			wantErr: "invalid \"tag\": unclosed expression in template \"app.{{ attributes[\\\"service\\\"]\"",
+		},
+		{
+			name:    "empty_default_tag",
+			modify:  func(cfg *Config) { cfg.DefaultTag = "" },
+			wantErr: "\"default_tag\" must not be empty",
+		},
+		{
+			name:    "missing_shared_key",
+			modify:  func(cfg *Config) { cfg.Security = &SecurityConfig{} },
+			wantErr: "\"security.shared_key\" must be specified",
+		},
+		{
+			name:    "password_without_username",
+			modify:  func(cfg *Config) { cfg.Security = &SecurityConfig{SharedKey: "secret", Password: "password"} },
+			wantErr: "\"security.username\" must be specified with \"security.password\"",
+		},
+		{
+			name: "packed_forward_with_gzip",
+			modify: func(cfg *Config) {
+				cfg.Mode = PackedForwardMode
+				cfg.Compression = CompressionGzip
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:24224"
+			tt.modify(cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.wantErr)
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,373 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/binary"
+	"errors"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// messageKey is the key of the record holding the body of the log record, as read by
+// fluentd and by the fluentforward receiver.
+const messageKey = "message"
+
+// Most of this logic follows
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1,
+// which describes the carrier modes and their options in much greater detail.
+
+// event is an encoded event, with the chunk the server must acknowledge if any.
+type event struct {
+	data  []byte
+	chunk string
+	// records are the log records encoded in the event.
+	records []logRecord
+}
+
+// logRecord is a log record with the indexes of its resource and scope logs in the batch.
+type logRecord struct {
+	plog.LogRecord
+	resource, scope int
+}
+
+// taggedRecords are the log records of a batch sharing a tag.
+type taggedRecords struct {
+	tag     string
+	records []logRecord
+}
+
+type encoder struct {
+	mode        string
+	compression string
+	tag         *tagTemplate
+	defaultTag  string
+	requireAck  bool
+}
+
+// encode encodes the log records as events of the configured mode.
+func (e *encoder) encode(ld plog.Logs) ([]event, error) {
+	groups := e.groupByTag(ld)
+
+	var events []event
+	for _, group := range groups {
+		if e.mode == MessageMode {
+			for _, lr := range group.records {
+				ev, err := e.encodeMessage(group.tag, lr)
+				if err != nil {
+					return nil, err
+				}
+				events = append(events, ev)
+			}
+			continue
+		}
+
+		ev, err := e.encodeForward(group)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// groupByTag groups the log records by tag, in the order the tags are first seen.
+func (e *encoder) groupByTag(ld plog.Logs) []*taggedRecords {
+	var groups []*taggedRecords
+	byTag := map[string]*taggedRecords{}
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			records := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				lr := records.At(k)
+				tag := e.tag.render(lr, rl.Resource())
+				if tag == "" {
+					tag = e.defaultTag
+				}
+				group, ok := byTag[tag]
+				if !ok {
+					group = &taggedRecords{tag: tag}
+					byTag[tag] = group
+					groups = append(groups, group)
+				}
+				group.records = append(group.records, logRecord{LogRecord: lr, resource: i, scope: j})
+			}
+		}
+	}
+	return groups
+}
+
+// encodeMessage encodes a log record as a Message mode event: [tag, time, record, option].
+func (e *encoder) encodeMessage(tag string, lr logRecord) (event, error) {
+	chunk, err := e.newChunk()
+	if err != nil {
+		return event{}, err
+	}
+
+	b := msgp.AppendArrayHeader(nil, arrayLen(3, chunk))
+	b = msgp.AppendString(b, tag)
+	if b, err = appendTime(b, lr.LogRecord); err != nil {
+		return event{}, err
+	}
+	b = appendRecord(b, lr.LogRecord)
+	if chunk != "" {
+		b = appendOptions(b, chunk, 0, "")
+	}
+	return event{data: b, chunk: chunk, records: []logRecord{lr}}, nil
+}
+
+// encodeForward encodes log records sharing a tag as a Forward mode event,
+// [tag, [[time, record], ...], option], or as a PackedForward mode event,
+// [tag, entries, option] where entries are the concatenated [time, record] entries.
+func (e *encoder) encodeForward(group *taggedRecords) (event, error) {
+	chunk, err := e.newChunk()
+	if err != nil {
+		return event{}, err
+	}
+
+	var entries []byte
+	for _, lr := range group.records {
+		entries = msgp.AppendArrayHeader(entries, 2)
+		if entries, err = appendTime(entries, lr.LogRecord); err != nil {
+			return event{}, err
+		}
+		entries = appendRecord(entries, lr.LogRecord)
+	}
+
+	if e.mode == ForwardMode {
+		b := msgp.AppendArrayHeader(nil, arrayLen(2, chunk))
+		b = msgp.AppendString(b, group.tag)
+		b = msgp.AppendArrayHeader(b, uint32(len(group.records)))
+		b = append(b, entries...)
+		if chunk != "" {
+			b = appendOptions(b, chunk, 0, "")
+		}
+		return event{data: b, chunk: chunk, records: group.records}, nil
+	}
+
+	if e.compression == CompressionGzip {
+		if entries, err = gzipEntries(entries); err != nil {
+			return event{}, err
+		}
+	}
+	// the packed forward events always carry the size option
+	b := msgp.AppendArrayHeader(nil, 3)
+	b = msgp.AppendString(b, group.tag)
+	b = msgp.AppendBytes(b, entries)
+	b = appendOptions(b, chunk, len(group.records), e.compression)
+	return event{data: b, chunk: chunk, records: group.records}, nil
+}
+
+// remainingLogs returns the log records of the given events, with their resources and scopes.
+func remainingLogs(ld plog.Logs, events []event) plog.Logs {
+	remaining := plog.NewLogs()
+	resources := map[int]plog.ResourceLogs{}
+	scopes := map[[2]int]plog.LogRecordSlice{}
+	for _, ev := range events {
+		for _, lr := range ev.records {
+			records, ok := scopes[[2]int{lr.resource, lr.scope}]
+			if !ok {
+				srcRL := ld.ResourceLogs().At(lr.resource)
+				rl, ok := resources[lr.resource]
+				if !ok {
+					rl = remaining.ResourceLogs().AppendEmpty()
+					srcRL.Resource().CopyTo(rl.Resource())
+					rl.SetSchemaUrl(srcRL.SchemaUrl())
+					resources[lr.resource] = rl
+				}
+				srcSL := srcRL.ScopeLogs().At(lr.scope)
+				sl := rl.ScopeLogs().AppendEmpty()
+				srcSL.Scope().CopyTo(sl.Scope())
+				sl.SetSchemaUrl(srcSL.SchemaUrl())
+				records = sl.LogRecords()
+				scopes[[2]int{lr.resource, lr.scope}] = records
+			}
+			lr.CopyTo(records.AppendEmpty())
+		}
+	}
+	return remaining
+}
+
+// newChunk returns a unique chunk id when acknowledgements are required.
+func (e *encoder) newChunk() (string, error) {
+	if !e.requireAck {
+		return "", nil
+	}
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return "", err
+	}
+	return base64.StdEncoding.EncodeToString(id), nil
+}
+
+// arrayLen returns the length of an event array, which holds the options as an
+// additional element when there is a chunk to acknowledge.
+func arrayLen(withoutOptions uint32, chunk string) uint32 {
+	if chunk != "" {
+		return withoutOptions + 1
+	}
+	return withoutOptions
+}
+
+func appendOptions(b []byte, chunk string, size int, compressed string) []byte {
+	var n uint32
+	if chunk != "" {
+		n++
+	}
+	if size > 0 {
+		n++
+	}
+	if compressed != "" {
+		n++
+	}
+
+	b = msgp.AppendMapHeader(b, n)
+	if chunk != "" {
+		b = msgp.AppendString(b, "chunk")
+		b = msgp.AppendString(b, chunk)
+	}
+	if size > 0 {
+		b = msgp.AppendString(b, "size")
+		b = msgp.AppendInt(b, size)
+	}
+	if compressed != "" {
+		b = msgp.AppendString(b, "compressed")
+		b = msgp.AppendString(b, compressed)
+	}
+	return b
+}
+
+func gzipEntries(entries []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(entries); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// appendTime appends the time of the log record as an EventTime, falling back to its
+// observed timestamp and then to the current time.
+func appendTime(b []byte, lr plog.LogRecord) ([]byte, error) {
+	ts := lr.Timestamp()
+	if ts == 0 {
+		ts = lr.ObservedTimestamp()
+	}
+	t := time.Now()
+	if ts != 0 {
+		t = ts.AsTime()
+	}
+	et := eventTime(t)
+	return msgp.AppendExtension(b, &et)
+}
+
+// appendRecord appends the record of a log record: its attributes, except the tag,
+// and its body under the message key.
+func appendRecord(b []byte, lr plog.LogRecord) []byte {
+	hasBody := lr.Body().Type() != pcommon.ValueTypeEmpty
+
+	var n uint32
+	lr.Attributes().Range(func(k string, _ pcommon.Value) bool {
+		if k != tagAttributeKey && !(hasBody && k == messageKey) {
+			n++
+		}
+		return true
+	})
+	if hasBody {
+		n++
+	}
+
+	b = msgp.AppendMapHeader(b, n)
+	lr.Attributes().Range(func(k string, v pcommon.Value) bool {
+		if k != tagAttributeKey && !(hasBody && k == messageKey) {
+			b = msgp.AppendString(b, k)
+			b = appendValue(b, v)
+		}
+		return true
+	})
+	if hasBody {
+		b = msgp.AppendString(b, messageKey)
+		b = appendValue(b, lr.Body())
+	}
+	return b
+}
+
+func appendValue(b []byte, v pcommon.Value) []byte {
+	switch v.Type() {
+	case pcommon.ValueTypeStr:
+		return msgp.AppendString(b, v.Str())
+	case pcommon.ValueTypeInt:
+		return msgp.AppendInt64(b, v.Int())
+	case pcommon.ValueTypeDouble:
+		return msgp.AppendFloat64(b, v.Double())
+	case pcommon.ValueTypeBool:
+		return msgp.AppendBool(b, v.Bool())
+	case pcommon.ValueTypeBytes:
+		return msgp.AppendBytes(b, v.Bytes().AsRaw())
+	case pcommon.ValueTypeMap:
+		b = msgp.AppendMapHeader(b, uint32(v.Map().Len()))
+		v.Map().Range(func(k string, mv pcommon.Value) bool {
+			b = msgp.AppendString(b, k)
+			b = appendValue(b, mv)
+			return true
+		})
+		return b
+	case pcommon.ValueTypeSlice:
+		b = msgp.AppendArrayHeader(b, uint32(v.Slice().Len()))
+		for i := 0; i < v.Slice().Len(); i++ {
+			b = appendValue(b, v.Slice().At(i))
+		}
+		return b
+	default:
+		return msgp.AppendNil(b)
+	}
+}
+
+// eventTime is the EventTime extension of the Forward protocol, holding the
+// seconds and nanoseconds of the time as big-endian 32-bit integers.
+type eventTime time.Time
+
+func (*eventTime) ExtensionType() int8 {
+	return 0x00
+}
+
+func (*eventTime) Len() int {
+	return 8
+}
+
+func (e *eventTime) MarshalBinaryTo(b []byte) error {
+	binary.BigEndian.PutUint32(b[0:], uint32(time.Time(*e).Unix()))
+	binary.BigEndian.PutUint32(b[4:], uint32(time.Time(*e).Nanosecond()))
+	return nil
+}
+
+func (e *eventTime) UnmarshalBinary(b []byte) error {
+	if len(b) != 8 {
+		return errors.New("data should be exactly 8 bytes")
+	}
+	*e = eventTime(time.Unix(int64(binary.BigEndian.Uint32(b[0:])), int64(binary.BigEndian.Uint32(b[4:]))))
+	return nil
+}
@@ -0,0 +1,239 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type fluentForwardExporter struct {
+	encoder *encoder
+	sender  *sender
+}
+
+func newExporter(cfg *Config) (*fluentForwardExporter, error) {
+	tag, err := parseTagTemplate(cfg.Tag)
+	if err != nil {
+		return nil, err
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.TLSSetting != nil {
+		if tlsConfig, err = cfg.TLSSetting.LoadTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	var security *SecurityConfig
+	if cfg.Security != nil {
+		sec := *cfg.Security
+		if sec.SelfHostname == "" {
+			if sec.SelfHostname, err = os.Hostname(); err != nil {
+				return nil, fmt.Errorf("failed to resolve the self hostname: %w", err)
+			}
+		}
+		security = &sec
+	}
+
+	return &fluentForwardExporter{
+		encoder: &encoder{
+			mode:        cfg.Mode,
+			compression: cfg.Compression,
+			tag:         tag,
+			defaultTag:  cfg.DefaultTag,
+			requireAck:  cfg.RequireAck,
+		},
+		sender: &sender{
+			endpoint:  cfg.Endpoint,
+			tlsConfig: tlsConfig,
+			timeout:   cfg.Timeout,
+			security:  security,
+		},
+	}, nil
+}
+
+func (e *fluentForwardExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	events, err := e.encoder.encode(ld)
+	if err != nil {
+		return err
+	}
+	sent, err := e.sender.send(ctx, events)
+	if err != nil && sent > 0 {
+		// only the events not sent are retried
+		return consumererror.NewLogs(err, remainingLogs(ld, events[sent:]))
+	}
+	return err
+}
+
+func (e *fluentForwardExporter) shutdown(context.Context) error {
+	return e.sender.close()
+}
+
+// sender writes the events to a single connection to the server, which is established
+// and authenticated lazily and re-established on the next send after a failure.
+type sender struct {
+	endpoint  string
+	tlsConfig *tls.Config
+	timeout   time.Duration
+	security  *SecurityConfig
+
+	mu     sync.Mutex
+	conn   net.Conn
+	reader *msgp.Reader
+}
+
+// send sends the events in order and returns the number of events sent, and acknowledged
+// when required, before a failure.
+func (s *sender) send(ctx context.Context, events []event) (int, error) {
+	if len(events) == 0 {
+		return 0, nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	deadline := s.deadline(ctx)
+	if s.conn == nil {
+		if err := s.connect(ctx, deadline); err != nil {
+			return 0, fmt.Errorf("failed to connect to %s: %w", s.endpoint, err)
+		}
+	} else {
+		_ = s.conn.SetDeadline(deadline)
+	}
+
+	for i, ev := range events {
+		if err := s.write(ev); err != nil {
+			// drop the connection, it will be re-established on the next attempt
+			s.reset()
+			return i, fmt.Errorf("failed to send the events to %s: %w", s.endpoint, err)
+		}
+	}
+	return len(events), nil
+}
+
+// deadline returns the deadline of the context, or the configured timeout from now when
+// the context has none, so that an unresponsive server can't block the sender forever.
+func (s *sender) deadline(ctx context.Context) time.Time {
+	if deadline, ok := ctx.Deadline(); ok {
+		return deadline
+	}
+	if s.timeout > 0 {
+		return time.Now().Add(s.timeout)
+	}
+	return time.Time{}
+}
+
+// write writes an event and waits for its acknowledgement when it has a chunk.
+func (s *sender) write(ev event) error {
+	if _, err := s.conn.Write(ev.data); err != nil {
+		return err
+	}
+	if ev.chunk == "" {
+		return nil
+	}
+
+	ack, err := readAck(s.reader)
+	if err != nil {
+		return fmt.Errorf("failed to read the acknowledgement of chunk %s: %w", ev.chunk, err)
+	}
+	if ack != ev.chunk {
+		return fmt.Errorf("unexpected acknowledgement %s for chunk %s", ack, ev.chunk)
+	}
+	return nil
+}
+
+func (s *sender) connect(ctx context.Context, deadline time.Time) error {
+	conn, err := s.dial(ctx)
+	if err != nil {
+		return err
+	}
+	_ = conn.SetDeadline(deadline)
+
+	s.conn = conn
+	s.reader = msgp.NewReader(conn)
+	if s.security == nil {
+		return nil
+	}
+
+	if err = handshake(conn, s.reader, s.security); err != nil {
+		s.reset()
+		return fmt.Errorf("handshake failed: %w", err)
+	}
+	return nil
+}
+
+func (s *sender) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	if s.tlsConfig != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		return tlsDialer.DialContext(ctx, "tcp", s.endpoint)
+	}
+	return dialer.DialContext(ctx, "tcp", s.endpoint)
+}
+
+func (s *sender) reset() {
+	_ = s.conn.Close()
+	s.conn = nil
+	s.reader = nil
+}
+
+func (s *sender) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	s.reader = nil
+	return err
+}
+
+// readAck reads an acknowledgement, a map whose "ack" key holds the acknowledged chunk.
+func readAck(r *msgp.Reader) (string, error) {
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return "", err
+	}
+
+	var ack string
+	for ; n > 0; n-- {
+		key, err := r.ReadString()
+		if err != nil {
+			return "", err
+		}
+		if key != "ack" {
+			if err = r.Skip(); err != nil {
+				return "", err
+			}
+			continue
+		}
+		if ack, err = r.ReadString(); err != nil {
+			return "", err
+		}
+	}
+	return ack, nil
+}
@@ -0,0 +1,327 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/fluentforwardreceiver"
+)
+
+var testTime = time.Date(2023, 1, 2, 3, 4, 5, 678, time.UTC)
+
+func testLogs() plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+
+	lr := records.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime))
+	lr.Body().SetStr("hello")
+	lr.Attributes().PutStr(tagAttributeKey, "app.web")
+	lr.Attributes().PutStr("level", "info")
+
+	lr = records.AppendEmpty()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTime.Add(time.Second)))
+	lr.Body().SetEmptyMap().PutStr("k", "v")
+	lr.Attributes().PutBool("retry", true)
+
+	lr = records.AppendEmpty()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTime.Add(2 * time.Second)))
+	lr.Body().SetStr("world")
+	lr.Attributes().PutStr(tagAttributeKey, "app.web")
+	lr.Attributes().PutInt("status", 42)
+	lr.Attributes().PutDouble("duration", 1.5)
+	return ld
+}
+
+func TestExportToReceiver(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(cfg *Config)
+	}{
+		{
+			name:   "message",
+			modify: func(cfg *Config) { cfg.Mode = MessageMode },
+		},
+		{
+			name: "message_with_ack",
+			modify: func(cfg *Config) {
+				cfg.Mode = MessageMode
+				cfg.RequireAck = true
+			},
+		},
+		{
+			name:   "forward",
+			modify: func(cfg *Config) {},
+		},
+		{
+			name:   "forward_with_ack",
+			modify: func(cfg *Config) { cfg.RequireAck = true },
+		},
+		{
+			name:   "packed_forward",
+			modify: func(cfg *Config) { cfg.Mode = PackedForwardMode },
+		},
+		{
+			name: "compressed_packed_forward_with_ack",
+			modify: func(cfg *Config) {
+				cfg.Mode = PackedForwardMode
+				cfg.Compression = CompressionGzip
+				cfg.RequireAck = true
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr := testutil.GetAvailableLocalAddress(t)
+			sink := startReceiver(t, addr)
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = addr
+			tt.modify(cfg)
+			require.NoError(t, cfg.Validate())
+
+			exp, err := newExporter(cfg)
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, exp.shutdown(context.Background())) }()
+
+			require.NoError(t, exp.pushLogs(context.Background(), testLogs()))
+			require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+			received := map[string]plog.LogRecord{}
+			for _, ld := range sink.AllLogs() {
+				for i := 0; i < ld.ResourceLogs().Len(); i++ {
+					records := ld.ResourceLogs().At(i).ScopeLogs().At(0).LogRecords()
+					for j := 0; j < records.Len(); j++ {
+						received[records.At(j).Timestamp().AsTime().UTC().String()] = records.At(j)
+					}
+				}
+			}
+
+			lr, ok := received[testTime.String()]
+			require.True(t, ok)
+			assert.Equal(t, "hello", lr.Body().Str())
+			assert.Equal(t, map[string]interface{}{tagAttributeKey: "app.web", "level": "info"}, lr.Attributes().AsRaw())
+
+			lr, ok = received[testTime.Add(time.Second).String()]
+			require.True(t, ok)
+			assert.Equal(t, map[string]interface{}{"k": "v"}, lr.Body().Map().AsRaw())
+			assert.Equal(t, map[string]interface{}{tagAttributeKey: defaultTag, "retry": true}, lr.Attributes().AsRaw())
+
+			lr, ok = received[testTime.Add(2*time.Second).String()]
+			require.True(t, ok)
+			assert.Equal(t, "world", lr.Body().Str())
+			assert.Equal(t, map[string]interface{}{tagAttributeKey: "app.web", "status": int64(42), "duration": 1.5}, lr.Attributes().AsRaw())
+		})
+	}
+}
+
+func TestExportTagTemplate(t *testing.T) {
+	addr := testutil.GetAvailableLocalAddress(t)
+	sink := startReceiver(t, addr)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = addr
+	cfg.Tag = `{{ resource["service.name"] }}.{{ attributes["level"] }}`
+
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, exp.shutdown(context.Background())) }()
+
+	require.NoError(t, exp.pushLogs(context.Background(), testLogs()))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 3 }, 5*time.Second, 10*time.Millisecond)
+
+	var tags []string
+	for _, ld := range sink.AllLogs() {
+		records := ld.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+		for i := 0; i < records.Len(); i++ {
+			tag, _ := records.At(i).Attributes().Get(tagAttributeKey)
+			tags = append(tags, tag.Str())
+		}
+	}
+	assert.ElementsMatch(t, []string{"checkout.info", "checkout.", "checkout."}, tags)
+}
+
+func TestExportUnexpectedAck(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		r := msgp.NewReader(conn)
+		if err := r.Skip(); err != nil {
+			return
+		}
+		ack := msgp.AppendMapHeader(nil, 1)
+		ack = msgp.AppendString(ack, "ack")
+		ack = msgp.AppendString(ack, "unknown")
+		_, _ = conn.Write(ack)
+	}()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	cfg.RequireAck = true
+
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = exp.pushLogs(ctx, testLogs())
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "unexpected acknowledgement unknown for chunk")
+	assert.Nil(t, exp.sender.conn, "the connection should be dropped after a failure")
+}
+
+// readChunk reads a Message mode event and returns its chunk.
+func readChunk(r *msgp.Reader) (string, error) {
+	if _, err := r.ReadArrayHeader(); err != nil {
+		return "", err
+	}
+	for i := 0; i < 3; i++ {
+		if err := r.Skip(); err != nil {
+			return "", err
+		}
+	}
+	if _, err := r.ReadMapHeader(); err != nil {
+		return "", err
+	}
+	if _, err := r.ReadString(); err != nil {
+		return "", err
+	}
+	return r.ReadString()
+}
+
+func TestExportRetriesRemainingEvents(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		// acknowledge the first event only
+		r := msgp.NewReader(conn)
+		chunk, err := readChunk(r)
+		if err != nil {
+			return
+		}
+		ack := msgp.AppendMapHeader(nil, 1)
+		ack = msgp.AppendString(ack, "ack")
+		ack = msgp.AppendString(ack, chunk)
+		_, _ = conn.Write(ack)
+		_, _ = readChunk(r)
+	}()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	cfg.Mode = MessageMode
+	cfg.RequireAck = true
+
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	err = exp.pushLogs(ctx, testLogs())
+	require.Error(t, err)
+
+	var logsErr consumererror.Logs
+	require.True(t, errors.As(err, &logsErr))
+	remaining := logsErr.GetLogs()
+	require.Equal(t, 2, remaining.LogRecordCount())
+	rl := remaining.ResourceLogs().At(0)
+	assert.Equal(t, map[string]interface{}{"service.name": "checkout"}, rl.Resource().Attributes().AsRaw())
+	records := rl.ScopeLogs().At(0).LogRecords()
+	assert.Equal(t, "world", records.At(0).Body().Str())
+	assert.Equal(t, map[string]interface{}{"k": "v"}, records.At(1).Body().Map().AsRaw())
+}
+
+func TestExportTimeoutWithoutContextDeadline(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	// the server never acknowledges the events
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		_, _ = readChunk(msgp.NewReader(conn))
+		time.Sleep(5 * time.Second)
+	}()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = ln.Addr().String()
+	cfg.Mode = MessageMode
+	cfg.RequireAck = true
+	cfg.Timeout = 100 * time.Millisecond
+
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+
+	done := make(chan error, 1)
+	go func() {
+		done <- exp.pushLogs(context.Background(), testLogs())
+	}()
+	select {
+	case err = <-done:
+		var netErr net.Error
+		require.True(t, errors.As(err, &netErr))
+		assert.True(t, netErr.Timeout())
+	case <-time.After(3 * time.Second):
+		t.Fatal("the export should time out")
+	}
+}
+
+func startReceiver(t *testing.T, addr string) *consumertest.LogsSink {
+	sink := new(consumertest.LogsSink)
+	factory := fluentforwardreceiver.NewFactory()
+	cfg := factory.CreateDefaultConfig().(*fluentforwardreceiver.Config)
+	cfg.ListenAddress = addr
+
+	rcv, err := factory.CreateLogsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcv.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() { assert.NoError(t, rcv.Shutdown(context.Background())) })
+	return sink
+}
@@ -0,0 +1,181 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"crypto/rand"
+	"crypto/sha512"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/tinylib/msgp/msgp"
+)
+
+// The handshake authenticating the client and the server with a shared key is described in
+// https://github.com/fluent/fluentd/wiki/Forward-Protocol-Specification-v1#handshake-messages:
+// the server sends a HELO message with a nonce, the client answers with a PING message
+// proving it knows the shared key, and the server answers with a PONG message proving
+// it knows the shared key too.
+
+// helo is the HELO message sent by the server when the connection is established.
+type helo struct {
+	nonce string
+	// auth is the salt of the password digest, empty when the server does not
+	// require user authentication.
+	auth string
+}
+
+func handshake(w io.Writer, r *msgp.Reader, security *SecurityConfig) error {
+	h, err := readHelo(r)
+	if err != nil {
+		return fmt.Errorf("failed to read HELO: %w", err)
+	}
+
+	salt := make([]byte, 16)
+	if _, err = rand.Read(salt); err != nil {
+		return err
+	}
+	sharedKeySalt := hex.EncodeToString(salt)
+
+	var passwordDigest string
+	if h.auth != "" {
+		passwordDigest = digest(h.auth, security.Username, string(security.Password))
+	}
+
+	ping := msgp.AppendArrayHeader(nil, 6)
+	ping = msgp.AppendString(ping, "PING")
+	ping = msgp.AppendString(ping, security.SelfHostname)
+	ping = msgp.AppendString(ping, sharedKeySalt)
+	ping = msgp.AppendString(ping, digest(sharedKeySalt, security.SelfHostname, h.nonce, string(security.SharedKey)))
+	ping = msgp.AppendString(ping, security.Username)
+	ping = msgp.AppendString(ping, passwordDigest)
+	if _, err = w.Write(ping); err != nil {
+		return fmt.Errorf("failed to send PING: %w", err)
+	}
+
+	return readPong(r, func(hostname string) string {
+		return digest(sharedKeySalt, hostname, h.nonce, string(security.SharedKey))
+	})
+}
+
+func readHelo(r *msgp.Reader) (helo, error) {
+	if err := readMessageType(r, "HELO", 2); err != nil {
+		return helo{}, err
+	}
+
+	n, err := r.ReadMapHeader()
+	if err != nil {
+		return helo{}, err
+	}
+	var h helo
+	for ; n > 0; n-- {
+		key, err := r.ReadString()
+		if err != nil {
+			return helo{}, err
+		}
+		switch key {
+		case "nonce":
+			h.nonce, err = readStringOrBytes(r)
+		case "auth":
+			h.auth, err = readStringOrBytes(r)
+		default:
+			err = r.Skip()
+		}
+		if err != nil {
+			return helo{}, msgp.WrapError(err, key)
+		}
+	}
+	if h.nonce == "" {
+		return helo{}, errors.New("missing nonce")
+	}
+	return h, nil
+}
+
+// readPong reads the PONG message and checks the digest of the shared key computed by
+// the server, expectedDigest returning the digest expected from the server hostname.
+func readPong(r *msgp.Reader, expectedDigest func(hostname string) string) error {
+	if err := readMessageType(r, "PONG", 5); err != nil {
+		return fmt.Errorf("failed to read PONG: %w", err)
+	}
+
+	authenticated, err := r.ReadBool()
+	if err != nil {
+		return fmt.Errorf("failed to read PONG: %w", err)
+	}
+	reason, err := r.ReadString()
+	if err != nil {
+		return fmt.Errorf("failed to read PONG: %w", err)
+	}
+	hostname, err := r.ReadString()
+	if err != nil {
+		return fmt.Errorf("failed to read PONG: %w", err)
+	}
+	serverDigest, err := r.ReadString()
+	if err != nil {
+		return fmt.Errorf("failed to read PONG: %w", err)
+	}
+
+	if !authenticated {
+		return fmt.Errorf("authentication failed: %s", reason)
+	}
+	if serverDigest != expectedDigest(hostname) {
+		return errors.New("the shared key digest of the server does not match")
+	}
+	return nil
+}
+
+// readMessageType reads the header of a handshake message, an array of size elements
+// whose first element is its type.
+func readMessageType(r *msgp.Reader, expected string, size uint32) error {
+	n, err := r.ReadArrayHeader()
+	if err != nil {
+		return err
+	}
+	if n != size {
+		return msgp.ArrayError{Wanted: size, Got: n}
+	}
+	messageType, err := r.ReadString()
+	if err != nil {
+		return err
+	}
+	if messageType != expected {
+		return fmt.Errorf("unexpected message type %q, expected %q", messageType, expected)
+	}
+	return nil
+}
+
+// readStringOrBytes reads a string which can be sent either as a msgpack str or bin.
+func readStringOrBytes(r *msgp.Reader) (string, error) {
+	t, err := r.NextType()
+	if err != nil {
+		return "", err
+	}
+	if t == msgp.BinType {
+		b, err := r.ReadBytes(nil)
+		return string(b), err
+	}
+	return r.ReadString()
+}
+
+// digest returns the hex encoded SHA-512 digest of the concatenated parts.
+func digest(parts ...string) string {
+	h := sha512.New()
+	for _, p := range parts {
+		h.Write([]byte(p))
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
@@ -0,0 +1,178 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/tinylib/msgp/msgp"
+)
+
+// handshakeServer is a fake server performing the handshake of fluentd's in_forward
+// plugin, which the fluentforward receiver doesn't support, and reporting the tag of
+// the first event it receives.
+type handshakeServer struct {
+	sharedKey      string
+	serverKey      string
+	username       string
+	password       string
+	serverHostname string
+
+	tags chan string
+}
+
+func (s *handshakeServer) serve(t *testing.T, ln net.Listener) {
+	conn, err := ln.Accept()
+	if err != nil {
+		return
+	}
+	defer conn.Close()
+
+	nonce, authSalt := "nonce-1234", ""
+	if s.username != "" {
+		authSalt = "auth-salt"
+	}
+	helo := msgp.AppendArrayHeader(nil, 2)
+	helo = msgp.AppendString(helo, "HELO")
+	helo = msgp.AppendMapHeader(helo, 3)
+	helo = msgp.AppendString(helo, "nonce")
+	helo = msgp.AppendBytes(helo, []byte(nonce))
+	helo = msgp.AppendString(helo, "auth")
+	helo = msgp.AppendString(helo, authSalt)
+	helo = msgp.AppendString(helo, "keepalive")
+	helo = msgp.AppendBool(helo, true)
+	_, err = conn.Write(helo)
+	require.NoError(t, err)
+
+	r := msgp.NewReader(conn)
+	require.NoError(t, readMessageType(r, "PING", 6))
+	ping := make([]string, 5)
+	for i := range ping {
+		ping[i], err = r.ReadString()
+		require.NoError(t, err)
+	}
+	hostname, sharedKeySalt, sharedKeyDigest, username, passwordDigest := ping[0], ping[1], ping[2], ping[3], ping[4]
+
+	authenticated, reason := true, ""
+	switch {
+	case sharedKeyDigest != digest(sharedKeySalt, hostname, nonce, s.sharedKey):
+		authenticated, reason = false, "shared_key mismatch"
+	case s.username != "" && (username != s.username || passwordDigest != digest(authSalt, s.username, s.password)):
+		authenticated, reason = false, "username/password mismatch"
+	}
+
+	pong := msgp.AppendArrayHeader(nil, 5)
+	pong = msgp.AppendString(pong, "PONG")
+	pong = msgp.AppendBool(pong, authenticated)
+	pong = msgp.AppendString(pong, reason)
+	pong = msgp.AppendString(pong, s.serverHostname)
+	pong = msgp.AppendString(pong, digest(sharedKeySalt, s.serverHostname, nonce, s.serverKey))
+	_, err = conn.Write(pong)
+	require.NoError(t, err)
+	if !authenticated {
+		return
+	}
+
+	if _, err = r.ReadArrayHeader(); err != nil {
+		return
+	}
+	tag, err := r.ReadString()
+	if err != nil {
+		return
+	}
+	s.tags <- tag
+}
+
+func TestHandshake(t *testing.T) {
+	tests := []struct {
+		name     string
+		server   handshakeServer
+		security SecurityConfig
+		wantErr  string
+	}{
+		{
+			name:     "shared_key",
+			server:   handshakeServer{sharedKey: "secret", serverKey: "secret"},
+			security: SecurityConfig{SharedKey: "secret"},
+		},
+		{
+			name:     "user_auth",
+			server:   handshakeServer{sharedKey: "secret", serverKey: "secret", username: "otel", password: "password"},
+			security: SecurityConfig{SharedKey: "secret", SelfHostname: "collector", Username: "otel", Password: "password"},
+		},
+		{
+			name:     "shared_key_mismatch",
+			server:   handshakeServer{sharedKey: "secret", serverKey: "secret"},
+			security: SecurityConfig{SharedKey: "wrong"},
+			wantErr:  "handshake failed: authentication failed: shared_key mismatch",
+		},
+		{
+			name:     "password_mismatch",
+			server:   handshakeServer{sharedKey: "secret", serverKey: "secret", username: "otel", password: "password"},
+			security: SecurityConfig{SharedKey: "secret", Username: "otel", Password: "wrong"},
+			wantErr:  "handshake failed: authentication failed: username/password mismatch",
+		},
+		{
+			name:     "server_digest_mismatch",
+			server:   handshakeServer{sharedKey: "secret", serverKey: "other"},
+			security: SecurityConfig{SharedKey: "secret"},
+			wantErr:  "handshake failed: the shared key digest of the server does not match",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer ln.Close()
+
+			server := tt.server
+			server.serverHostname = "fluentd.example.com"
+			server.tags = make(chan string, 1)
+			go server.serve(t, ln)
+
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = ln.Addr().String()
+			security := tt.security
+			cfg.Security = &security
+			require.NoError(t, cfg.Validate())
+
+			exp, err := newExporter(cfg)
+			require.NoError(t, err)
+			defer func() { assert.NoError(t, exp.shutdown(context.Background())) }()
+
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+			defer cancel()
+			err = exp.pushLogs(ctx, testLogs())
+			if tt.wantErr != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.wantErr)
+				return
+			}
+
+			require.NoError(t, err)
+			select {
+			case tag := <-server.tags:
+				assert.Equal(t, "app.web", tag)
+			case <-ctx.Done():
+				t.Fatal("the server did not receive any event")
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+// tagAttributeKey is the attribute holding the tag of the logs received by the fluentforward receiver.
+const tagAttributeKey = "fluent.tag"
+
+const (
+	tagSourceResource   = "resource"
+	tagSourceAttributes = "attributes"
+)
+
+var tagExpressionRE = regexp.MustCompile(`^(resource|attributes)\[\s*(?:"([^"]*)"|'([^']*)')\s*\]$`)
+
+// tagTemplate renders the tag of a log record, replacing the `resource["<name>"]` and
+// `attributes["<name>"]` expressions between double braces by the values of the
+// attributes. Missing attributes are rendered as empty strings.
+type tagTemplate struct {
+	parts []tagPart
+}
+
+type tagPart struct {
+	// text is rendered as is when source is empty
+	text   string
+	source string
+	key    string
+}
+
+func parseTagTemplate(text string) (*tagTemplate, error) {
+	t := &tagTemplate{}
+	rest := text
+	for len(rest) > 0 {
+		before, after, found := strings.Cut(rest, "{{")
+		if before != "" {
+			t.parts = append(t.parts, tagPart{text: before})
+		}
+		if !found {
+			break
+		}
+
+		expr, remaining, closed := strings.Cut(after, "}}")
+		if !closed {
+			return nil, fmt.Errorf("unclosed expression in template %q", text)
+		}
+		matches := tagExpressionRE.FindStringSubmatch(strings.TrimSpace(expr))
+		if matches == nil {
+			return nil, fmt.Errorf("unsupported expression %q, expected resource[\"<name>\"] or attributes[\"<name>\"]", strings.TrimSpace(expr))
+		}
+		t.parts = append(t.parts, tagPart{source: matches[1], key: matches[2] + matches[3]})
+		rest = remaining
+	}
+	return t, nil
+}
+
+func (t *tagTemplate) render(lr plog.LogRecord, res pcommon.Resource) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		var attributes pcommon.Map
+		switch part.source {
+		case "":
+			b.WriteString(part.text)
+			continue
+		case tagSourceResource:
+			attributes = res.Attributes()
+		case tagSourceAttributes:
+			attributes = lr.Attributes()
+		}
+		if v, ok := attributes.Get(part.key); ok {
+			b.WriteString(v.AsString())
+		}
+	}
+	return b.String()
+}
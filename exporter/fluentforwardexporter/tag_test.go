@@ -0,0 +1,57 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package fluentforwardexporter
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestTagTemplate(t *testing.T) {
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("service.name", "checkout")
+	lr := plog.NewLogRecord()
+	lr.Attributes().PutStr("level", "warn")
+	lr.Attributes().PutInt("code", 404)
+
+	tests := []struct {
+		template string
+		expected string
+		wantErr  string
+	}{
+		{template: "static.tag", expected: "static.tag"},
+		{template: `app.{{ resource["service.name"] }}`, expected: "app.checkout"},
+		{template: `{{attributes['level']}}.{{ attributes["code"] }}`, expected: "warn.404"},
+		{template: `app.{{ attributes["missing"] }}`, expected: "app."},
+		{template: "", expected: ""},
+		{template: `{{ attributes["level"]`, wantErr: "unclosed expression in template \"{{ attributes[\\\"level\\\"]\""},
+		{template: `{{ severity }}`, wantErr: "unsupported expression \"severity\", expected resource[\"<name>\"] or attributes[\"<name>\"]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.template, func(t *testing.T) {
+			tmpl, err := parseTagTemplate(tt.template)
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, tmpl.render(lr, res))
+		})
+	}
+}
@@ -17,9 +17,13 @@ package lokiexporter // import "github.com/open-telemetry/opentelemetry-collecto
 import (
 	"fmt"
 	"net/url"
+	"time"
 
+	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/exporter/exporterhelper"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
 )
 
 // Config defines configuration for Loki exporter.
@@ -48,6 +52,33 @@ type Config struct {
 	// Deprecated: [v0.57.0] use the attribute processor to add a `loki.tenant` hint.
 	// See this component's documentation for more information on how to specify the hint.
 	Tenant *Tenant `mapstructure:"tenant"`
+
+	// StreamLabels are templates of stream labels, keyed by label name, e.g.
+	// `namespace: '{{ resource["k8s.namespace.name"] }}'`. They are added to the labels
+	// from the hints.
+	StreamLabels map[string]string `mapstructure:"stream_labels"`
+
+	// LineFormat overrides the format of the log lines from the `loki.format` hint.
+	// One of json, logfmt or raw, the latter sending the body of the log records as is.
+	LineFormat string `mapstructure:"line_format"`
+
+	// StreamLimit caps the number of distinct streams sent per interval.
+	StreamLimit StreamLimitConfig `mapstructure:"stream_limit"`
+}
+
+// StreamLimitConfig defines the limit on the number of distinct streams, protecting Loki
+// against cardinality explosions.
+type StreamLimitConfig struct {
+	// MaxStreams is the maximum number of distinct label sets sent per interval, the logs
+	// of the streams beyond the limit being sent to the fallback stream. 0 disables the limit.
+	MaxStreams int `mapstructure:"max_streams"`
+
+	// Interval is the period over which the distinct label sets are counted. Defaults to 1m.
+	Interval time.Duration `mapstructure:"interval"`
+
+	// FallbackLabels are the labels of the stream receiving the logs of the streams
+	// beyond the limit. Defaults to exporter="OTLP".
+	FallbackLabels map[string]string `mapstructure:"fallback_labels"`
 }
 
 func (c *Config) Validate() error {
@@ -57,7 +88,11 @@ func (c *Config) Validate() error {
 
 	// further validation is needed only if we are in legacy mode
 	if !c.isLegacy() {
-		return nil
+		return c.validateStreams()
+	}
+
+	if len(c.StreamLabels) > 0 || c.LineFormat != "" || c.StreamLimit.MaxStreams != 0 {
+		return fmt.Errorf("\"stream_labels\", \"line_format\" and \"stream_limit\" can't be used with the deprecated \"labels\", \"tenant\", \"tenant_id\" and \"format\"")
 	}
 
 	if c.Tenant != nil {
@@ -77,6 +112,51 @@ func (c *Config) Validate() error {
 	return nil
 }
 
+func (c *Config) validateStreams() error {
+	if _, err := c.labelTemplates(); err != nil {
+		return err
+	}
+
+	switch c.LineFormat {
+	case "", "json", "logfmt", "raw":
+	default:
+		return fmt.Errorf("invalid \"line_format\" %q, must be one of 'json', 'logfmt', 'raw'", c.LineFormat)
+	}
+
+	if c.StreamLimit.MaxStreams < 0 {
+		return fmt.Errorf("\"stream_limit.max_streams\" cannot be negative")
+	}
+	if c.StreamLimit.Interval < 0 {
+		return fmt.Errorf("\"stream_limit.interval\" cannot be negative")
+	}
+	for name, value := range c.StreamLimit.FallbackLabels {
+		if !model.LabelName(name).IsValid() {
+			return fmt.Errorf("the label `%s` in \"stream_limit.fallback_labels\" is not a valid label name. Label names must match %s", name, model.LabelNameRE.String())
+		}
+		if value == "" {
+			return fmt.Errorf("the label `%s` in \"stream_limit.fallback_labels\" has an empty value", name)
+		}
+	}
+
+	return nil
+}
+
+// labelTemplates parses the templates of the stream labels.
+func (c *Config) labelTemplates() (map[model.LabelName]*loki.LabelTemplate, error) {
+	templates := make(map[model.LabelName]*loki.LabelTemplate, len(c.StreamLabels))
+	for name, text := range c.StreamLabels {
+		if !model.LabelName(name).IsValid() {
+			return nil, fmt.Errorf("the label `%s` in \"stream_labels\" is not a valid label name. Label names must match %s", name, model.LabelNameRE.String())
+		}
+		template, err := loki.ParseLabelTemplate(text)
+		if err != nil {
+			return nil, fmt.Errorf("the label `%s` in \"stream_labels\" has an invalid template: %w", name, err)
+		}
+		templates[model.LabelName(name)] = template
+	}
+	return templates, nil
+}
+
 func (c *Config) isLegacy() bool {
 	if c.Format != nil && *c.Format == "body" {
 		return true
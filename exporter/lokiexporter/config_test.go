@@ -19,6 +19,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -72,6 +73,24 @@ func TestLoadConfigNewExporter(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "streams"),
+			expected: func() component.Config {
+				cfg := NewFactory().CreateDefaultConfig().(*Config)
+				cfg.Endpoint = "https://loki:3100/loki/api/v1/push"
+				cfg.StreamLabels = map[string]string{
+					"namespace": `{{ resource["k8s.namespace.name"] }}`,
+					"app":       `{{ resource["k8s.namespace.name"] }}/{{ attributes["app"] }}`,
+				}
+				cfg.LineFormat = "raw"
+				cfg.StreamLimit = StreamLimitConfig{
+					MaxStreams:     1000,
+					Interval:       5 * time.Minute,
+					FallbackLabels: map[string]string{"exporter": "OTLP", "overflow": "true"},
+				}
+				return cfg
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -89,6 +108,78 @@ func TestLoadConfigNewExporter(t *testing.T) {
 	}
 }
 
+func TestValidateStreams(t *testing.T) {
+	testCases := []struct {
+		desc string
+		cfg  func(*Config)
+		err  string
+	}{
+		{
+			desc: "invalid label name",
+			cfg: func(cfg *Config) {
+				cfg.StreamLabels = map[string]string{"k8s.namespace": `{{ resource["k8s.namespace.name"] }}`}
+			},
+			err: "the label `k8s.namespace` in \"stream_labels\" is not a valid label name. Label names must match " + model.LabelNameRE.String(),
+		},
+		{
+			desc: "invalid template",
+			cfg: func(cfg *Config) {
+				cfg.StreamLabels = map[string]string{"namespace": `{{ resource["k8s.namespace.name"]`}
+			},
+			err: "the label `namespace` in \"stream_labels\" has an invalid template: unclosed expression in template \"{{ resource[\\\"k8s.namespace.name\\\"]\"",
+		},
+		{
+			desc: "invalid line format",
+			cfg: func(cfg *Config) {
+				cfg.LineFormat = "xml"
+			},
+			err: "invalid \"line_format\" \"xml\", must be one of 'json', 'logfmt', 'raw'",
+		},
+		{
+			desc: "negative max streams",
+			cfg: func(cfg *Config) {
+				cfg.StreamLimit.MaxStreams = -1
+			},
+			err: "\"stream_limit.max_streams\" cannot be negative",
+		},
+		{
+			desc: "negative interval",
+			cfg: func(cfg *Config) {
+				cfg.StreamLimit.MaxStreams = 10
+				cfg.StreamLimit.Interval = -time.Second
+			},
+			err: "\"stream_limit.interval\" cannot be negative",
+		},
+		{
+			desc: "empty fallback label",
+			cfg: func(cfg *Config) {
+				cfg.StreamLimit.MaxStreams = 10
+				cfg.StreamLimit.FallbackLabels = map[string]string{"overflow": ""}
+			},
+			err: "the label `overflow` in \"stream_limit.fallback_labels\" has an empty value",
+		},
+		{
+			desc: "used with the deprecated settings",
+			cfg: func(cfg *Config) {
+				cfg.TenantID = stringp("acme")
+				cfg.LineFormat = "raw"
+			},
+			err: "\"stream_labels\", \"line_format\" and \"stream_limit\" can't be used with the deprecated \"labels\", \"tenant\", \"tenant_id\" and \"format\"",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			cfg := &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: "https://loki.example.com",
+				},
+			}
+			tC.cfg(cfg)
+			assert.EqualError(t, cfg.Validate(), tC.err)
+		})
+	}
+}
+
 func TestIsLegacy(t *testing.T) {
 	testCases := []struct {
 		desc    string
@@ -22,7 +22,10 @@ import (
 )
 
 func createNextLogsExporter(ctx context.Context, set exporter.CreateSettings, cfg *Config) (exporter.Logs, error) {
-	exp := newNextExporter(cfg, set.TelemetrySettings)
+	exp, err := newNextExporter(cfg, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 
 	return exporterhelper.NewLogsExporter(
 		ctx,
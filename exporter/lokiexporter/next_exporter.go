@@ -37,19 +37,34 @@ type nextLokiExporter struct {
 	settings component.TelemetrySettings
 	client   *http.Client
 	wg       sync.WaitGroup
+	opts     loki.Options
 }
 
-func newNextExporter(config *Config, settings component.TelemetrySettings) *nextLokiExporter {
+func newNextExporter(config *Config, settings component.TelemetrySettings) (*nextLokiExporter, error) {
 	settings.Logger.Info("using the new Loki exporter")
 
+	templates, err := config.labelTemplates()
+	if err != nil {
+		return nil, err
+	}
+
+	opts := loki.Options{
+		Labels: templates,
+		Format: config.LineFormat,
+	}
+	if config.StreamLimit.MaxStreams > 0 {
+		opts.StreamLabels = newStreamLimiter(config.StreamLimit, settings.Logger).streamLabels
+	}
+
 	return &nextLokiExporter{
 		config:   config,
 		settings: settings,
-	}
+		opts:     opts,
+	}, nil
 }
 
 func (l *nextLokiExporter) pushLogData(ctx context.Context, ld plog.Logs) error {
-	requests := loki.LogsToLokiRequests(ld)
+	requests := loki.LogsToLokiRequestsWithOptions(ld, l.opts)
 
 	var errs error
 	for tenant, request := range requests {
@@ -40,6 +40,8 @@ func TestPushLogData(t *testing.T) {
 		hints         map[string]interface{}
 		attrs         map[string]interface{}
 		res           map[string]interface{}
+		streamLabels  map[string]string
+		lineFormat    string
 		expectedLabel string
 		expectedLine  string
 	}{
@@ -67,6 +69,21 @@ func TestPushLogData(t *testing.T) {
 			expectedLabel: `{exporter="OTLP", host.name="guarana"}`,
 			expectedLine:  `{"traceid":"01020304000000000000000000000000","resources":{"region.az":"eu-west-1a"}}`,
 		},
+		{
+			desc: "with templated labels and logfmt lines",
+			res: map[string]interface{}{
+				"k8s.namespace.name": "shop",
+			},
+			attrs: map[string]interface{}{
+				"http.status": 200,
+			},
+			streamLabels: map[string]string{
+				"namespace": `{{ resource["k8s.namespace.name"] }}`,
+			},
+			lineFormat:    "logfmt",
+			expectedLabel: `{exporter="OTLP", namespace="shop"}`,
+			expectedLine:  `traceID=01020304000000000000000000000000 attribute_http.status=200 resource_k8s.namespace.name=shop`,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -89,6 +106,8 @@ func TestPushLogData(t *testing.T) {
 				HTTPClientSettings: confighttp.HTTPClientSettings{
 					Endpoint: ts.URL,
 				},
+				StreamLabels: tC.streamLabels,
+				LineFormat:   tC.lineFormat,
 			}
 
 			f := NewFactory()
@@ -0,0 +1,95 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/lokiexporter"
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"go.uber.org/zap"
+)
+
+const defaultStreamLimitInterval = time.Minute
+
+var defaultFallbackLabels = model.LabelSet{"exporter": "OTLP"}
+
+// streamLimiter caps the number of distinct streams per interval, the logs of the streams
+// beyond the limit being sent to a fallback stream.
+type streamLimiter struct {
+	logger    *zap.Logger
+	max       int
+	interval  time.Duration
+	fallback  model.LabelSet
+	nowFunc   func() time.Time
+	mu        sync.Mutex
+	start     time.Time
+	streams   map[string]struct{}
+	limitHits int
+}
+
+func newStreamLimiter(cfg StreamLimitConfig, logger *zap.Logger) *streamLimiter {
+	fallback := defaultFallbackLabels
+	if len(cfg.FallbackLabels) > 0 {
+		fallback = make(model.LabelSet, len(cfg.FallbackLabels))
+		for name, value := range cfg.FallbackLabels {
+			fallback[model.LabelName(name)] = model.LabelValue(value)
+		}
+	}
+
+	interval := cfg.Interval
+	if interval == 0 {
+		interval = defaultStreamLimitInterval
+	}
+
+	return &streamLimiter{
+		logger:   logger,
+		max:      cfg.MaxStreams,
+		interval: interval,
+		fallback: fallback,
+		nowFunc:  time.Now,
+		streams:  map[string]struct{}{},
+	}
+}
+
+// streamLabels returns the given labels when the stream is within the limit, the fallback labels otherwise.
+func (l *streamLimiter) streamLabels(tenant string, labels model.LabelSet) model.LabelSet {
+	key := tenant + labels.String()
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if now := l.nowFunc(); now.Sub(l.start) >= l.interval {
+		if l.limitHits > 0 {
+			l.logger.Warn("the number of streams exceeded the limit, logs were sent to the fallback stream",
+				zap.Int("max_streams", l.max),
+				zap.Int("logs", l.limitHits),
+				zap.Stringer("fallback_labels", l.fallback))
+		}
+		l.start = now
+		l.streams = map[string]struct{}{}
+		l.limitHits = 0
+	}
+
+	if _, ok := l.streams[key]; ok {
+		return labels
+	}
+	if len(l.streams) < l.max {
+		l.streams[key] = struct{}{}
+		return labels
+	}
+	l.limitHits++
+	return l.fallback
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package lokiexporter
+
+import (
+	"testing"
+	"time"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestStreamLimiter(t *testing.T) {
+	core, logs := observer.New(zap.WarnLevel)
+	limiter := newStreamLimiter(StreamLimitConfig{
+		MaxStreams:     2,
+		Interval:       time.Minute,
+		FallbackLabels: map[string]string{"exporter": "OTLP", "overflow": "true"},
+	}, zap.New(core))
+	now := time.Unix(1000, 0)
+	limiter.nowFunc = func() time.Time { return now }
+
+	fallback := model.LabelSet{"exporter": "OTLP", "overflow": "true"}
+	a := model.LabelSet{"app": "a"}
+	b := model.LabelSet{"app": "b"}
+	c := model.LabelSet{"app": "c"}
+
+	assert.Equal(t, a, limiter.streamLabels("", a))
+	assert.Equal(t, b, limiter.streamLabels("", b))
+	assert.Equal(t, fallback, limiter.streamLabels("", c))
+	// streams already seen in the interval are kept
+	assert.Equal(t, a, limiter.streamLabels("", a))
+	// the same labels for another tenant are another stream
+	assert.Equal(t, fallback, limiter.streamLabels("acme", a))
+	assert.Equal(t, 0, logs.Len())
+
+	// a new interval starts
+	now = now.Add(time.Minute)
+	assert.Equal(t, c, limiter.streamLabels("", c))
+	assert.Equal(t, 1, logs.Len())
+	assert.Equal(t, int64(2), logs.All()[0].ContextMap()["logs"])
+}
+
+func TestStreamLimiterDefaultFallback(t *testing.T) {
+	limiter := newStreamLimiter(StreamLimitConfig{MaxStreams: 1, Interval: time.Minute}, zap.NewNop())
+
+	assert.Equal(t, model.LabelSet{"app": "a"}, limiter.streamLabels("", model.LabelSet{"app": "a"}))
+	assert.Equal(t, model.LabelSet{"exporter": "OTLP"}, limiter.streamLabels("", model.LabelSet{"app": "b"}))
+}
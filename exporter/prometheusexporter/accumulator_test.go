@@ -47,6 +47,21 @@ func TestAccumulateDeltaAggregation(t *testing.T) {
 				dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
 			},
 		},
+		{
+			name: "ExponentialHistogram",
+			fillMetric: func(ts time.Time, metric pmetric.Metric) {
+				metric.SetName("test_metric")
+				metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+				metric.SetDescription("test description")
+				dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+				dp.Positive().BucketCounts().FromRaw([]uint64{5, 2})
+				dp.SetCount(7)
+				dp.SetSum(42.42)
+				dp.Attributes().PutStr("label_1", "1")
+				dp.Attributes().PutStr("label_2", "2")
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -176,6 +191,24 @@ func TestAccumulateMetrics(t *testing.T) {
 				dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
 			},
 		},
+		{
+			name: "ExponentialHistogram",
+			metric: func(ts time.Time, v float64, metrics pmetric.MetricSlice) {
+				metric := metrics.AppendEmpty()
+				metric.SetName("test_metric")
+				metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+				metric.SetDescription("test description")
+				dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+				dp.SetScale(2)
+				dp.Positive().SetOffset(1)
+				dp.Positive().BucketCounts().FromRaw([]uint64{5, 2})
+				dp.SetCount(7)
+				dp.SetSum(v)
+				dp.Attributes().PutStr("label_1", "1")
+				dp.Attributes().PutStr("label_2", "2")
+				dp.SetTimestamp(pcommon.NewTimestampFromTime(ts))
+			},
+		},
 		{
 			name: "Summary",
 			metric: func(ts time.Time, v float64, metrics pmetric.MetricSlice) {
@@ -557,6 +590,12 @@ func getMetricProperties(metric pmetric.Metric) (
 		value = metric.Histogram().DataPoints().At(0).Sum()
 		temporality = metric.Histogram().AggregationTemporality()
 		isMonotonic = true
+	case pmetric.MetricTypeExponentialHistogram:
+		attributes = metric.ExponentialHistogram().DataPoints().At(0).Attributes()
+		ts = metric.ExponentialHistogram().DataPoints().At(0).Timestamp().AsTime()
+		value = metric.ExponentialHistogram().DataPoints().At(0).Sum()
+		temporality = metric.ExponentialHistogram().AggregationTemporality()
+		isMonotonic = true
 	case pmetric.MetricTypeSummary:
 		attributes = metric.Summary().DataPoints().At(0).Attributes()
 		ts = metric.Summary().DataPoints().At(0).Timestamp().AsTime()
@@ -77,6 +77,8 @@ func (c *collector) convertMetric(metric pmetric.Metric, resourceAttrs pcommon.M
 		return c.convertSum(metric, resourceAttrs)
 	case pmetric.MetricTypeHistogram:
 		return c.convertDoubleHistogram(metric, resourceAttrs)
+	case pmetric.MetricTypeExponentialHistogram:
+		return c.convertExponentialHistogram(metric, resourceAttrs)
 	case pmetric.MetricTypeSummary:
 		return c.convertSummary(metric, resourceAttrs)
 	}
@@ -154,6 +156,14 @@ func (c *collector) convertSum(metric pmetric.Metric, resourceAttrs pcommon.Map)
 		return nil, err
 	}
 
+	// Only counters can carry exemplars
+	if metricType == prometheus.CounterValue && ip.Exemplars().Len() > 0 {
+		m, err = prometheus.NewMetricWithExemplars(m, convertExemplars(ip.Exemplars())...)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	if c.sendTimestamps {
 		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
 	}
@@ -212,35 +222,41 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 		points[bucket] = cumCount
 	}
 
-	arrLen := ip.Exemplars().Len()
-	exemplars := make([]prometheus.Exemplar, arrLen)
-
-	for i := 0; i < arrLen; i++ {
-		e := ip.Exemplars().At(i)
-		exemplarLabels := make(prometheus.Labels, 0)
-
-		if traceID := e.TraceID(); !traceID.IsEmpty() {
-			exemplarLabels["trace_id"] = hex.EncodeToString(traceID[:])
-		}
+	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), points, attributes...)
+	if err != nil {
+		return nil, err
+	}
 
-		if spanID := e.SpanID(); !spanID.IsEmpty() {
-			exemplarLabels["span_id"] = hex.EncodeToString(spanID[:])
+	if ip.Exemplars().Len() > 0 {
+		m, err = prometheus.NewMetricWithExemplars(m, convertExemplars(ip.Exemplars())...)
+		if err != nil {
+			return nil, err
 		}
+	}
 
-		exemplars[i] = prometheus.Exemplar{
-			Value:     e.DoubleValue(),
-			Labels:    exemplarLabels,
-			Timestamp: e.Timestamp().AsTime(),
-		}
+	if c.sendTimestamps {
+		return prometheus.NewMetricWithTimestamp(ip.Timestamp().AsTime(), m), nil
 	}
+	return m, nil
+}
 
-	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), points, attributes...)
+func (c *collector) convertExponentialHistogram(metric pmetric.Metric, resourceAttrs pcommon.Map) (prometheus.Metric, error) {
+	ip := metric.ExponentialHistogram().DataPoints().At(0)
+	desc, attributes := c.getMetricMetadata(metric, ip.Attributes(), resourceAttrs)
+
+	// The classic representation only has the count and the sum, the buckets are only exposed
+	// as a native histogram when the protobuf format is negotiated.
+	m, err := prometheus.NewConstHistogram(desc, ip.Count(), ip.Sum(), nil, attributes...)
+	if err != nil {
+		return nil, err
+	}
+	m, err = newNativeHistogram(m, ip)
 	if err != nil {
 		return nil, err
 	}
 
-	if arrLen > 0 {
-		m, err = prometheus.NewMetricWithExemplars(m, exemplars...)
+	if ip.Exemplars().Len() > 0 {
+		m, err = prometheus.NewMetricWithExemplars(m, convertExemplars(ip.Exemplars())...)
 		if err != nil {
 			return nil, err
 		}
@@ -252,6 +268,38 @@ func (c *collector) convertDoubleHistogram(metric pmetric.Metric, resourceAttrs
 	return m, nil
 }
 
+// convertExemplars converts the exemplars, keeping their trace and span ids as labels.
+func convertExemplars(exemplars pmetric.ExemplarSlice) []prometheus.Exemplar {
+	result := make([]prometheus.Exemplar, exemplars.Len())
+	for i := 0; i < exemplars.Len(); i++ {
+		e := exemplars.At(i)
+		exemplarLabels := make(prometheus.Labels, 0)
+
+		if traceID := e.TraceID(); !traceID.IsEmpty() {
+			exemplarLabels["trace_id"] = hex.EncodeToString(traceID[:])
+		}
+
+		if spanID := e.SpanID(); !spanID.IsEmpty() {
+			exemplarLabels["span_id"] = hex.EncodeToString(spanID[:])
+		}
+
+		var value float64
+		switch e.ValueType() {
+		case pmetric.ExemplarValueTypeInt:
+			value = float64(e.IntValue())
+		case pmetric.ExemplarValueTypeDouble:
+			value = e.DoubleValue()
+		}
+
+		result[i] = prometheus.Exemplar{
+			Value:     value,
+			Labels:    exemplarLabels,
+			Timestamp: e.Timestamp().AsTime(),
+		}
+	}
+	return result
+}
+
 func (c *collector) createTargetInfoMetrics(resourceAttrs []pcommon.Map) ([]prometheus.Metric, error) {
 	var metrics []prometheus.Metric
 	var lastErr error
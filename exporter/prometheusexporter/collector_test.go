@@ -16,6 +16,7 @@ package prometheusexporter
 
 import (
 	"encoding/hex"
+	"math"
 	"strings"
 	"testing"
 	"time"
@@ -160,6 +161,196 @@ func TestConvertDoubleHistogramExemplar(t *testing.T) {
 	require.Equal(t, "7436d6ac76178623", ml["span_id"])
 }
 
+func TestConvertSumExemplar(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetEmptySum().SetIsMonotonic(true)
+	metric.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.Sum().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetTraceID([16]byte{0x64, 0x1d, 0x68, 0xe3, 0x14, 0xa5, 0x81, 0x52, 0xcc, 0x25, 0x81, 0xe7, 0x66, 0x34, 0x35, 0xd1})
+	exemplar.SetSpanID([8]byte{0x74, 0x36, 0xd6, 0xac, 0x76, 0x17, 0x86, 0x23})
+	exemplar.SetTimestamp(pcommon.NewTimestampFromTime(time.Unix(1, 0)))
+	exemplar.SetIntValue(3)
+
+	c := collector{logger: zap.NewNop()}
+	pbMetric, err := c.convertSum(metric, pcommon.NewMap())
+	require.NoError(t, err)
+	m := io_prometheus_client.Metric{}
+	require.NoError(t, pbMetric.Write(&m))
+
+	e := m.GetCounter().GetExemplar()
+	require.NotNil(t, e)
+	require.Equal(t, 3.0, e.GetValue())
+	ml := make(map[string]string)
+	for _, l := range e.GetLabel() {
+		ml[l.GetName()] = l.GetValue()
+	}
+	require.Equal(t, map[string]string{"trace_id": "641d68e314a58152cc2581e7663435d1", "span_id": "7436d6ac76178623"}, ml)
+
+	// non-monotonic sums are gauges, which can't have exemplars
+	metric.Sum().SetIsMonotonic(false)
+	pbMetric, err = c.convertSum(metric, pcommon.NewMap())
+	require.NoError(t, err)
+	m = io_prometheus_client.Metric{}
+	require.NoError(t, pbMetric.Write(&m))
+	require.Equal(t, 42.0, m.GetGauge().GetValue())
+}
+
+func TestConvertExponentialHistogram(t *testing.T) {
+	tests := []struct {
+		name           string
+		scale          int32
+		offset         int32
+		counts         []uint64
+		negativeCounts []uint64
+		expectedSchema int32
+		expectedSpans  []*io_prometheus_client.BucketSpan
+		expectedDeltas []int64
+		expectedNegSp  []*io_prometheus_client.BucketSpan
+		expectedNegDel []int64
+		expectedErr    string
+	}{
+		{
+			name:           "contiguous buckets",
+			scale:          2,
+			offset:         -1,
+			counts:         []uint64{2, 5, 1},
+			expectedSchema: 2,
+			expectedSpans:  []*io_prometheus_client.BucketSpan{bucketSpan(0, 3)},
+			expectedDeltas: []int64{2, 3, -4},
+		},
+		{
+			name:           "empty buckets are skipped",
+			scale:          0,
+			offset:         3,
+			counts:         []uint64{1, 0, 0, 4, 0, 2},
+			negativeCounts: []uint64{3},
+			expectedSchema: 0,
+			expectedSpans:  []*io_prometheus_client.BucketSpan{bucketSpan(4, 1), bucketSpan(2, 1), bucketSpan(1, 1)},
+			expectedDeltas: []int64{1, 3, -2},
+			expectedNegSp:  []*io_prometheus_client.BucketSpan{bucketSpan(1, 1)},
+			expectedNegDel: []int64{3},
+		},
+		{
+			name:           "downscaled",
+			scale:          10,
+			offset:         -3,
+			counts:         []uint64{1, 2, 3, 4, 5},
+			expectedSchema: 8,
+			// indexes -3 to 1 at scale 10 are -1, -1, -1, 0, 0 at scale 8
+			expectedSpans:  []*io_prometheus_client.BucketSpan{bucketSpan(0, 2)},
+			expectedDeltas: []int64{6, 3},
+		},
+		{
+			name:           "no buckets",
+			scale:          3,
+			expectedSchema: 3,
+			expectedSpans:  []*io_prometheus_client.BucketSpan{bucketSpan(0, 0)},
+		},
+		{
+			name:        "unsupported scale",
+			scale:       -5,
+			expectedErr: "cannot convert exponential histogram with scale -5, the minimum supported scale is -4",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			metric := pmetric.NewMetric()
+			metric.SetName("test_metric")
+			metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+			dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+			dp.SetScale(tt.scale)
+			dp.SetCount(20)
+			dp.SetSum(100)
+			dp.SetZeroCount(4)
+			dp.Positive().SetOffset(tt.offset)
+			dp.Positive().BucketCounts().FromRaw(tt.counts)
+			dp.Negative().BucketCounts().FromRaw(tt.negativeCounts)
+
+			c := collector{logger: zap.NewNop()}
+			pbMetric, err := c.convertExponentialHistogram(metric, pcommon.NewMap())
+			if tt.expectedErr != "" {
+				require.EqualError(t, err, tt.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+
+			m := io_prometheus_client.Metric{}
+			require.NoError(t, pbMetric.Write(&m))
+			h := m.GetHistogram()
+			require.Equal(t, uint64(20), h.GetSampleCount())
+			require.Equal(t, 100.0, h.GetSampleSum())
+			require.Equal(t, uint64(4), h.GetZeroCount())
+			require.NotNil(t, h.ZeroThreshold)
+			require.Equal(t, 0.0, h.GetZeroThreshold())
+			require.Equal(t, tt.expectedSchema, h.GetSchema())
+			require.Equal(t, tt.expectedSpans, h.GetPositiveSpan())
+			require.Equal(t, tt.expectedDeltas, h.GetPositiveDelta())
+			require.Equal(t, tt.expectedNegSp, h.GetNegativeSpan())
+			require.Equal(t, tt.expectedNegDel, h.GetNegativeDelta())
+			require.Empty(t, h.GetBucket())
+		})
+	}
+}
+
+func TestNativeHistogramZeroThreshold(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(5)
+	dp.SetZeroCount(5)
+
+	c := collector{logger: zap.NewNop()}
+	pbMetric, err := c.convertExponentialHistogram(metric, pcommon.NewMap())
+	require.NoError(t, err)
+	h, ok := pbMetric.(*nativeHistogram)
+	require.True(t, ok)
+	h.zeroThreshold = 1e-3
+
+	m := io_prometheus_client.Metric{}
+	require.NoError(t, h.Write(&m))
+	require.Equal(t, 1e-3, m.GetHistogram().GetZeroThreshold())
+	require.Equal(t, uint64(5), m.GetHistogram().GetZeroCount())
+}
+
+func TestConvertExponentialHistogramExemplar(t *testing.T) {
+	metric := pmetric.NewMetric()
+	metric.SetName("test_metric")
+	metric.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	dp := metric.ExponentialHistogram().DataPoints().AppendEmpty()
+	dp.SetCount(2)
+	dp.SetSum(5)
+	dp.Positive().BucketCounts().FromRaw([]uint64{2})
+
+	exemplar := dp.Exemplars().AppendEmpty()
+	exemplar.SetTraceID([16]byte{0x64, 0x1d, 0x68, 0xe3, 0x14, 0xa5, 0x81, 0x52, 0xcc, 0x25, 0x81, 0xe7, 0x66, 0x34, 0x35, 0xd1})
+	exemplar.SetDoubleValue(2.5)
+
+	c := collector{logger: zap.NewNop()}
+	pbMetric, err := c.convertExponentialHistogram(metric, pcommon.NewMap())
+	require.NoError(t, err)
+	m := io_prometheus_client.Metric{}
+	require.NoError(t, pbMetric.Write(&m))
+
+	h := m.GetHistogram()
+	require.Equal(t, int32(0), h.GetSchema())
+	require.Equal(t, []int64{2}, h.GetPositiveDelta())
+	// the exemplar is attached to the +Inf bucket
+	require.Len(t, h.GetBucket(), 1)
+	require.Equal(t, math.Inf(1), h.GetBucket()[0].GetUpperBound())
+	require.Equal(t, uint64(2), h.GetBucket()[0].GetCumulativeCount())
+	require.Equal(t, 2.5, h.GetBucket()[0].GetExemplar().GetValue())
+}
+
+func bucketSpan(offset int32, length uint32) *io_prometheus_client.BucketSpan {
+	return &io_prometheus_client.BucketSpan{Offset: &offset, Length: &length}
+}
+
 // errorCheckCore keeps track of logged errors
 type errorCheckCore struct {
 	errorMessages []string
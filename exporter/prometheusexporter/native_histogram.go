@@ -0,0 +1,147 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package prometheusexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/prometheusexporter"
+
+import (
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	dto "github.com/prometheus/client_model/go"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+const (
+	// Prometheus native histograms support schemas from -4 to 8, the schema being the same as the OTLP scale.
+	minNativeHistogramSchema = -4
+	maxNativeHistogramSchema = 8
+)
+
+// nativeHistogram adds the buckets of an exponential histogram to a histogram, as Prometheus native histogram buckets.
+type nativeHistogram struct {
+	prometheus.Metric
+
+	schema         int32
+	zeroThreshold  float64
+	zeroCount      uint64
+	positiveSpans  []*dto.BucketSpan
+	positiveDeltas []int64
+	negativeSpans  []*dto.BucketSpan
+	negativeDeltas []int64
+}
+
+func newNativeHistogram(m prometheus.Metric, ip pmetric.ExponentialHistogramDataPoint) (prometheus.Metric, error) {
+	schema := ip.Scale()
+	if schema < minNativeHistogramSchema {
+		return nil, fmt.Errorf("cannot convert exponential histogram with scale %d, the minimum supported scale is %d", schema, minNativeHistogramSchema)
+	}
+	// Higher scales are downscaled by merging their buckets
+	var downscale int32
+	if schema > maxNativeHistogramSchema {
+		downscale = schema - maxNativeHistogramSchema
+		schema = maxNativeHistogramSchema
+	}
+
+	h := &nativeHistogram{
+		Metric:        m,
+		schema:        schema,
+		zeroThreshold: zeroThreshold(ip),
+		zeroCount:     ip.ZeroCount(),
+	}
+	h.positiveSpans, h.positiveDeltas = convertExponentialBuckets(ip.Positive(), downscale)
+	h.negativeSpans, h.negativeDeltas = convertExponentialBuckets(ip.Negative(), downscale)
+	if len(h.positiveSpans) == 0 && len(h.negativeSpans) == 0 {
+		// A span without buckets marks the histogram as a native histogram even without observations.
+		h.positiveSpans = []*dto.BucketSpan{{Offset: new(int32), Length: new(uint32)}}
+	}
+	return h, nil
+}
+
+func (h *nativeHistogram) Write(out *dto.Metric) error {
+	if err := h.Metric.Write(out); err != nil {
+		return err
+	}
+	if out.Histogram == nil {
+		return fmt.Errorf("expected a histogram for %s", h.Desc().String())
+	}
+
+	schema, zeroThreshold, zeroCount := h.schema, h.zeroThreshold, h.zeroCount
+	out.Histogram.Schema = &schema
+	out.Histogram.ZeroThreshold = &zeroThreshold
+	out.Histogram.ZeroCount = &zeroCount
+	out.Histogram.PositiveSpan = h.positiveSpans
+	out.Histogram.PositiveDelta = h.positiveDeltas
+	out.Histogram.NegativeSpan = h.negativeSpans
+	out.Histogram.NegativeDelta = h.negativeDeltas
+	return nil
+}
+
+// zeroThreshold returns the width of the zero bucket of an exponential histogram data point. The pdata version in use
+// doesn't decode the zero_threshold field of the OTLP data points yet, the zero bucket then only counts the exact zeros.
+func zeroThreshold(pmetric.ExponentialHistogramDataPoint) float64 {
+	return 0
+}
+
+// convertExponentialBuckets converts OTLP exponential buckets to the spans and the delta encoded counts of
+// Prometheus native histogram buckets, merging the buckets 2^downscale by 2^downscale.
+func convertExponentialBuckets(buckets pmetric.ExponentialHistogramDataPointBuckets, downscale int32) ([]*dto.BucketSpan, []int64) {
+	counts := buckets.BucketCounts()
+	if counts.Len() == 0 {
+		return nil, nil
+	}
+
+	// The OTLP bucket of index i is (base^i, base^(i+1)], its Prometheus index is i+1.
+	var (
+		spans  []*dto.BucketSpan
+		deltas []int64
+		// index of the next bucket of the current span
+		nextIndex int32
+		prevCount int64
+		// the merged bucket being built
+		index int32
+		count int64
+		empty = true
+	)
+	flush := func() {
+		if count == 0 {
+			return
+		}
+		if len(spans) == 0 || index != nextIndex {
+			gap := index
+			if len(spans) > 0 {
+				gap = index - nextIndex
+			}
+			length := uint32(0)
+			spans = append(spans, &dto.BucketSpan{Offset: &gap, Length: &length})
+		}
+		*spans[len(spans)-1].Length++
+		deltas = append(deltas, count-prevCount)
+		prevCount = count
+		nextIndex = index + 1
+	}
+
+	for i := 0; i < counts.Len(); i++ {
+		bucketIndex := ((buckets.Offset() + int32(i)) >> downscale) + 1
+		if !empty && bucketIndex != index {
+			flush()
+			count = 0
+		}
+		index = bucketIndex
+		count += int64(counts.At(i))
+		empty = false
+	}
+	flush()
+
+	return spans, deltas
+}
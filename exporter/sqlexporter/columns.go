@@ -0,0 +1,371 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sqlexporter"
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+const (
+	attributesFieldPrefix = "attributes."
+	resourceFieldPrefix   = "resource."
+)
+
+var columnNameRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+type valueKind int
+
+const (
+	kindString valueKind = iota
+	kindInt
+	kindDouble
+	kindTimestamp
+)
+
+// record holds the telemetry a row is built from. Only the item of the exported signal is set.
+type record struct {
+	resource   pcommon.Resource
+	scope      pcommon.InstrumentationScope
+	attributes pcommon.Map
+	log        plog.LogRecord
+	span       ptrace.Span
+	point      dataPoint
+}
+
+// dataPoint is a metric data point, flattened across the metric types.
+type dataPoint struct {
+	metric         pmetric.Metric
+	startTimestamp pcommon.Timestamp
+	timestamp      pcommon.Timestamp
+	// value is set for the gauge and sum data points.
+	value interface{}
+	// count and sum are set for the histogram and summary data points.
+	count interface{}
+	sum   interface{}
+}
+
+// field is a value of the telemetry that can be written to a column.
+type field struct {
+	kind valueKind
+	get  func(r *record) interface{}
+}
+
+// signal defines the fields available for a signal and the columns used by default.
+type signal struct {
+	fields         map[string]field
+	defaultColumns []string
+}
+
+// column is a resolved column of a table.
+type column struct {
+	name    string
+	sqlType string
+	get     func(r *record) interface{}
+}
+
+var commonFields = map[string]field{
+	"attributes": {kind: kindString, get: func(r *record) interface{} {
+		return jsonMap(r.attributes)
+	}},
+	"resource_attributes": {kind: kindString, get: func(r *record) interface{} {
+		return jsonMap(r.resource.Attributes())
+	}},
+	"scope_name": {kind: kindString, get: func(r *record) interface{} {
+		return r.scope.Name()
+	}},
+	"scope_version": {kind: kindString, get: func(r *record) interface{} {
+		return r.scope.Version()
+	}},
+}
+
+var logsSignal = signal{
+	fields: withCommonFields(map[string]field{
+		"timestamp": {kind: kindTimestamp, get: func(r *record) interface{} {
+			return timestamp(r.log.Timestamp())
+		}},
+		"observed_timestamp": {kind: kindTimestamp, get: func(r *record) interface{} {
+			return timestamp(r.log.ObservedTimestamp())
+		}},
+		"trace_id": {kind: kindString, get: func(r *record) interface{} {
+			return traceID(r.log.TraceID())
+		}},
+		"span_id": {kind: kindString, get: func(r *record) interface{} {
+			return spanID(r.log.SpanID())
+		}},
+		"flags": {kind: kindInt, get: func(r *record) interface{} {
+			return int64(r.log.Flags())
+		}},
+		"severity_number": {kind: kindInt, get: func(r *record) interface{} {
+			return int64(r.log.SeverityNumber())
+		}},
+		"severity_text": {kind: kindString, get: func(r *record) interface{} {
+			return r.log.SeverityText()
+		}},
+		"body": {kind: kindString, get: func(r *record) interface{} {
+			return r.log.Body().AsString()
+		}},
+	}),
+	defaultColumns: []string{
+		"timestamp", "observed_timestamp", "trace_id", "span_id", "severity_number", "severity_text", "body",
+		"attributes", "resource_attributes", "scope_name", "scope_version",
+	},
+}
+
+var tracesSignal = signal{
+	fields: withCommonFields(map[string]field{
+		"trace_id": {kind: kindString, get: func(r *record) interface{} {
+			return traceID(r.span.TraceID())
+		}},
+		"span_id": {kind: kindString, get: func(r *record) interface{} {
+			return spanID(r.span.SpanID())
+		}},
+		"parent_span_id": {kind: kindString, get: func(r *record) interface{} {
+			return spanID(r.span.ParentSpanID())
+		}},
+		"trace_state": {kind: kindString, get: func(r *record) interface{} {
+			return r.span.TraceState().AsRaw()
+		}},
+		"name": {kind: kindString, get: func(r *record) interface{} {
+			return r.span.Name()
+		}},
+		"kind": {kind: kindString, get: func(r *record) interface{} {
+			return r.span.Kind().String()
+		}},
+		"start_timestamp": {kind: kindTimestamp, get: func(r *record) interface{} {
+			return timestamp(r.span.StartTimestamp())
+		}},
+		"end_timestamp": {kind: kindTimestamp, get: func(r *record) interface{} {
+			return timestamp(r.span.EndTimestamp())
+		}},
+		"duration": {kind: kindInt, get: func(r *record) interface{} {
+			return int64(r.span.EndTimestamp() - r.span.StartTimestamp())
+		}},
+		"status_code": {kind: kindString, get: func(r *record) interface{} {
+			return r.span.Status().Code().String()
+		}},
+		"status_message": {kind: kindString, get: func(r *record) interface{} {
+			return r.span.Status().Message()
+		}},
+		"events": {kind: kindString, get: func(r *record) interface{} {
+			return jsonEvents(r.span.Events())
+		}},
+		"links": {kind: kindString, get: func(r *record) interface{} {
+			return jsonLinks(r.span.Links())
+		}},
+	}),
+	defaultColumns: []string{
+		"trace_id", "span_id", "parent_span_id", "trace_state", "name", "kind", "start_timestamp", "end_timestamp",
+		"duration", "status_code", "status_message", "attributes", "events", "links", "resource_attributes",
+		"scope_name", "scope_version",
+	},
+}
+
+var metricsSignal = signal{
+	fields: withCommonFields(map[string]field{
+		"metric_name": {kind: kindString, get: func(r *record) interface{} {
+			return r.point.metric.Name()
+		}},
+		"metric_description": {kind: kindString, get: func(r *record) interface{} {
+			return r.point.metric.Description()
+		}},
+		"metric_unit": {kind: kindString, get: func(r *record) interface{} {
+			return r.point.metric.Unit()
+		}},
+		"metric_type": {kind: kindString, get: func(r *record) interface{} {
+			return strings.ToLower(r.point.metric.Type().String())
+		}},
+		"start_timestamp": {kind: kindTimestamp, get: func(r *record) interface{} {
+			return timestamp(r.point.startTimestamp)
+		}},
+		"timestamp": {kind: kindTimestamp, get: func(r *record) interface{} {
+			return timestamp(r.point.timestamp)
+		}},
+		"value": {kind: kindDouble, get: func(r *record) interface{} {
+			return r.point.value
+		}},
+		"count": {kind: kindInt, get: func(r *record) interface{} {
+			return r.point.count
+		}},
+		"sum": {kind: kindDouble, get: func(r *record) interface{} {
+			return r.point.sum
+		}},
+	}),
+	defaultColumns: []string{
+		"metric_name", "metric_description", "metric_unit", "metric_type", "start_timestamp", "timestamp",
+		"value", "count", "sum", "attributes", "resource_attributes", "scope_name", "scope_version",
+	},
+}
+
+func withCommonFields(fields map[string]field) map[string]field {
+	for name, f := range commonFields {
+		fields[name] = f
+	}
+	return fields
+}
+
+// newColumns resolves the configured columns, or the default columns of the signal when none is configured.
+func newColumns(cfgs []ColumnConfig, s signal, driver string) ([]column, error) {
+	if len(cfgs) == 0 {
+		for _, name := range s.defaultColumns {
+			cfgs = append(cfgs, ColumnConfig{Name: name})
+		}
+	}
+
+	columns := make([]column, 0, len(cfgs))
+	names := map[string]bool{}
+	for _, cfg := range cfgs {
+		if !columnNameRegexp.MatchString(cfg.Name) {
+			return nil, fmt.Errorf("invalid column name '%s'", cfg.Name)
+		}
+		if names[strings.ToLower(cfg.Name)] {
+			return nil, fmt.Errorf("duplicate column '%s'", cfg.Name)
+		}
+		names[strings.ToLower(cfg.Name)] = true
+
+		name := cfg.Field
+		if name == "" {
+			name = cfg.Name
+		}
+		f, err := resolveField(name, s.fields)
+		if err != nil {
+			return nil, err
+		}
+
+		sqlType := cfg.Type
+		if sqlType == "" {
+			sqlType = dialects[driver].types[f.kind]
+		}
+		columns = append(columns, column{name: cfg.Name, sqlType: sqlType, get: f.get})
+	}
+	return columns, nil
+}
+
+func resolveField(name string, fields map[string]field) (field, error) {
+	if f, ok := fields[name]; ok {
+		return f, nil
+	}
+	switch {
+	case strings.HasPrefix(name, attributesFieldPrefix) && len(name) > len(attributesFieldPrefix):
+		key := strings.TrimPrefix(name, attributesFieldPrefix)
+		return field{kind: kindString, get: func(r *record) interface{} {
+			return attributeValue(r.attributes, key)
+		}}, nil
+	case strings.HasPrefix(name, resourceFieldPrefix) && len(name) > len(resourceFieldPrefix):
+		key := strings.TrimPrefix(name, resourceFieldPrefix)
+		return field{kind: kindString, get: func(r *record) interface{} {
+			return attributeValue(r.resource.Attributes(), key)
+		}}, nil
+	}
+	return field{}, fmt.Errorf("unknown field '%s'", name)
+}
+
+func attributeValue(attrs pcommon.Map, key string) interface{} {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return nil
+}
+
+func timestamp(ts pcommon.Timestamp) interface{} {
+	if ts == 0 {
+		return nil
+	}
+	return ts.AsTime()
+}
+
+func traceID(id pcommon.TraceID) interface{} {
+	if id.IsEmpty() {
+		return nil
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func spanID(id pcommon.SpanID) interface{} {
+	if id.IsEmpty() {
+		return nil
+	}
+	return hex.EncodeToString(id[:])
+}
+
+func jsonMap(m pcommon.Map) interface{} {
+	return jsonString(m.AsRaw())
+}
+
+func jsonEvents(events ptrace.SpanEventSlice) interface{} {
+	out := make([]map[string]interface{}, 0, events.Len())
+	for i := 0; i < events.Len(); i++ {
+		event := events.At(i)
+		out = append(out, map[string]interface{}{
+			"name":       event.Name(),
+			"timestamp":  event.Timestamp().AsTime(),
+			"attributes": event.Attributes().AsRaw(),
+		})
+	}
+	return jsonString(out)
+}
+
+func jsonLinks(links ptrace.SpanLinkSlice) interface{} {
+	out := make([]map[string]interface{}, 0, links.Len())
+	for i := 0; i < links.Len(); i++ {
+		link := links.At(i)
+		out = append(out, map[string]interface{}{
+			"trace_id":    traceID(link.TraceID()),
+			"span_id":     spanID(link.SpanID()),
+			"trace_state": link.TraceState().AsRaw(),
+			"attributes":  link.Attributes().AsRaw(),
+		})
+	}
+	return jsonString(out)
+}
+
+func jsonString(v interface{}) string {
+	// the values come from pdata, which only holds JSON compatible values once the non-finite floats are replaced
+	raw, _ := json.Marshal(finiteFloats(v))
+	return string(raw)
+}
+
+// finiteFloats replaces the NaN and infinite floats, which can't be encoded in JSON, by their string representation.
+func finiteFloats(v interface{}) interface{} {
+	switch v := v.(type) {
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return strconv.FormatFloat(v, 'g', -1, 64)
+		}
+	case map[string]interface{}:
+		for key, value := range v {
+			v[key] = finiteFloats(value)
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = finiteFloats(value)
+		}
+	case []map[string]interface{}:
+		for _, value := range v {
+			finiteFloats(value)
+		}
+	}
+	return v
+}
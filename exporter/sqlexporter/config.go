@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sqlexporter"
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+	"go.uber.org/multierr"
+)
+
+// identifierRegexp matches the table and column names, which are inserted in the statements as is.
+var identifierRegexp = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*(\.[A-Za-z_][A-Za-z0-9_]*)?$`)
+
+// Config defines configuration for the SQL exporter.
+type Config struct {
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Driver is the name of the database/sql driver.
+	Driver string `mapstructure:"driver"`
+
+	// DataSource is the driver-specific data source name passed to sql.Open.
+	DataSource string `mapstructure:"datasource"`
+
+	// CreateTables creates the tables, if they don't exist, when the exporter starts.
+	CreateTables bool `mapstructure:"create_tables"`
+
+	// Logs defines the table the log records are written to.
+	Logs TableConfig `mapstructure:"logs"`
+
+	// Traces defines the table the spans are written to.
+	Traces TableConfig `mapstructure:"traces"`
+
+	// Metrics defines the table the metric data points are written to.
+	Metrics TableConfig `mapstructure:"metrics"`
+}
+
+// TableConfig defines a table and how its columns are filled.
+type TableConfig struct {
+	// Table is the name of the table, optionally prefixed by its schema.
+	Table string `mapstructure:"table"`
+
+	// Columns are the columns of the table. A default set of columns is used when empty.
+	Columns []ColumnConfig `mapstructure:"columns"`
+}
+
+// ColumnConfig maps a field of the telemetry to a column.
+type ColumnConfig struct {
+	// Name is the name of the column.
+	Name string `mapstructure:"name"`
+
+	// Field is the field written to the column, see the README for the available fields.
+	// It defaults to the name of the column.
+	Field string `mapstructure:"field"`
+
+	// Type is the SQL type of the column used when creating the table. It defaults to
+	// a type suitable for the field and the driver.
+	Type string `mapstructure:"type"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Driver == "" {
+		return errors.New("'driver' cannot be empty")
+	}
+	if cfg.DataSource == "" {
+		return errors.New("'datasource' cannot be empty")
+	}
+	if _, ok := dialects[cfg.Driver]; !ok {
+		return fmt.Errorf("unsupported driver '%s'", cfg.Driver)
+	}
+	if cfg.CreateTables && !dialects[cfg.Driver].createTables {
+		return fmt.Errorf("'create_tables' is not supported with the '%s' driver", cfg.Driver)
+	}
+
+	var errs error
+	for _, table := range []struct {
+		name   string
+		cfg    TableConfig
+		signal signal
+	}{
+		{name: "logs", cfg: cfg.Logs, signal: logsSignal},
+		{name: "traces", cfg: cfg.Traces, signal: tracesSignal},
+		{name: "metrics", cfg: cfg.Metrics, signal: metricsSignal},
+	} {
+		if !identifierRegexp.MatchString(table.cfg.Table) {
+			errs = multierr.Append(errs, fmt.Errorf("'%s.table': invalid table name '%s'", table.name, table.cfg.Table))
+		}
+		if _, err := newColumns(table.cfg.Columns, table.signal, cfg.Driver); err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("'%s.columns': %w", table.name, err))
+		}
+	}
+	return errs
+}
@@ -0,0 +1,179 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(typeStr, ""),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Driver = "postgres"
+				cfg.DataSource = "host=localhost port=5432 user=postgres password=s3cr3t sslmode=disable"
+				return cfg
+			}(),
+		},
+		{
+			id: component.NewIDWithName(typeStr, "allsettings"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutSettings{
+					Timeout: 10 * time.Second,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 2,
+					QueueSize:    10,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:         true,
+					InitialInterval: 10 * time.Second,
+					MaxInterval:     1 * time.Minute,
+					MaxElapsedTime:  10 * time.Minute,
+				},
+				Driver:       "mysql",
+				DataSource:   "otel:s3cr3t@tcp(localhost:3306)/otel",
+				CreateTables: true,
+				Logs: TableConfig{
+					Table: "audit.events",
+					Columns: []ColumnConfig{
+						{Name: "ts", Field: "timestamp"},
+						{Name: "body"},
+						{Name: "user_name", Field: "attributes.user.name", Type: "VARCHAR(255)"},
+						{Name: "service", Field: "resource.service.name"},
+					},
+				},
+				Traces:  TableConfig{Table: "spans"},
+				Metrics: TableConfig{Table: "datapoints"},
+			},
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "unknown_field"),
+			errorMessage: "'logs.columns': unknown field 'level'",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "create_tables_unsupported"),
+			errorMessage: "'create_tables' is not supported with the 'sqlserver' driver",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "missing_driver",
+			modify:  func(cfg *Config) { cfg.Driver = "" },
+			wantErr: "'driver' cannot be empty",
+		},
+		{
+			name:    "missing_datasource",
+			modify:  func(cfg *Config) { cfg.DataSource = "" },
+			wantErr: "'datasource' cannot be empty",
+		},
+		{
+			name:    "unsupported_driver",
+			modify:  func(cfg *Config) { cfg.Driver = "mongodb" },
+			wantErr: "unsupported driver 'mongodb'",
+		},
+		{
+			name: "invalid_names",
+			modify: func(cfg *Config) {
+				cfg.Logs.Table = "logs; DROP TABLE users"
+				cfg.Metrics.Columns = []ColumnConfig{{Name: "metric name", Field: "metric_name"}}
+			},
+			wantErr: "'logs.table': invalid table name 'logs; DROP TABLE users'; " +
+				"'metrics.columns': invalid column name 'metric name'",
+		},
+		{
+			name: "duplicate_column",
+			modify: func(cfg *Config) {
+				cfg.Traces.Columns = []ColumnConfig{{Name: "name"}, {Name: "NAME", Field: "span_id"}}
+			},
+			wantErr: "'traces.columns': duplicate column 'NAME'",
+		},
+		{
+			name: "attribute_fields",
+			modify: func(cfg *Config) {
+				cfg.Traces.Columns = []ColumnConfig{
+					{Name: "http_method", Field: "attributes.http.method"},
+					{Name: "service", Field: "resource.service.name"},
+				}
+			},
+		},
+		{
+			name: "empty_attribute_key",
+			modify: func(cfg *Config) {
+				cfg.Traces.Columns = []ColumnConfig{{Name: "attribute", Field: "attributes."}}
+			},
+			wantErr: "'traces.columns': unknown field 'attributes.'",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Driver = "sqlite"
+			cfg.DataSource = "file:otel.db"
+			tt.modify(cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.wantErr)
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
@@ -0,0 +1,108 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sqlexporter"
+
+import (
+	"strconv"
+
+	// register db drivers
+	_ "github.com/SAP/go-hdb/driver"
+	_ "github.com/denisenkom/go-mssqldb"
+	_ "github.com/go-sql-driver/mysql"
+	_ "github.com/lib/pq"
+	_ "github.com/sijms/go-ora/v2"
+	_ "github.com/snowflakedb/gosnowflake"
+	_ "modernc.org/sqlite"
+)
+
+// dialect holds the differences between the SQL dialects of the databases.
+type dialect struct {
+	// placeholder returns the placeholder of the i-th (1-based) parameter of a statement.
+	placeholder func(i int) string
+	// maxParameters is the maximum number of parameters of an insert statement writing several rows, 0 when the
+	// database doesn't support inserting several rows with a single VALUES clause.
+	maxParameters int
+	// createTables tells whether the database supports "CREATE TABLE IF NOT EXISTS".
+	createTables bool
+	// types are the default column types used when creating the tables.
+	types map[valueKind]string
+}
+
+var dialects = map[string]dialect{
+	"postgres": {
+		placeholder:   numberedPlaceholder("$"),
+		maxParameters: 65535,
+		createTables:  true,
+		types: map[valueKind]string{
+			kindString:    "TEXT",
+			kindInt:       "BIGINT",
+			kindDouble:    "DOUBLE PRECISION",
+			kindTimestamp: "TIMESTAMP WITH TIME ZONE",
+		},
+	},
+	"mysql": {
+		placeholder:   questionMarkPlaceholder,
+		maxParameters: 65535,
+		createTables:  true,
+		types: map[valueKind]string{
+			kindString:    "TEXT",
+			kindInt:       "BIGINT",
+			kindDouble:    "DOUBLE",
+			kindTimestamp: "DATETIME(6)",
+		},
+	},
+	"snowflake": {
+		placeholder:   questionMarkPlaceholder,
+		maxParameters: 65535,
+		createTables:  true,
+		types: map[valueKind]string{
+			kindString:    "TEXT",
+			kindInt:       "BIGINT",
+			kindDouble:    "DOUBLE",
+			kindTimestamp: "TIMESTAMP_TZ",
+		},
+	},
+	"sqlite": {
+		placeholder:   questionMarkPlaceholder,
+		maxParameters: 999,
+		createTables:  true,
+		types: map[valueKind]string{
+			kindString:    "TEXT",
+			kindInt:       "INTEGER",
+			kindDouble:    "REAL",
+			kindTimestamp: "TIMESTAMP",
+		},
+	},
+	"sqlserver": {
+		placeholder:   numberedPlaceholder("@p"),
+		maxParameters: 2099,
+	},
+	"oracle": {
+		placeholder: numberedPlaceholder(":"),
+	},
+	"hdb": {
+		placeholder: questionMarkPlaceholder,
+	},
+}
+
+func questionMarkPlaceholder(int) string {
+	return "?"
+}
+
+func numberedPlaceholder(prefix string) func(i int) string {
+	return func(i int) string {
+		return prefix + strconv.Itoa(i)
+	}
+}
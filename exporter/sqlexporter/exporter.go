@@ -0,0 +1,289 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sqlexporter"
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// maxInsertRows is the maximum number of rows written by a single insert statement.
+const maxInsertRows = 1000
+
+// sqlExporter writes the telemetry of a signal to a table, one row per log record, span or data point.
+type sqlExporter struct {
+	cfg     *Config
+	table   string
+	columns []column
+	// batchSize is the number of rows written by an insert statement.
+	batchSize int
+	db        *sql.DB
+}
+
+func newExporter(cfg *Config, table TableConfig, s signal) (*sqlExporter, error) {
+	columns, err := newColumns(table.Columns, s, cfg.Driver)
+	if err != nil {
+		return nil, err
+	}
+
+	batchSize := 1
+	if maxParameters := dialects[cfg.Driver].maxParameters; maxParameters > 0 {
+		batchSize = maxParameters / len(columns)
+		if batchSize > maxInsertRows {
+			batchSize = maxInsertRows
+		}
+		if batchSize < 1 {
+			batchSize = 1
+		}
+	}
+
+	return &sqlExporter{
+		cfg:       cfg,
+		table:     table.Table,
+		columns:   columns,
+		batchSize: batchSize,
+	}, nil
+}
+
+// insertSQL returns the statement inserting the given number of rows.
+func (e *sqlExporter) insertSQL(rows int) string {
+	names := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		names[i] = c.name
+	}
+	placeholder := dialects[e.cfg.Driver].placeholder
+	values := make([]string, rows)
+	placeholders := make([]string, len(e.columns))
+	for r := range values {
+		for i := range e.columns {
+			placeholders[i] = placeholder(r*len(e.columns) + i + 1)
+		}
+		values[r] = "(" + strings.Join(placeholders, ", ") + ")"
+	}
+	return fmt.Sprintf("INSERT INTO %s (%s) VALUES %s", e.table, strings.Join(names, ", "), strings.Join(values, ", "))
+}
+
+func (e *sqlExporter) start(ctx context.Context, _ component.Host) error {
+	db, err := sql.Open(e.cfg.Driver, e.cfg.DataSource)
+	if err != nil {
+		return fmt.Errorf("failed to open the database: %w", err)
+	}
+	e.db = db
+
+	if e.cfg.CreateTables {
+		if _, err = db.ExecContext(ctx, e.createTableSQL()); err != nil {
+			return fmt.Errorf("failed to create the table %s: %w", e.table, err)
+		}
+	}
+	return nil
+}
+
+func (e *sqlExporter) shutdown(context.Context) error {
+	if e.db == nil {
+		return nil
+	}
+	return e.db.Close()
+}
+
+func (e *sqlExporter) createTableSQL() string {
+	definitions := make([]string, len(e.columns))
+	for i, c := range e.columns {
+		definitions[i] = c.name + " " + c.sqlType
+	}
+	return fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (%s)", e.table, strings.Join(definitions, ", "))
+}
+
+func (e *sqlExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	rows := make([][]interface{}, 0, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			sl := rl.ScopeLogs().At(j)
+			for k := 0; k < sl.LogRecords().Len(); k++ {
+				lr := sl.LogRecords().At(k)
+				rows = append(rows, e.row(&record{
+					resource:   rl.Resource(),
+					scope:      sl.Scope(),
+					attributes: lr.Attributes(),
+					log:        lr,
+				}))
+			}
+		}
+	}
+	return e.insert(ctx, rows)
+}
+
+func (e *sqlExporter) pushTraces(ctx context.Context, td ptrace.Traces) error {
+	rows := make([][]interface{}, 0, td.SpanCount())
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			ss := rs.ScopeSpans().At(j)
+			for k := 0; k < ss.Spans().Len(); k++ {
+				span := ss.Spans().At(k)
+				rows = append(rows, e.row(&record{
+					resource:   rs.Resource(),
+					scope:      ss.Scope(),
+					attributes: span.Attributes(),
+					span:       span,
+				}))
+			}
+		}
+	}
+	return e.insert(ctx, rows)
+}
+
+func (e *sqlExporter) pushMetrics(ctx context.Context, md pmetric.Metrics) error {
+	rows := make([][]interface{}, 0, md.DataPointCount())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		for j := 0; j < rm.ScopeMetrics().Len(); j++ {
+			sm := rm.ScopeMetrics().At(j)
+			for k := 0; k < sm.Metrics().Len(); k++ {
+				metric := sm.Metrics().At(k)
+				forEachDataPoint(metric, func(attributes pcommon.Map, dp dataPoint) {
+					rows = append(rows, e.row(&record{
+						resource:   rm.Resource(),
+						scope:      sm.Scope(),
+						attributes: attributes,
+						point:      dp,
+					}))
+				})
+			}
+		}
+	}
+	return e.insert(ctx, rows)
+}
+
+func (e *sqlExporter) row(r *record) []interface{} {
+	values := make([]interface{}, len(e.columns))
+	for i, c := range e.columns {
+		values[i] = c.get(r)
+	}
+	return values
+}
+
+// insert writes the rows in a single transaction, in batches of batchSize rows written by a prepared statement.
+func (e *sqlExporter) insert(ctx context.Context, rows [][]interface{}) error {
+	if len(rows) == 0 {
+		return nil
+	}
+
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin the transaction: %w", err)
+	}
+
+	batchSize := e.batchSize
+	if len(rows) < batchSize {
+		batchSize = len(rows)
+	}
+	stmt, err := tx.PrepareContext(ctx, e.insertSQL(batchSize))
+	if err != nil {
+		_ = tx.Rollback()
+		return fmt.Errorf("failed to prepare the insert statement: %w", err)
+	}
+	defer stmt.Close()
+
+	for start := 0; start < len(rows); start += batchSize {
+		batch := rows[start:]
+		if len(batch) > batchSize {
+			batch = batch[:batchSize]
+		}
+		args := make([]interface{}, 0, len(batch)*len(e.columns))
+		for _, row := range batch {
+			args = append(args, row...)
+		}
+		if len(batch) == batchSize {
+			_, err = stmt.ExecContext(ctx, args...)
+		} else {
+			// the last batch is smaller
+			_, err = tx.ExecContext(ctx, e.insertSQL(len(batch)), args...)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("failed to insert into %s: %w", e.table, err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit the transaction: %w", err)
+	}
+	return nil
+}
+
+// forEachDataPoint calls fn with every data point of the metric.
+func forEachDataPoint(metric pmetric.Metric, fn func(attributes pcommon.Map, dp dataPoint)) {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		forEachNumberDataPoint(metric, metric.Gauge().DataPoints(), fn)
+	case pmetric.MetricTypeSum:
+		forEachNumberDataPoint(metric, metric.Sum().DataPoints(), fn)
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p := dps.At(i)
+			dp := dataPoint{metric: metric, startTimestamp: p.StartTimestamp(), timestamp: p.Timestamp(), count: int64(p.Count())}
+			if p.HasSum() {
+				dp.sum = p.Sum()
+			}
+			fn(p.Attributes(), dp)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := metric.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p := dps.At(i)
+			dp := dataPoint{metric: metric, startTimestamp: p.StartTimestamp(), timestamp: p.Timestamp(), count: int64(p.Count())}
+			if p.HasSum() {
+				dp.sum = p.Sum()
+			}
+			fn(p.Attributes(), dp)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := metric.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			p := dps.At(i)
+			fn(p.Attributes(), dataPoint{
+				metric:         metric,
+				startTimestamp: p.StartTimestamp(),
+				timestamp:      p.Timestamp(),
+				count:          int64(p.Count()),
+				sum:            p.Sum(),
+			})
+		}
+	}
+}
+
+func forEachNumberDataPoint(metric pmetric.Metric, dps pmetric.NumberDataPointSlice, fn func(attributes pcommon.Map, dp dataPoint)) {
+	for i := 0; i < dps.Len(); i++ {
+		p := dps.At(i)
+		dp := dataPoint{metric: metric, startTimestamp: p.StartTimestamp(), timestamp: p.Timestamp()}
+		switch p.ValueType() {
+		case pmetric.NumberDataPointValueTypeDouble:
+			dp.value = p.DoubleValue()
+		case pmetric.NumberDataPointValueTypeInt:
+			dp.value = float64(p.IntValue())
+		}
+		fn(p.Attributes(), dp)
+	}
+}
@@ -0,0 +1,298 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlexporter
+
+import (
+	"context"
+	"database/sql"
+	"math"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	testTimestamp = pcommon.NewTimestampFromTime(time.Date(2023, 1, 12, 10, 15, 30, 0, time.UTC))
+	testTraceID   = pcommon.TraceID([16]byte{1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16})
+	testSpanID    = pcommon.SpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+)
+
+// newTestConfig returns a configuration writing to a sqlite database in a temporary directory.
+func newTestConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Driver = "sqlite"
+	cfg.DataSource = "file:" + filepath.Join(t.TempDir(), "otel.db")
+	cfg.CreateTables = true
+	return cfg
+}
+
+func startExporter(t *testing.T, cfg *Config, table TableConfig, s signal) *sqlExporter {
+	require.NoError(t, cfg.Validate())
+	exp, err := newExporter(cfg, table, s)
+	require.NoError(t, err)
+	require.NoError(t, exp.start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	})
+	return exp
+}
+
+// queryRows returns the rows of the query, with the NULL values as nil.
+func queryRows(t *testing.T, db *sql.DB, query string) [][]interface{} {
+	rows, err := db.Query(query)
+	require.NoError(t, err)
+	defer rows.Close()
+
+	columns, err := rows.Columns()
+	require.NoError(t, err)
+
+	var out [][]interface{}
+	for rows.Next() {
+		values := make([]interface{}, len(columns))
+		dest := make([]interface{}, len(columns))
+		for i := range values {
+			dest[i] = &values[i]
+		}
+		require.NoError(t, rows.Scan(dest...))
+		out = append(out, values)
+	}
+	require.NoError(t, rows.Err())
+	return out
+}
+
+func TestPushLogs(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := startExporter(t, cfg, cfg.Logs, logsSignal)
+
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("service.name", "checkout")
+	sl := rl.ScopeLogs().AppendEmpty()
+	sl.Scope().SetName("audit")
+	sl.Scope().SetVersion("1.0")
+	lr := sl.LogRecords().AppendEmpty()
+	lr.SetTimestamp(testTimestamp)
+	lr.SetTraceID(testTraceID)
+	lr.SetSpanID(testSpanID)
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("WARN")
+	lr.Body().SetStr("payment declined")
+	lr.Attributes().PutStr("user", "jane")
+	sl.LogRecords().AppendEmpty().Body().SetStr("no timestamp")
+
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+
+	rows := queryRows(t, exp.db, "SELECT * FROM otel_logs")
+	assert.Equal(t, [][]interface{}{
+		{
+			testTimestamp.AsTime(), nil, "0102030405060708090a0b0c0d0e0f10", "0102030405060708", int64(13), "WARN",
+			"payment declined", `{"user":"jane"}`, `{"service.name":"checkout"}`, "audit", "1.0",
+		},
+		{
+			nil, nil, nil, nil, int64(0), "",
+			"no timestamp", `{}`, `{"service.name":"checkout"}`, "audit", "1.0",
+		},
+	}, rows)
+}
+
+func TestPushNonFiniteAttributes(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Logs.Columns = []ColumnConfig{{Name: "attributes"}}
+	exp := startExporter(t, cfg, cfg.Logs, logsSignal)
+
+	ld := plog.NewLogs()
+	lr := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	lr.Attributes().PutDouble("ratio", math.NaN())
+	lr.Attributes().PutDouble("max", math.Inf(1))
+	values := lr.Attributes().PutEmptySlice("values")
+	values.AppendEmpty().SetDouble(1.5)
+	values.AppendEmpty().SetDouble(math.Inf(-1))
+
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+	assert.Equal(t, [][]interface{}{{`{"max":"+Inf","ratio":"NaN","values":[1.5,"-Inf"]}`}},
+		queryRows(t, exp.db, "SELECT * FROM otel_logs"))
+}
+
+func TestPushTraces(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := startExporter(t, cfg, cfg.Traces, tracesSignal)
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	span := rs.ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetTraceID(testTraceID)
+	span.SetSpanID(testSpanID)
+	span.SetName("GET /cart")
+	span.SetKind(ptrace.SpanKindServer)
+	span.SetStartTimestamp(testTimestamp)
+	span.SetEndTimestamp(testTimestamp + 1500)
+	span.Status().SetCode(ptrace.StatusCodeError)
+	span.Status().SetMessage("timeout")
+	span.Attributes().PutInt("http.status_code", 504)
+	event := span.Events().AppendEmpty()
+	event.SetName("retry")
+	event.SetTimestamp(testTimestamp)
+	link := span.Links().AppendEmpty()
+	link.SetTraceID(testTraceID)
+	link.SetSpanID(testSpanID)
+
+	require.NoError(t, exp.pushTraces(context.Background(), td))
+
+	rows := queryRows(t, exp.db, "SELECT * FROM otel_traces")
+	assert.Equal(t, [][]interface{}{
+		{
+			"0102030405060708090a0b0c0d0e0f10", "0102030405060708", nil, "", "GET /cart", "Server",
+			testTimestamp.AsTime(), testTimestamp.AsTime().Add(1500), int64(1500), "Error", "timeout",
+			`{"http.status_code":504}`,
+			`[{"attributes":{},"name":"retry","timestamp":"2023-01-12T10:15:30Z"}]`,
+			`[{"attributes":{},"span_id":"0102030405060708","trace_id":"0102030405060708090a0b0c0d0e0f10","trace_state":""}]`,
+			`{"service.name":"checkout"}`, "", "",
+		},
+	}, rows)
+}
+
+func TestPushMetrics(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Metrics.Columns = []ColumnConfig{
+		{Name: "name", Field: "metric_name"},
+		{Name: "type", Field: "metric_type"},
+		{Name: "value"},
+		{Name: "count"},
+		{Name: "sum"},
+		{Name: "host", Field: "attributes.host"},
+	}
+	exp := startExporter(t, cfg, cfg.Metrics, metricsSignal)
+
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	gauge := metrics.AppendEmpty()
+	gauge.SetName("queue.size")
+	dp := gauge.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(42)
+	dp.Attributes().PutStr("host", "a")
+
+	sum := metrics.AppendEmpty()
+	sum.SetName("requests")
+	sum.SetEmptySum().DataPoints().AppendEmpty().SetDoubleValue(1.5)
+
+	histogram := metrics.AppendEmpty()
+	histogram.SetName("latency")
+	hdp := histogram.SetEmptyHistogram().DataPoints().AppendEmpty()
+	hdp.SetCount(3)
+	hdp.SetSum(0.75)
+
+	summary := metrics.AppendEmpty()
+	summary.SetName("duration")
+	sdp := summary.SetEmptySummary().DataPoints().AppendEmpty()
+	sdp.SetCount(2)
+	sdp.SetSum(4)
+
+	require.NoError(t, exp.pushMetrics(context.Background(), md))
+
+	rows := queryRows(t, exp.db, "SELECT * FROM otel_metrics")
+	assert.Equal(t, [][]interface{}{
+		{"queue.size", "gauge", float64(42), nil, nil, "a"},
+		{"requests", "sum", 1.5, nil, nil, nil},
+		{"latency", "histogram", nil, int64(3), 0.75, nil},
+		{"duration", "summary", nil, int64(2), float64(4), nil},
+	}, rows)
+}
+
+func TestPushRollsBack(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.CreateTables = false
+	cfg.Logs.Columns = []ColumnConfig{{Name: "body"}, {Name: "user", Field: "attributes.user"}}
+	exp := startExporter(t, cfg, cfg.Logs, logsSignal)
+
+	_, err := exp.db.Exec("CREATE TABLE otel_logs (body TEXT, user TEXT NOT NULL)")
+	require.NoError(t, err)
+
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	records.AppendEmpty().Attributes().PutStr("user", "jane")
+	records.AppendEmpty().Body().SetStr("anonymous")
+
+	assert.ErrorContains(t, exp.pushLogs(context.Background(), ld), "failed to insert into otel_logs")
+	assert.Empty(t, queryRows(t, exp.db, "SELECT * FROM otel_logs"))
+}
+
+func TestPushBatches(t *testing.T) {
+	cfg := newTestConfig(t)
+	exp := startExporter(t, cfg, cfg.Logs, logsSignal)
+	require.Equal(t, 999/len(logsSignal.defaultColumns), exp.batchSize)
+
+	// two full batches and a smaller one
+	ld := plog.NewLogs()
+	records := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 2*exp.batchSize+5; i++ {
+		records.AppendEmpty().Body().SetInt(int64(i))
+	}
+	require.NoError(t, exp.pushLogs(context.Background(), ld))
+
+	rows := queryRows(t, exp.db, "SELECT COUNT(*), COUNT(DISTINCT body) FROM otel_logs")
+	assert.Equal(t, [][]interface{}{{int64(2*exp.batchSize + 5), int64(2*exp.batchSize + 5)}}, rows)
+}
+
+func TestPushMissingTable(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.CreateTables = false
+	exp := startExporter(t, cfg, cfg.Logs, logsSignal)
+
+	ld := plog.NewLogs()
+	ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+	assert.ErrorContains(t, exp.pushLogs(context.Background(), ld), "no such table: otel_logs")
+}
+
+func TestStatements(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Driver = "postgres"
+	cfg.DataSource = "host=localhost"
+	table := TableConfig{
+		Table: "otel.events",
+		Columns: []ColumnConfig{
+			{Name: "ts", Field: "timestamp"},
+			{Name: "message", Field: "body", Type: "VARCHAR(1024)"},
+			{Name: "service", Field: "resource.service.name"},
+		},
+	}
+
+	exp, err := newExporter(cfg, table, logsSignal)
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO otel.events (ts, message, service) VALUES ($1, $2, $3)", exp.insertSQL(1))
+	assert.Equal(t, "INSERT INTO otel.events (ts, message, service) VALUES ($1, $2, $3), ($4, $5, $6)", exp.insertSQL(2))
+	assert.Equal(t, maxInsertRows, exp.batchSize)
+	assert.Equal(t, "CREATE TABLE IF NOT EXISTS otel.events (ts TIMESTAMP WITH TIME ZONE, message VARCHAR(1024), service TEXT)", exp.createTableSQL())
+
+	cfg.Driver = "sqlserver"
+	exp, err = newExporter(cfg, table, logsSignal)
+	require.NoError(t, err)
+	assert.Equal(t, "INSERT INTO otel.events (ts, message, service) VALUES (@p1, @p2, @p3)", exp.insertSQL(1))
+	assert.Equal(t, 2099/3, exp.batchSize)
+
+	cfg.Driver = "oracle"
+	exp, err = newExporter(cfg, table, logsSignal)
+	require.NoError(t, err)
+	assert.Equal(t, 1, exp.batchSize, "oracle doesn't support inserting several rows with a VALUES clause")
+}
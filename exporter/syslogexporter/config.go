@@ -0,0 +1,136 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+const (
+	// RFC3164 renders the records as BSD syslog messages.
+	RFC3164 = "rfc3164"
+	// RFC5424 renders the records as IETF syslog messages.
+	RFC5424 = "rfc5424"
+	// GELF renders the records as Graylog Extended Log Format messages.
+	GELF = "gelf"
+
+	// NULTrailer terminates the messages with a NUL byte.
+	NULTrailer = "NUL"
+	// LFTrailer terminates the messages with a line feed.
+	LFTrailer = "LF"
+
+	networkTCP = "tcp"
+	networkUDP = "udp"
+)
+
+// Config defines configuration for the syslog exporter.
+type Config struct {
+	exporterhelper.TimeoutSettings `mapstructure:",squash"` // squash ensures fields are correctly decoded in embedded struct.
+	exporterhelper.QueueSettings   `mapstructure:"sending_queue"`
+	exporterhelper.RetrySettings   `mapstructure:"retry_on_failure"`
+
+	// Endpoint is the host and port of the syslog server.
+	Endpoint string `mapstructure:"endpoint"`
+
+	// Network is the transport used to reach the syslog server, either "tcp" or "udp".
+	Network string `mapstructure:"network"`
+
+	// TLSSetting enables TLS on the TCP connection when set.
+	TLSSetting *configtls.TLSClientSetting `mapstructure:"tls,omitempty"`
+
+	// Protocol is the format of the messages: "rfc5424", "rfc3164" or "gelf".
+	Protocol string `mapstructure:"protocol"`
+
+	// Location is the time zone used to render the RFC3164 timestamps, which carry no offset.
+	Location string `mapstructure:"location"`
+
+	// EnableOctetCounting prefixes the messages sent over TCP with their length (RFC6587).
+	EnableOctetCounting bool `mapstructure:"enable_octet_counting"`
+
+	// NonTransparentFramingTrailer is the trailer terminating the messages sent over TCP
+	// when octet counting is disabled, either "LF" or "NUL". It defaults to "LF" for
+	// syslog messages and to "NUL" for GELF messages.
+	NonTransparentFramingTrailer *string `mapstructure:"non_transparent_framing_trailer,omitempty"`
+}
+
+// Validate checks if the exporter configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.Endpoint == "" {
+		return errors.New("\"endpoint\" must be specified")
+	}
+	if _, _, err := net.SplitHostPort(cfg.Endpoint); err != nil {
+		return fmt.Errorf("invalid \"endpoint\": %w", err)
+	}
+
+	switch cfg.Network {
+	case networkTCP:
+	case networkUDP:
+		if cfg.TLSSetting != nil {
+			return errors.New("tls is not compatible with the udp network")
+		}
+		// every datagram holds a single message, there is nothing to frame
+		if cfg.EnableOctetCounting || cfg.NonTransparentFramingTrailer != nil {
+			return errors.New("enable_octet_counting and non_transparent_framing_trailer are not compatible with the udp network")
+		}
+	default:
+		return fmt.Errorf("invalid network '%s', must be either 'tcp' or 'udp'", cfg.Network)
+	}
+
+	switch cfg.Protocol {
+	case RFC5424, RFC3164:
+	case GELF:
+		if cfg.EnableOctetCounting {
+			return errors.New("enable_octet_counting is not compatible with the gelf protocol")
+		}
+	default:
+		return fmt.Errorf("invalid protocol '%s', must be one of 'rfc5424', 'rfc3164' or 'gelf'", cfg.Protocol)
+	}
+
+	if cfg.NonTransparentFramingTrailer != nil {
+		if cfg.EnableOctetCounting {
+			return errors.New("only one of enable_octet_counting and non_transparent_framing_trailer can be set")
+		}
+		if *cfg.NonTransparentFramingTrailer != NULTrailer && *cfg.NonTransparentFramingTrailer != LFTrailer {
+			return fmt.Errorf("invalid non_transparent_framing_trailer '%s', must be either 'LF' or 'NUL'", *cfg.NonTransparentFramingTrailer)
+		}
+	}
+
+	if _, err := time.LoadLocation(cfg.Location); err != nil {
+		return fmt.Errorf("failed to load location %s: %w", cfg.Location, err)
+	}
+
+	return nil
+}
+
+// trailer returns the byte terminating the messages sent over TCP without octet counting.
+func (cfg *Config) trailer() byte {
+	trailer := LFTrailer
+	if cfg.Protocol == GELF {
+		trailer = NULTrailer
+	}
+	if cfg.NonTransparentFramingTrailer != nil {
+		trailer = *cfg.NonTransparentFramingTrailer
+	}
+	if trailer == NULTrailer {
+		return 0
+	}
+	return '\n'
+}
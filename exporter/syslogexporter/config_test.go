@@ -0,0 +1,212 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/config/configtls"
+	"go.opentelemetry.io/collector/confmap/confmaptest"
+	"go.opentelemetry.io/collector/exporter/exporterhelper"
+)
+
+func TestLoadConfig(t *testing.T) {
+	t.Parallel()
+
+	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config.yaml"))
+	require.NoError(t, err)
+
+	tests := []struct {
+		id           component.ID
+		expected     component.Config
+		errorMessage string
+	}{
+		{
+			id: component.NewIDWithName(typeStr, ""),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = "syslog.example.com:514"
+				return cfg
+			}(),
+		},
+		{
+			id: component.NewIDWithName(typeStr, "allsettings"),
+			expected: &Config{
+				TimeoutSettings: exporterhelper.TimeoutSettings{
+					Timeout: 10 * time.Second,
+				},
+				QueueSettings: exporterhelper.QueueSettings{
+					Enabled:      true,
+					NumConsumers: 2,
+					QueueSize:    10,
+				},
+				RetrySettings: exporterhelper.RetrySettings{
+					Enabled:         true,
+					InitialInterval: 10 * time.Second,
+					MaxInterval:     1 * time.Minute,
+					MaxElapsedTime:  10 * time.Minute,
+				},
+				Endpoint: "syslog.example.com:6514",
+				Network:  "tcp",
+				TLSSetting: &configtls.TLSClientSetting{
+					TLSSetting: configtls.TLSSetting{
+						CAFile: "/var/lib/mycert.pem",
+					},
+					ServerName: "syslog.example.com",
+				},
+				Protocol:            RFC3164,
+				Location:            "Europe/Berlin",
+				EnableOctetCounting: true,
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "gelf"),
+			expected: func() component.Config {
+				cfg := createDefaultConfig().(*Config)
+				cfg.Endpoint = "graylog.example.com:12201"
+				cfg.Network = "udp"
+				cfg.Protocol = GELF
+				return cfg
+			}(),
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "invalid_network"),
+			errorMessage: "invalid network 'sctp', must be either 'tcp' or 'udp'",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "invalid_protocol"),
+			errorMessage: "invalid protocol 'rfc1234', must be one of 'rfc5424', 'rfc3164' or 'gelf'",
+		},
+		{
+			id:           component.NewIDWithName(typeStr, "invalid_trailer"),
+			errorMessage: "invalid non_transparent_framing_trailer 'CRLF', must be either 'LF' or 'NUL'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.id.String(), func(t *testing.T) {
+			factory := NewFactory()
+			cfg := factory.CreateDefaultConfig()
+
+			sub, err := cm.Sub(tt.id.String())
+			require.NoError(t, err)
+			require.NoError(t, component.UnmarshalConfig(sub, cfg))
+
+			if tt.expected == nil {
+				assert.EqualError(t, component.ValidateConfig(cfg), tt.errorMessage)
+				return
+			}
+
+			assert.NoError(t, component.ValidateConfig(cfg))
+			assert.Equal(t, tt.expected, cfg)
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	lf := LFTrailer
+
+	tests := []struct {
+		name    string
+		modify  func(cfg *Config)
+		wantErr string
+	}{
+		{
+			name:    "missing_endpoint",
+			modify:  func(cfg *Config) { cfg.Endpoint = "" },
+			wantErr: "\"endpoint\" must be specified",
+		},
+		{
+			name:    "endpoint_without_port",
+			modify:  func(cfg *Config) { cfg.Endpoint = "localhost" },
+			wantErr: "invalid \"endpoint\": address localhost: missing port in address",
+		},
+		{
+			name: "udp_with_tls",
+			modify: func(cfg *Config) {
+				cfg.Network = networkUDP
+				cfg.TLSSetting = &configtls.TLSClientSetting{}
+			},
+			wantErr: "tls is not compatible with the udp network",
+		},
+		{
+			name: "udp_with_octet_counting",
+			modify: func(cfg *Config) {
+				cfg.Network = networkUDP
+				cfg.EnableOctetCounting = true
+			},
+			wantErr: "enable_octet_counting and non_transparent_framing_trailer are not compatible with the udp network",
+		},
+		{
+			name: "gelf_with_octet_counting",
+			modify: func(cfg *Config) {
+				cfg.Protocol = GELF
+				cfg.EnableOctetCounting = true
+			},
+			wantErr: "enable_octet_counting is not compatible with the gelf protocol",
+		},
+		{
+			name: "octet_counting_and_trailer",
+			modify: func(cfg *Config) {
+				cfg.EnableOctetCounting = true
+				cfg.NonTransparentFramingTrailer = &lf
+			},
+			wantErr: "only one of enable_octet_counting and non_transparent_framing_trailer can be set",
+		},
+		{
+			name:    "invalid_location",
+			modify:  func(cfg *Config) { cfg.Location = "Nowhere/Atlantis" },
+			wantErr: "failed to load location Nowhere/Atlantis: unknown time zone Nowhere/Atlantis",
+		},
+		{
+			name:   "valid_trailer",
+			modify: func(cfg *Config) { cfg.NonTransparentFramingTrailer = &lf },
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := createDefaultConfig().(*Config)
+			cfg.Endpoint = "localhost:514"
+			tt.modify(cfg)
+			if tt.wantErr != "" {
+				assert.EqualError(t, cfg.Validate(), tt.wantErr)
+			} else {
+				assert.NoError(t, cfg.Validate())
+			}
+		})
+	}
+}
+
+func TestTrailer(t *testing.T) {
+	nul := NULTrailer
+	lf := LFTrailer
+
+	cfg := &Config{Protocol: RFC5424}
+	assert.Equal(t, byte('\n'), cfg.trailer())
+
+	cfg.NonTransparentFramingTrailer = &nul
+	assert.Equal(t, byte(0), cfg.trailer())
+
+	cfg = &Config{Protocol: GELF}
+	assert.Equal(t, byte(0), cfg.trailer())
+
+	cfg.NonTransparentFramingTrailer = &lf
+	assert.Equal(t, byte('\n'), cfg.trailer())
+}
@@ -0,0 +1,188 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+type formatFunc func(m message, location *time.Location) []byte
+
+type syslogExporter struct {
+	format   formatFunc
+	location *time.Location
+	sender   *sender
+}
+
+func newExporter(cfg *Config) (*syslogExporter, error) {
+	location, err := time.LoadLocation(cfg.Location)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load location %s: %w", cfg.Location, err)
+	}
+
+	var tlsConfig *tls.Config
+	if cfg.TLSSetting != nil {
+		if tlsConfig, err = cfg.TLSSetting.LoadTLSConfig(); err != nil {
+			return nil, err
+		}
+	}
+
+	var format formatFunc
+	switch cfg.Protocol {
+	case RFC3164:
+		format = formatRFC3164
+	case GELF:
+		format = formatGELF
+	default:
+		format = formatRFC5424
+	}
+
+	return &syslogExporter{
+		format:   format,
+		location: location,
+		sender: &sender{
+			network:             cfg.Network,
+			endpoint:            cfg.Endpoint,
+			tlsConfig:           tlsConfig,
+			timeout:             cfg.Timeout,
+			enableOctetCounting: cfg.EnableOctetCounting,
+			trailer:             cfg.trailer(),
+		},
+	}, nil
+}
+
+func (e *syslogExporter) pushLogs(ctx context.Context, ld plog.Logs) error {
+	messages := make([][]byte, 0, ld.LogRecordCount())
+	for i := 0; i < ld.ResourceLogs().Len(); i++ {
+		rl := ld.ResourceLogs().At(i)
+		for j := 0; j < rl.ScopeLogs().Len(); j++ {
+			records := rl.ScopeLogs().At(j).LogRecords()
+			for k := 0; k < records.Len(); k++ {
+				messages = append(messages, e.format(newMessage(rl.Resource(), records.At(k)), e.location))
+			}
+		}
+	}
+	return e.sender.send(ctx, messages)
+}
+
+func (e *syslogExporter) shutdown(context.Context) error {
+	return e.sender.close()
+}
+
+// sender writes the messages to a single connection to the syslog server, which is
+// established lazily and re-established on the next send after a failure.
+type sender struct {
+	network             string
+	endpoint            string
+	tlsConfig           *tls.Config
+	timeout             time.Duration
+	enableOctetCounting bool
+	trailer             byte
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+func (s *sender) send(ctx context.Context, messages [][]byte) error {
+	if len(messages) == 0 {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		conn, err := s.dial(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to connect to %s: %w", s.endpoint, err)
+		}
+		s.conn = conn
+	}
+
+	if deadline, ok := ctx.Deadline(); ok {
+		_ = s.conn.SetWriteDeadline(deadline)
+	}
+
+	if err := s.write(messages); err != nil {
+		// drop the connection, it will be re-established on the next attempt
+		_ = s.conn.Close()
+		s.conn = nil
+		return fmt.Errorf("failed to send the messages to %s: %w", s.endpoint, err)
+	}
+	return nil
+}
+
+func (s *sender) write(messages [][]byte) error {
+	// each datagram holds a single message
+	if s.network == networkUDP {
+		for _, msg := range messages {
+			if _, err := s.conn.Write(msg); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var b bytes.Buffer
+	for _, msg := range messages {
+		// see https://www.rfc-editor.org/rfc/rfc6587#section-3.4
+		if s.enableOctetCounting {
+			b.WriteString(strconv.Itoa(len(msg)))
+			b.WriteByte(' ')
+			b.Write(msg)
+			continue
+		}
+		// the trailer would split the message into several frames on the receiver
+		for _, c := range msg {
+			if c == s.trailer {
+				c = ' '
+			}
+			b.WriteByte(c)
+		}
+		b.WriteByte(s.trailer)
+	}
+	_, err := s.conn.Write(b.Bytes())
+	return err
+}
+
+func (s *sender) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: s.timeout}
+	if s.tlsConfig != nil {
+		tlsDialer := &tls.Dialer{NetDialer: dialer, Config: s.tlsConfig}
+		return tlsDialer.DialContext(ctx, s.network, s.endpoint)
+	}
+	return dialer.DialContext(ctx, s.network, s.endpoint)
+}
+
+func (s *sender) close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.conn == nil {
+		return nil
+	}
+	err := s.conn.Close()
+	s.conn = nil
+	return err
+}
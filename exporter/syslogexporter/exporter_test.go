@@ -0,0 +1,246 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func newTestLogs(messages ...string) plog.Logs {
+	ld := plog.NewLogs()
+	rl := ld.ResourceLogs().AppendEmpty()
+	rl.Resource().Attributes().PutStr("host.name", "host")
+	rl.Resource().Attributes().PutStr("service.name", "app")
+	records := rl.ScopeLogs().AppendEmpty().LogRecords()
+	for _, msg := range messages {
+		lr := records.AppendEmpty()
+		lr.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+		lr.SetSeverityNumber(plog.SeverityNumberInfo)
+		lr.Body().SetStr(msg)
+	}
+	return ld
+}
+
+func newTestExporter(t *testing.T, modify func(cfg *Config)) *syslogExporter {
+	cfg := createDefaultConfig().(*Config)
+	modify(cfg)
+	require.NoError(t, cfg.Validate())
+
+	exp, err := newExporter(cfg)
+	require.NoError(t, err)
+	t.Cleanup(func() {
+		assert.NoError(t, exp.shutdown(context.Background()))
+	})
+	return exp
+}
+
+// readTCP accepts a single connection and returns the first n bytes sent on it.
+func readTCP(t *testing.T, ln net.Listener, n int) <-chan []byte {
+	received := make(chan []byte, 1)
+	go func() {
+		conn, err := ln.Accept()
+		if !assert.NoError(t, err) {
+			return
+		}
+		defer conn.Close()
+		buf := make([]byte, n)
+		_, err = io.ReadFull(bufio.NewReader(conn), buf)
+		assert.NoError(t, err)
+		received <- buf
+	}()
+	return received
+}
+
+func TestPushLogsTCPNonTransparentFraming(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = ln.Addr().String()
+	})
+
+	expected := "<14>1 2023-01-12T10:15:30.123456Z host app - - - first\n" +
+		"<14>1 2023-01-12T10:15:30.123456Z host app - - - second\n"
+	received := readTCP(t, ln, len(expected))
+
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("first", "second")))
+	select {
+	case got := <-received:
+		assert.Equal(t, expected, string(got))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no messages received")
+	}
+}
+
+func TestPushLogsTCPNonTransparentFramingReplacesTrailer(t *testing.T) {
+	tests := []struct {
+		name     string
+		trailer  string
+		message  string
+		expected string
+	}{
+		{
+			name:     "lf",
+			trailer:  LFTrailer,
+			message:  "multi\nline",
+			expected: "<14>1 2023-01-12T10:15:30.123456Z host app - - - multi line\n",
+		},
+		{
+			name:     "nul",
+			trailer:  NULTrailer,
+			message:  "nul\x00byte\nkept",
+			expected: "<14>1 2023-01-12T10:15:30.123456Z host app - - - nul byte\nkept\x00",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ln, err := net.Listen("tcp", "127.0.0.1:0")
+			require.NoError(t, err)
+			defer ln.Close()
+
+			exp := newTestExporter(t, func(cfg *Config) {
+				cfg.Endpoint = ln.Addr().String()
+				cfg.NonTransparentFramingTrailer = &tt.trailer
+			})
+
+			received := readTCP(t, ln, len(tt.expected))
+			require.NoError(t, exp.pushLogs(context.Background(), newTestLogs(tt.message)))
+			select {
+			case got := <-received:
+				assert.Equal(t, tt.expected, string(got))
+			case <-time.After(5 * time.Second):
+				t.Fatal("no messages received")
+			}
+		})
+	}
+}
+
+func TestPushLogsTCPOctetCounting(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = ln.Addr().String()
+		cfg.EnableOctetCounting = true
+	})
+
+	expected := "54 <14>1 2023-01-12T10:15:30.123456Z host app - - - first" +
+		"55 <14>1 2023-01-12T10:15:30.123456Z host app - - - second"
+	received := readTCP(t, ln, len(expected))
+
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("first", "second")))
+	select {
+	case got := <-received:
+		assert.Equal(t, expected, string(got))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no messages received")
+	}
+}
+
+func TestPushLogsTCPGELF(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer ln.Close()
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = ln.Addr().String()
+		cfg.Protocol = GELF
+	})
+
+	expected := `{"_appname":"app","_facility":1,"host":"host","level":6,"short_message":"first","timestamp":1673518530.123,"version":"1.1"}` + "\x00"
+	received := readTCP(t, ln, len(expected))
+
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("first")))
+	select {
+	case got := <-received:
+		assert.Equal(t, expected, string(got))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no messages received")
+	}
+}
+
+func TestPushLogsUDP(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer conn.Close()
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = conn.LocalAddr().String()
+		cfg.Network = networkUDP
+		cfg.Protocol = RFC3164
+	})
+
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("first", "second")))
+
+	require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+	buf := make([]byte, 1024)
+	for _, expected := range []string{
+		"<14>Jan 12 10:15:30 host app: first",
+		"<14>Jan 12 10:15:30 host app: second",
+	} {
+		n, _, err := conn.ReadFrom(buf)
+		require.NoError(t, err)
+		assert.Equal(t, expected, string(buf[:n]))
+	}
+}
+
+func TestPushLogsReconnects(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	endpoint := ln.Addr().String()
+	require.NoError(t, ln.Close())
+
+	exp := newTestExporter(t, func(cfg *Config) {
+		cfg.Endpoint = endpoint
+	})
+
+	// the server is down, the batch is returned to the exporter helper to be retried
+	assert.Error(t, exp.pushLogs(context.Background(), newTestLogs("first")))
+
+	ln, err = net.Listen("tcp", endpoint)
+	require.NoError(t, err)
+	defer ln.Close()
+
+	expected := "<14>1 2023-01-12T10:15:30.123456Z host app - - - first\n"
+	received := readTCP(t, ln, len(expected))
+
+	require.NoError(t, exp.pushLogs(context.Background(), newTestLogs("first")))
+	select {
+	case got := <-received:
+		assert.Equal(t, expected, string(got))
+	case <-time.After(5 * time.Second):
+		t.Fatal("no messages received")
+	}
+}
+
+func TestPushLogsEmpty(t *testing.T) {
+	exp := newTestExporter(t, func(cfg *Config) {
+		// nothing listens there, no connection must be attempted
+		cfg.Endpoint = "127.0.0.1:1"
+	})
+	assert.NoError(t, exp.pushLogs(context.Background(), plog.NewLogs()))
+}
@@ -0,0 +1,388 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter // import "github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+)
+
+// The attributes read from the log records, as set by the syslog parser.
+const (
+	priorityAttribute       = "priority"
+	facilityAttribute       = "facility"
+	hostnameAttribute       = "hostname"
+	appnameAttribute        = "appname"
+	procIDAttribute         = "proc_id"
+	msgIDAttribute          = "msg_id"
+	messageAttribute        = "message"
+	structuredDataAttribute = "structured_data"
+	versionAttribute        = "version"
+	severityAttribute       = "severity"
+)
+
+const (
+	nilValue = "-"
+
+	// the user-level facility, used when the records don't carry one
+	defaultFacility = 1
+	// the informational severity, used when the records don't carry one
+	defaultSeverity = 6
+
+	rfc5424TimeFormat = "2006-01-02T15:04:05.999999Z07:00"
+	rfc3164TimeFormat = time.Stamp
+
+	gelfVersion = "1.1"
+)
+
+// severityText holds the names of the syslog severities, as set in the severity text by the syslog parser.
+var severityText = [...]string{
+	0: "emerg",
+	1: "alert",
+	2: "crit",
+	3: "err",
+	4: "warning",
+	5: "notice",
+	6: "info",
+	7: "debug",
+}
+
+// message holds the syslog fields of a log record.
+type message struct {
+	timestamp      time.Time
+	facility       int
+	severity       int
+	hostname       string
+	appname        string
+	procID         string
+	msgID          string
+	structuredData map[string]map[string]string
+	text           string
+	attributes     pcommon.Map
+}
+
+func newMessage(resource pcommon.Resource, lr plog.LogRecord) message {
+	attrs := lr.Attributes()
+	priority, hasPriority := intAttribute(attrs, priorityAttribute)
+
+	m := message{
+		timestamp:      lr.Timestamp().AsTime(),
+		facility:       defaultFacility,
+		severity:       syslogSeverity(lr.SeverityNumber(), lr.SeverityText()),
+		hostname:       stringAttribute(attrs, hostnameAttribute),
+		appname:        stringAttribute(attrs, appnameAttribute),
+		procID:         stringAttribute(attrs, procIDAttribute),
+		msgID:          stringAttribute(attrs, msgIDAttribute),
+		structuredData: structuredData(attrs),
+		attributes:     attrs,
+	}
+
+	if lr.Timestamp() == 0 {
+		m.timestamp = lr.ObservedTimestamp().AsTime()
+		if lr.ObservedTimestamp() == 0 {
+			m.timestamp = time.Now()
+		}
+	}
+
+	if facility, ok := intAttribute(attrs, facilityAttribute); ok && facility >= 0 && facility <= 23 {
+		m.facility = facility
+	} else if hasPriority && priority >= 0 && priority <= 191 {
+		m.facility = priority / 8
+	}
+
+	if m.severity < 0 {
+		m.severity = defaultSeverity
+		if hasPriority && priority >= 0 && priority <= 191 {
+			m.severity = priority % 8
+		}
+	}
+
+	if m.hostname == "" {
+		m.hostname = stringAttribute(resource.Attributes(), conventions.AttributeHostName)
+	}
+	if m.appname == "" {
+		m.appname = stringAttribute(resource.Attributes(), conventions.AttributeServiceName)
+	}
+
+	// the syslog parser keeps the raw message in the body and the parsed one in the attributes
+	if text, ok := attrs.Get(messageAttribute); ok {
+		m.text = text.AsString()
+	} else {
+		m.text = lr.Body().AsString()
+	}
+
+	return m
+}
+
+func (m message) priority() int {
+	return m.facility*8 + m.severity
+}
+
+// syslogSeverity reverts the mapping of the syslog parser from the syslog severities to the
+// log severities. It returns -1 when the record carries no severity.
+func syslogSeverity(number plog.SeverityNumber, text string) int {
+	switch {
+	case number >= plog.SeverityNumberFatal:
+		return 0
+	case number >= plog.SeverityNumberError3:
+		return 1
+	case number == plog.SeverityNumberError2:
+		return 2
+	case number == plog.SeverityNumberError:
+		return 3
+	case number >= plog.SeverityNumberWarn:
+		return 4
+	case number >= plog.SeverityNumberInfo2:
+		return 5
+	case number == plog.SeverityNumberInfo:
+		return 6
+	case number > plog.SeverityNumberUnspecified:
+		return 7
+	}
+
+	for severity, name := range severityText {
+		if strings.EqualFold(text, name) {
+			return severity
+		}
+	}
+	return -1
+}
+
+// formatRFC5424 renders the message as described in https://www.rfc-editor.org/rfc/rfc5424.
+func formatRFC5424(m message, _ *time.Location) []byte {
+	var b bytes.Buffer
+	b.WriteByte('<')
+	b.WriteString(strconv.Itoa(m.priority()))
+	b.WriteString(">1 ")
+	b.WriteString(m.timestamp.UTC().Format(rfc5424TimeFormat))
+	b.WriteByte(' ')
+	b.WriteString(headerField(m.hostname, 255))
+	b.WriteByte(' ')
+	b.WriteString(headerField(m.appname, 48))
+	b.WriteByte(' ')
+	b.WriteString(headerField(m.procID, 128))
+	b.WriteByte(' ')
+	b.WriteString(headerField(m.msgID, 32))
+	b.WriteByte(' ')
+	writeStructuredData(&b, m.structuredData)
+	if m.text != "" {
+		b.WriteByte(' ')
+		b.WriteString(m.text)
+	}
+	return b.Bytes()
+}
+
+// formatRFC3164 renders the message as described in https://www.rfc-editor.org/rfc/rfc3164.
+func formatRFC3164(m message, location *time.Location) []byte {
+	var b bytes.Buffer
+	b.WriteByte('<')
+	b.WriteString(strconv.Itoa(m.priority()))
+	b.WriteByte('>')
+	b.WriteString(m.timestamp.In(location).Format(rfc3164TimeFormat))
+	b.WriteByte(' ')
+	b.WriteString(headerField(m.hostname, 255))
+	b.WriteByte(' ')
+	if m.appname != "" {
+		b.WriteString(sanitize(m.appname, 48, nil))
+		if m.procID != "" {
+			b.WriteByte('[')
+			b.WriteString(sanitize(m.procID, 128, nil))
+			b.WriteByte(']')
+		}
+		b.WriteString(": ")
+	}
+	b.WriteString(m.text)
+	return b.Bytes()
+}
+
+// formatGELF renders the message as described in https://go2docs.graylog.org/current/getting_in_log_data/gelf.html.
+// The syslog fields and the other attributes of the record are sent as additional fields.
+func formatGELF(m message, _ *time.Location) []byte {
+	fields := map[string]interface{}{
+		"version":       gelfVersion,
+		"host":          headerField(m.hostname, 255),
+		"short_message": m.text,
+		"timestamp":     float64(m.timestamp.UnixMilli()) / 1e3,
+		"level":         m.severity,
+		"_facility":     m.facility,
+	}
+	if m.appname != "" {
+		fields["_appname"] = m.appname
+	}
+	if m.procID != "" {
+		fields["_proc_id"] = m.procID
+	}
+	if m.msgID != "" {
+		fields["_msg_id"] = m.msgID
+	}
+
+	m.attributes.Range(func(k string, v pcommon.Value) bool {
+		switch k {
+		case priorityAttribute, facilityAttribute, hostnameAttribute, appnameAttribute,
+			procIDAttribute, msgIDAttribute, messageAttribute, versionAttribute, severityAttribute:
+			return true
+		}
+		name := "_" + gelfFieldName(k)
+		// "_id" is reserved by the GELF specification
+		if name == "_id" {
+			return true
+		}
+		if _, ok := fields[name]; ok {
+			return true
+		}
+		switch v.Type() {
+		case pcommon.ValueTypeInt:
+			fields[name] = v.Int()
+		case pcommon.ValueTypeDouble:
+			fields[name] = v.Double()
+		default:
+			fields[name] = v.AsString()
+		}
+		return true
+	})
+
+	// the fields hold strings and numbers only, marshaling can't fail
+	raw, _ := json.Marshal(fields)
+	return raw
+}
+
+func writeStructuredData(b *bytes.Buffer, data map[string]map[string]string) {
+	if len(data) == 0 {
+		b.WriteString(nilValue)
+		return
+	}
+
+	ids := make([]string, 0, len(data))
+	for id := range data {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	for _, id := range ids {
+		b.WriteByte('[')
+		b.WriteString(sdName(id))
+
+		params := make([]string, 0, len(data[id]))
+		for name := range data[id] {
+			params = append(params, name)
+		}
+		sort.Strings(params)
+
+		for _, name := range params {
+			b.WriteByte(' ')
+			b.WriteString(sdName(name))
+			b.WriteString(`="`)
+			b.WriteString(sdValueEscaper.Replace(data[id][name]))
+			b.WriteByte('"')
+		}
+		b.WriteByte(']')
+	}
+}
+
+var sdValueEscaper = strings.NewReplacer(`"`, `\"`, `\`, `\\`, `]`, `\]`)
+
+// headerField returns the value of an RFC5424 header field, made of printable ASCII characters.
+func headerField(value string, maxLen int) string {
+	if value == "" {
+		return nilValue
+	}
+	return sanitize(value, maxLen, nil)
+}
+
+// sdName returns the name of an RFC5424 structured data element or parameter.
+func sdName(name string) string {
+	return sanitize(name, 32, func(c byte) bool {
+		return c == '=' || c == ']' || c == '"'
+	})
+}
+
+// sanitize replaces the characters that aren't printable ASCII, or are excluded, by underscores,
+// and truncates the value to maxLen characters.
+func sanitize(value string, maxLen int, excluded func(byte) bool) string {
+	if len(value) > maxLen {
+		value = value[:maxLen]
+	}
+	b := []byte(value)
+	for i, c := range b {
+		if c < 33 || c > 126 || (excluded != nil && excluded(c)) {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+// gelfFieldName replaces the characters not allowed in the names of the GELF additional fields.
+func gelfFieldName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '_' || c == '.' || c == '-') {
+			b[i] = '_'
+		}
+	}
+	return string(b)
+}
+
+func stringAttribute(attrs pcommon.Map, key string) string {
+	if v, ok := attrs.Get(key); ok {
+		return v.AsString()
+	}
+	return ""
+}
+
+func intAttribute(attrs pcommon.Map, key string) (int, bool) {
+	v, ok := attrs.Get(key)
+	if !ok {
+		return 0, false
+	}
+	switch v.Type() {
+	case pcommon.ValueTypeInt:
+		return int(v.Int()), true
+	case pcommon.ValueTypeStr:
+		i, err := strconv.Atoi(v.Str())
+		return i, err == nil
+	default:
+		return 0, false
+	}
+}
+
+// structuredData reads the structured data elements, keyed by their ID, from the attributes.
+func structuredData(attrs pcommon.Map) map[string]map[string]string {
+	v, ok := attrs.Get(structuredDataAttribute)
+	if !ok || v.Type() != pcommon.ValueTypeMap {
+		return nil
+	}
+
+	data := make(map[string]map[string]string, v.Map().Len())
+	v.Map().Range(func(id string, element pcommon.Value) bool {
+		params := map[string]string{}
+		if element.Type() == pcommon.ValueTypeMap {
+			element.Map().Range(func(name string, param pcommon.Value) bool {
+				params[name] = param.AsString()
+				return true
+			})
+		}
+		data[id] = params
+		return true
+	})
+	return data
+}
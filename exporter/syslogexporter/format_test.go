@@ -0,0 +1,206 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package syslogexporter
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/influxdata/go-syslog/v3/rfc3164"
+	"github.com/influxdata/go-syslog/v3/rfc5424"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+var testTimestamp = time.Date(2023, 1, 12, 10, 15, 30, 123456000, time.UTC)
+
+// newParsedRecord returns a log record as emitted by the syslog parser.
+func newParsedRecord() plog.LogRecord {
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+	lr.SetSeverityNumber(plog.SeverityNumberWarn)
+	lr.SetSeverityText("warning")
+	lr.Body().SetStr("raw message")
+	lr.Attributes().PutInt("priority", 36)
+	lr.Attributes().PutInt("facility", 4)
+	lr.Attributes().PutStr("hostname", "host.example.com")
+	lr.Attributes().PutStr("appname", "sshd")
+	lr.Attributes().PutStr("proc_id", "4321")
+	lr.Attributes().PutStr("msg_id", "ID47")
+	lr.Attributes().PutStr("message", "Failed password for root")
+	lr.Attributes().PutInt("version", 1)
+	sd := lr.Attributes().PutEmptyMap("structured_data")
+	origin := sd.PutEmptyMap("origin@32473")
+	origin.PutStr("ip", "192.0.2.1")
+	origin.PutStr("note", `a "quoted" \ value]`)
+	sd.PutEmptyMap("meta")
+	return lr
+}
+
+func TestFormatRFC5424(t *testing.T) {
+	got := formatRFC5424(newMessage(pcommon.NewResource(), newParsedRecord()), time.UTC)
+	assert.Equal(t,
+		`<36>1 2023-01-12T10:15:30.123456Z host.example.com sshd 4321 ID47 [meta][origin@32473 ip="192.0.2.1" note="a \"quoted\" \\ value\]"] Failed password for root`,
+		string(got))
+}
+
+func TestFormatRFC5424NilValues(t *testing.T) {
+	lr := plog.NewLogRecord()
+	lr.SetTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+
+	got := formatRFC5424(newMessage(pcommon.NewResource(), lr), time.UTC)
+	assert.Equal(t, "<14>1 2023-01-12T10:15:30.123456Z - - - - -", string(got))
+}
+
+func TestFormatRFC3164(t *testing.T) {
+	location, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	got := formatRFC3164(newMessage(pcommon.NewResource(), newParsedRecord()), location)
+	assert.Equal(t, "<36>Jan 12 11:15:30 host.example.com sshd[4321]: Failed password for root", string(got))
+}
+
+func TestFormatGELF(t *testing.T) {
+	lr := newParsedRecord()
+	lr.Attributes().PutStr("user name", "root")
+	lr.Attributes().PutInt("attempts", 3)
+	lr.Attributes().PutStr("id", "reserved")
+	// sent as the level
+	lr.Attributes().PutStr("severity", "warning")
+
+	got := formatGELF(newMessage(pcommon.NewResource(), lr), time.UTC)
+
+	var fields map[string]interface{}
+	require.NoError(t, json.Unmarshal(got, &fields))
+	assert.Equal(t, map[string]interface{}{
+		"version":          "1.1",
+		"host":             "host.example.com",
+		"short_message":    "Failed password for root",
+		"timestamp":        1673518530.123,
+		"level":            float64(4),
+		"_facility":        float64(4),
+		"_appname":         "sshd",
+		"_proc_id":         "4321",
+		"_msg_id":          "ID47",
+		"_structured_data": `{"meta":{},"origin@32473":{"ip":"192.0.2.1","note":"a \"quoted\" \\ value]"}}`,
+		"_user_name":       "root",
+		"_attempts":        float64(3),
+	}, fields)
+}
+
+func TestNewMessageFallbacks(t *testing.T) {
+	resource := pcommon.NewResource()
+	resource.Attributes().PutStr("host.name", "resource-host")
+	resource.Attributes().PutStr("service.name", "checkout")
+
+	lr := plog.NewLogRecord()
+	lr.SetObservedTimestamp(pcommon.NewTimestampFromTime(testTimestamp))
+	lr.SetSeverityText("CRIT")
+	lr.Body().SetStr("body message")
+	lr.Attributes().PutInt("priority", 165)
+
+	m := newMessage(resource, lr)
+	assert.Equal(t, testTimestamp, m.timestamp)
+	assert.Equal(t, 20, m.facility)
+	assert.Equal(t, 2, m.severity)
+	assert.Equal(t, "resource-host", m.hostname)
+	assert.Equal(t, "checkout", m.appname)
+	assert.Equal(t, "body message", m.text)
+
+	lr.SetSeverityText("")
+	assert.Equal(t, 5, newMessage(resource, lr).severity)
+}
+
+func TestSyslogSeverity(t *testing.T) {
+	tests := []struct {
+		number   plog.SeverityNumber
+		text     string
+		expected int
+	}{
+		{number: plog.SeverityNumberFatal4, expected: 0},
+		{number: plog.SeverityNumberFatal, expected: 0},
+		{number: plog.SeverityNumberError4, expected: 1},
+		{number: plog.SeverityNumberError3, expected: 1},
+		{number: plog.SeverityNumberError2, expected: 2},
+		{number: plog.SeverityNumberError, expected: 3},
+		{number: plog.SeverityNumberWarn3, expected: 4},
+		{number: plog.SeverityNumberWarn, expected: 4},
+		{number: plog.SeverityNumberInfo4, expected: 5},
+		{number: plog.SeverityNumberInfo2, expected: 5},
+		{number: plog.SeverityNumberInfo, expected: 6},
+		{number: plog.SeverityNumberDebug, expected: 7},
+		{number: plog.SeverityNumberTrace, expected: 7},
+		{text: "alert", expected: 1},
+		{text: "Notice", expected: 5},
+		{text: "verbose", expected: -1},
+		{expected: -1},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.expected, syslogSeverity(tt.number, tt.text), "%s %q", tt.number, tt.text)
+	}
+}
+
+func TestSanitize(t *testing.T) {
+	assert.Equal(t, "my_app", headerField("my app", 48))
+	assert.Equal(t, "abc", headerField("abcdef", 3))
+	assert.Equal(t, "-", headerField("", 48))
+	assert.Equal(t, "a_b_c_d", sdName(`a=b]c"d`))
+	assert.Equal(t, "k8s.pod_name-1", gelfFieldName("k8s.pod/name-1"))
+}
+
+func TestRFC5424RoundTrip(t *testing.T) {
+	lr := newParsedRecord()
+	out := formatRFC5424(newMessage(pcommon.NewResource(), lr), time.UTC)
+
+	parsed, err := rfc5424.NewParser().Parse(out)
+	require.NoError(t, err)
+	msg := parsed.(*rfc5424.SyslogMessage)
+
+	assert.Equal(t, uint8(36), *msg.Priority)
+	assert.Equal(t, uint8(4), *msg.Facility)
+	assert.Equal(t, uint8(4), *msg.Severity)
+	assert.Equal(t, uint16(1), msg.Version)
+	assert.Equal(t, testTimestamp, *msg.Timestamp)
+	assert.Equal(t, "host.example.com", *msg.Hostname)
+	assert.Equal(t, "sshd", *msg.Appname)
+	assert.Equal(t, "4321", *msg.ProcID)
+	assert.Equal(t, "ID47", *msg.MsgID)
+	assert.Equal(t, "Failed password for root", *msg.Message)
+	assert.Equal(t, map[string]map[string]string{
+		"meta":         {},
+		"origin@32473": {"ip": "192.0.2.1", "note": `a "quoted" \ value]`},
+	}, *msg.StructuredData)
+}
+
+func TestRFC3164RoundTrip(t *testing.T) {
+	lr := newParsedRecord()
+	out := formatRFC3164(newMessage(pcommon.NewResource(), lr), time.UTC)
+
+	parsed, err := rfc3164.NewParser(rfc3164.WithYear(rfc3164.Year{YYYY: 2023})).Parse(out)
+	require.NoError(t, err)
+	msg := parsed.(*rfc3164.SyslogMessage)
+
+	assert.Equal(t, uint8(36), *msg.Priority)
+	assert.Equal(t, uint8(4), *msg.Facility)
+	assert.Equal(t, uint8(4), *msg.Severity)
+	assert.Equal(t, testTimestamp.Truncate(time.Second), *msg.Timestamp)
+	assert.Equal(t, "host.example.com", *msg.Hostname)
+	assert.Equal(t, "sshd", *msg.Appname)
+	assert.Equal(t, "4321", *msg.ProcID)
+	assert.Equal(t, "Failed password for root", *msg.Message)
+}
@@ -23,11 +23,14 @@ import (
 	"net/http"
 	"os"
 	"strings"
+	"sync"
 
+	"github.com/fsnotify/fsnotify"
 	"github.com/tg123/go-htpasswd"
 	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension/auth"
+	"go.uber.org/zap"
 	creds "google.golang.org/grpc/credentials"
 )
 
@@ -41,7 +44,14 @@ var (
 type basicAuth struct {
 	htpasswd   *HtpasswdSettings
 	clientAuth *ClientAuthSettings
-	matchFunc  func(username, password string) bool
+	logger     *zap.Logger
+
+	matchMu   sync.RWMutex
+	matchFunc func(username, password string) bool
+
+	watcher  *fsnotify.Watcher
+	shutdown chan struct{}
+	done     chan struct{}
 }
 
 func newClientAuthExtension(cfg *Config) (auth.Client, error) {
@@ -58,7 +68,7 @@ func newClientAuthExtension(cfg *Config) (auth.Client, error) {
 	), nil
 }
 
-func newServerAuthExtension(cfg *Config) (auth.Server, error) {
+func newServerAuthExtension(cfg *Config, logger *zap.Logger) (auth.Server, error) {
 
 	if cfg.Htpasswd == nil || (cfg.Htpasswd.File == "" && cfg.Htpasswd.Inline == "") {
 		return nil, errNoCredentialSource
@@ -66,14 +76,90 @@ func newServerAuthExtension(cfg *Config) (auth.Server, error) {
 
 	ba := basicAuth{
 		htpasswd: cfg.Htpasswd,
+		logger:   logger,
 	}
 	return auth.NewServer(
 		auth.WithServerStart(ba.serverStart),
 		auth.WithServerAuthenticate(ba.authenticate),
+		auth.WithServerShutdown(ba.serverShutdown),
 	), nil
 }
 
 func (ba *basicAuth) serverStart(ctx context.Context, host component.Host) error {
+	if err := ba.loadHtpasswd(); err != nil {
+		return err
+	}
+
+	if ba.htpasswd.File == "" {
+		return nil
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create htpasswd file watcher: %w", err)
+	}
+	if err = watcher.Add(ba.htpasswd.File); err != nil {
+		_ = watcher.Close()
+		return fmt.Errorf("watch htpasswd file: %w", err)
+	}
+
+	ba.watcher = watcher
+	ba.shutdown = make(chan struct{})
+	ba.done = make(chan struct{})
+	go ba.watch()
+
+	return nil
+}
+
+func (ba *basicAuth) serverShutdown(ctx context.Context) error {
+	if ba.watcher == nil {
+		return nil
+	}
+	close(ba.shutdown)
+	<-ba.done
+	err := ba.watcher.Close()
+	ba.watcher = nil
+	return err
+}
+
+// watch reloads the htpasswd file whenever it changes until the extension is shut down.
+func (ba *basicAuth) watch() {
+	defer close(ba.done)
+	for {
+		select {
+		case <-ba.shutdown:
+			return
+		case event, ok := <-ba.watcher.Events:
+			if !ok {
+				return
+			}
+			// Kubernetes mounts secrets through symlinks that are swapped on update,
+			// which removes the watched file. Re-add the watch on the new file.
+			if event.Op&(fsnotify.Remove|fsnotify.Rename|fsnotify.Chmod) != 0 {
+				if err := ba.watcher.Remove(event.Name); err != nil && !errors.Is(err, fsnotify.ErrNonExistentWatch) {
+					ba.logger.Debug("failed to remove htpasswd file watch", zap.Error(err))
+				}
+				if err := ba.watcher.Add(ba.htpasswd.File); err != nil {
+					ba.logger.Error("failed to watch htpasswd file", zap.String("file", ba.htpasswd.File), zap.Error(err))
+				}
+			}
+			if err := ba.loadHtpasswd(); err != nil {
+				ba.logger.Error("failed to reload htpasswd file, keeping previous credentials", zap.String("file", ba.htpasswd.File), zap.Error(err))
+				continue
+			}
+			ba.logger.Info("reloaded htpasswd file", zap.String("file", ba.htpasswd.File))
+		case err, ok := <-ba.watcher.Errors:
+			if !ok {
+				return
+			}
+			ba.logger.Error("htpasswd file watcher error", zap.Error(err))
+		}
+	}
+}
+
+// loadHtpasswd reads the htpasswd content and atomically swaps the credentials used
+// to authenticate requests.
+func (ba *basicAuth) loadHtpasswd() error {
 	var rs []io.Reader
 
 	if ba.htpasswd.File != "" {
@@ -97,7 +183,9 @@ func (ba *basicAuth) serverStart(ctx context.Context, host component.Host) error
 		return fmt.Errorf("read htpasswd content: %w", err)
 	}
 
+	ba.matchMu.Lock()
 	ba.matchFunc = htp.Match
+	ba.matchMu.Unlock()
 
 	return nil
 }
@@ -113,7 +201,11 @@ func (ba *basicAuth) authenticate(ctx context.Context, headers map[string][]stri
 		return ctx, err
 	}
 
-	if !ba.matchFunc(authData.username, authData.password) {
+	ba.matchMu.RLock()
+	matchFunc := ba.matchFunc
+	ba.matchMu.RUnlock()
+
+	if !matchFunc(authData.username, authData.password) {
 		return ctx, errInvalidCredentials
 	}
 
@@ -16,9 +16,12 @@ package bearertokenauthextension // import "github.com/open-telemetry/openteleme
 
 import (
 	"context"
+	"crypto/subtle"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 
 	"github.com/fsnotify/fsnotify"
@@ -30,14 +33,27 @@ import (
 
 var _ credentials.PerRPCCredentials = (*PerRPCAuth)(nil)
 
+var (
+	errNoAuth         = errors.New("no bearer token provided in the authorization header")
+	errInvalidScheme  = errors.New("invalid authorization scheme")
+	errInvalidToken   = errors.New("invalid bearer token")
+	errEmptyTokenFile = errors.New("token file is empty")
+)
+
 // PerRPCAuth is a gRPC credentials.PerRPCCredentials implementation that returns an 'authorization' header.
 type PerRPCAuth struct {
 	metadata map[string]string
+	// auth, when set, provides the current token instead of metadata.
+	auth *BearerTokenAuth
 }
 
 // GetRequestMetadata returns the request metadata to be used with the RPC.
+// The token of the extension is read on every call so that reloaded tokens are picked up.
 func (c *PerRPCAuth) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
-	return c.metadata, nil
+	if c.auth == nil {
+		return c.metadata, nil
+	}
+	return map[string]string{"authorization": c.auth.bearerToken()}, nil
 }
 
 // RequireTransportSecurity always returns true for this implementation. Passing bearer tokens in plain-text connections is a bad idea.
@@ -45,11 +61,16 @@ func (c *PerRPCAuth) RequireTransportSecurity() bool {
 	return true
 }
 
-// BearerTokenAuth is an implementation of auth.Client. It embeds a static authorization "bearer" token in every rpc call.
+// BearerTokenAuth is an implementation of auth.Client and auth.Server. As a client it embeds an
+// authorization "bearer" token in every rpc call, as a server it accepts any of the configured tokens.
 type BearerTokenAuth struct {
 	muTokenString sync.RWMutex
 	scheme        string
 	tokenString   string
+	// tokens holds every token read from the token file, or the static token.
+	tokens []string
+	// staticTokens holds the additional tokens accepted by the server authenticator.
+	staticTokens []string
 
 	shutdownCH chan struct{}
 
@@ -58,17 +79,23 @@ type BearerTokenAuth struct {
 }
 
 var _ auth.Client = (*BearerTokenAuth)(nil)
+var _ auth.Server = (*BearerTokenAuth)(nil)
 
 func newBearerTokenAuth(cfg *Config, logger *zap.Logger) *BearerTokenAuth {
 	if cfg.Filename != "" && cfg.BearerToken != "" {
 		logger.Warn("a filename is specified. Configured token is ignored!")
 	}
-	return &BearerTokenAuth{
-		scheme:      cfg.Scheme,
-		tokenString: cfg.BearerToken,
-		filename:    cfg.Filename,
-		logger:      logger,
+	b := &BearerTokenAuth{
+		scheme:       cfg.Scheme,
+		tokenString:  cfg.BearerToken,
+		staticTokens: cfg.Tokens,
+		filename:     cfg.Filename,
+		logger:       logger,
+	}
+	if cfg.BearerToken != "" {
+		b.tokens = []string{cfg.BearerToken}
 	}
+	return b
 }
 
 // Start of BearerTokenAuth does nothing and returns nil if no filename
@@ -133,18 +160,40 @@ func (b *BearerTokenAuth) startWatcher(ctx context.Context, watcher *fsnotify.Wa
 	}
 }
 
+// refreshToken reads the token file and swaps the tokens in memory. The client sends the
+// content of the file as is, the server accepts every non-empty line of the file. When the
+// file can't be read or has no token the previously loaded tokens are kept.
 func (b *BearerTokenAuth) refreshToken() {
 	b.logger.Info("refresh token", zap.String("filename", b.filename))
-	token, err := os.ReadFile(b.filename)
+	token, tokens, err := readTokenFile(b.filename)
 	if err != nil {
-		b.logger.Error(err.Error())
+		b.logger.Error("failed to reload token file, keeping previous token", zap.String("filename", b.filename), zap.Error(err))
 		return
 	}
 	b.muTokenString.Lock()
-	b.tokenString = string(token)
+	b.tokenString = token
+	b.tokens = tokens
 	b.muTokenString.Unlock()
 }
 
+// readTokenFile returns the content of the token file and the tokens of its non-empty lines.
+func readTokenFile(filename string) (string, []string, error) {
+	content, err := os.ReadFile(filename)
+	if err != nil {
+		return "", nil, err
+	}
+	var tokens []string
+	for _, line := range strings.Split(string(content), "\n") {
+		if token := strings.TrimSpace(line); token != "" {
+			tokens = append(tokens, token)
+		}
+	}
+	if len(tokens) == 0 {
+		return "", nil, errEmptyTokenFile
+	}
+	return string(content), tokens, nil
+}
+
 // Shutdown of BearerTokenAuth does nothing and returns nil
 func (b *BearerTokenAuth) Shutdown(ctx context.Context) error {
 	if b.filename == "" {
@@ -162,9 +211,7 @@ func (b *BearerTokenAuth) Shutdown(ctx context.Context) error {
 
 // PerRPCCredentials returns PerRPCAuth an implementation of credentials.PerRPCCredentials that
 func (b *BearerTokenAuth) PerRPCCredentials() (credentials.PerRPCCredentials, error) {
-	return &PerRPCAuth{
-		metadata: map[string]string{"authorization": b.bearerToken()},
-	}, nil
+	return &PerRPCAuth{auth: b}, nil
 }
 
 func (b *BearerTokenAuth) bearerToken() string {
@@ -174,18 +221,68 @@ func (b *BearerTokenAuth) bearerToken() string {
 	return token
 }
 
-// RoundTripper is not implemented by BearerTokenAuth
+// Authenticate checks that the authorization header carries one of the accepted bearer tokens.
+func (b *BearerTokenAuth) Authenticate(ctx context.Context, headers map[string][]string) (context.Context, error) {
+	header := getAuthHeader(headers)
+	if header == "" {
+		return ctx, errNoAuth
+	}
+
+	prefix := b.scheme + " "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return ctx, errInvalidScheme
+	}
+	token := []byte(header[len(prefix):])
+
+	b.muTokenString.RLock()
+	defer b.muTokenString.RUnlock()
+	for _, tokens := range [][]string{b.tokens, b.staticTokens} {
+		for _, accepted := range tokens {
+			if subtle.ConstantTimeCompare(token, []byte(accepted)) == 1 {
+				return ctx, nil
+			}
+		}
+	}
+	return ctx, errInvalidToken
+}
+
+func getAuthHeader(h map[string][]string) string {
+	const (
+		canonicalHeaderKey = "Authorization"
+		metadataKey        = "authorization"
+	)
+
+	authHeaders, ok := h[canonicalHeaderKey]
+	if !ok {
+		authHeaders, ok = h[metadataKey]
+	}
+	if !ok {
+		for k, v := range h {
+			if strings.EqualFold(k, metadataKey) {
+				authHeaders = v
+				break
+			}
+		}
+	}
+
+	if len(authHeaders) == 0 {
+		return ""
+	}
+	return authHeaders[0]
+}
+
+// RoundTripper returns a http.RoundTripper that adds the current bearer token to every request.
 func (b *BearerTokenAuth) RoundTripper(base http.RoundTripper) (http.RoundTripper, error) {
 	return &BearerAuthRoundTripper{
 		baseTransport: base,
-		bearerToken:   b.bearerToken(),
+		auth:          b,
 	}, nil
 }
 
 // BearerAuthRoundTripper intercepts and adds Bearer token Authorization headers to each http request.
 type BearerAuthRoundTripper struct {
 	baseTransport http.RoundTripper
-	bearerToken   string
+	auth          *BearerTokenAuth
 }
 
 // RoundTrip modifies the original request and adds Bearer token Authorization headers.
@@ -194,6 +291,6 @@ func (interceptor *BearerAuthRoundTripper) RoundTrip(req *http.Request) (*http.R
 	if req2.Header == nil {
 		req2.Header = make(http.Header)
 	}
-	req2.Header.Set("Authorization", interceptor.bearerToken)
+	req2.Header.Set("Authorization", interceptor.auth.bearerToken())
 	return interceptor.baseTransport.RoundTrip(req2)
 }
@@ -19,10 +19,12 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap/zaptest"
 )
@@ -159,3 +161,111 @@ func TestBearerStartWatchStop(t *testing.T) {
 	assert.Nil(t, bauth.Shutdown(context.Background()))
 	assert.Nil(t, bauth.shutdownCH)
 }
+
+func TestBearerServerAuthenticate(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	cfg.BearerToken = "current"
+	cfg.Tokens = []string{"previous", "next"}
+
+	bauth := newBearerTokenAuth(cfg, zaptest.NewLogger(t))
+	require.NoError(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, bauth.Shutdown(context.Background())) }()
+
+	tests := []struct {
+		name    string
+		headers map[string][]string
+		err     error
+	}{
+		{
+			name:    "configured token",
+			headers: map[string][]string{"Authorization": {"Bearer current"}},
+		},
+		{
+			name:    "additional token",
+			headers: map[string][]string{"authorization": {"Bearer previous"}},
+		},
+		{
+			name:    "case insensitive header and scheme",
+			headers: map[string][]string{"AUTHORIZATION": {"bearer next"}},
+		},
+		{
+			name:    "no header",
+			headers: map[string][]string{},
+			err:     errNoAuth,
+		},
+		{
+			name:    "invalid scheme",
+			headers: map[string][]string{"authorization": {"Basic current"}},
+			err:     errInvalidScheme,
+		},
+		{
+			name:    "invalid token",
+			headers: map[string][]string{"authorization": {"Bearer unknown"}},
+			err:     errInvalidToken,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := bauth.Authenticate(context.Background(), tt.headers)
+			assert.Equal(t, tt.err, err)
+		})
+	}
+}
+
+func TestBearerServerAuthenticateTokenFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "tokens")
+	require.NoError(t, os.WriteFile(filename, []byte("first\nsecond\n"), 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filename = filename
+
+	bauth := newBearerTokenAuth(cfg, zaptest.NewLogger(t))
+	require.NoError(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, bauth.Shutdown(context.Background())) }()
+
+	// the client sends the file as is, the server accepts every line
+	assert.Equal(t, "Bearer first\nsecond\n", bauth.bearerToken())
+	for _, token := range []string{"first", "second"} {
+		_, err := bauth.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer " + token}})
+		assert.NoError(t, err)
+	}
+
+	// rotate the tokens
+	require.NoError(t, os.WriteFile(filename, []byte("second\nthird\n"), 0600))
+	assert.Eventually(t, func() bool {
+		_, err := bauth.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer third"}})
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err := bauth.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer first"}})
+	assert.Equal(t, errInvalidToken, err)
+}
+
+func TestBearerKeepsTokenOnEmptyFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(filename, []byte("first"), 0600))
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Filename = filename
+
+	bauth := newBearerTokenAuth(cfg, zaptest.NewLogger(t))
+	require.NoError(t, bauth.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, bauth.Shutdown(context.Background())) }()
+	credential, err := bauth.PerRPCCredentials()
+	require.NoError(t, err)
+
+	// a file without token is rejected and the last token is kept
+	require.NoError(t, os.WriteFile(filename, []byte(" \n"), 0600))
+	time.Sleep(500 * time.Millisecond)
+	md, err := credential.GetRequestMetadata(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"authorization": "Bearer first"}, md)
+	_, err = bauth.Authenticate(context.Background(), map[string][]string{"authorization": {"Bearer first"}})
+	assert.NoError(t, err)
+
+	// the credentials pick up the reloaded token
+	require.NoError(t, os.WriteFile(filename, []byte("second"), 0600))
+	assert.Eventually(t, func() bool {
+		md, err = credential.GetRequestMetadata(context.Background())
+		return err == nil && md["authorization"] == "Bearer second"
+	}, 5*time.Second, 10*time.Millisecond)
+}
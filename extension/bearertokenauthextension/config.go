@@ -30,7 +30,12 @@ type Config struct {
 	BearerToken string `mapstructure:"token,omitempty"`
 
 	// Filename points to a file that contains the bearer token to use for every RPC.
+	// The file is watched for changes and the token is reloaded without a restart.
 	Filename string `mapstructure:"filename,omitempty"`
+
+	// Tokens specifies additional bearer tokens accepted when the extension is used
+	// as a server authenticator. Accepting several tokens allows rotating them without downtime.
+	Tokens []string `mapstructure:"tokens,omitempty"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -38,7 +43,7 @@ var errNoTokenProvided = errors.New("no bearer token provided")
 
 // Validate checks if the extension configuration is valid
 func (cfg *Config) Validate() error {
-	if cfg.BearerToken == "" && cfg.Filename == "" {
+	if cfg.BearerToken == "" && cfg.Filename == "" && len(cfg.Tokens) == 0 {
 		return errNoTokenProvided
 	}
 	return nil
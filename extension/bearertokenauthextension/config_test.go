@@ -50,6 +50,14 @@ func TestLoadConfig(t *testing.T) {
 				BearerToken: "my-token",
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "multipletokens"),
+			expected: &Config{
+				Scheme:      defaultScheme,
+				BearerToken: "current",
+				Tokens:      []string{"previous", "next"},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -0,0 +1,342 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegerremotesampling // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/strategystore"
+	"github.com/jaegertracing/jaeger/pkg/distributedlock"
+	"github.com/jaegertracing/jaeger/pkg/metrics"
+	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/adaptive"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	conventions "go.opentelemetry.io/collector/semconv/v1.6.1"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
+)
+
+const (
+	// the tags set by the Jaeger clients on the root spans, describing the sampler that sampled the trace
+	samplerTypeTagKey  = "sampler.type"
+	samplerParamTagKey = "sampler.param"
+
+	samplerTypeProbabilistic = "probabilistic"
+	samplerTypeLowerBound    = "lowerbound"
+	samplerTypeRateLimiting  = "ratelimiting"
+
+	defaultTargetSamplesPerSecond     = 1
+	defaultDeltaTolerance             = 0.3
+	defaultCalculationInterval        = time.Minute
+	defaultAggregationBuckets         = 10
+	defaultBucketsForCalculation      = 1
+	defaultDelay                      = 2 * time.Minute
+	defaultInitialSamplingProbability = 0.001
+	defaultMinSamplingProbability     = 1e-5
+	defaultMinSamplesPerSecond        = 1.0 / 60
+	leaderLeaseRefreshInterval        = 5 * time.Second
+	followerLeaseRefreshInterval      = time.Minute
+)
+
+var errAdaptiveNotConfigured = errors.New("the jaegerremotesampling extension is not configured with the 'adaptive' source")
+
+// TracesObserver is implemented by the jaegerremotesampling extension. The jaegeradaptivesampling
+// processor uses it to feed the traces flowing through the pipeline to the adaptive source.
+type TracesObserver interface {
+	// ObserveTraces records the throughput of the root spans in the given traces. It returns an
+	// error when the extension isn't configured with the adaptive source.
+	ObserveTraces(ctx context.Context, td ptrace.Traces) error
+}
+
+var _ TracesObserver = (*jrsExtension)(nil)
+
+// adaptiveSource calculates the sampling strategies from the observed throughput, using the
+// adaptive sampling implementation of the Jaeger collector.
+type adaptiveSource struct {
+	logger     *zap.Logger
+	client     storage.Client
+	store      *internal.SamplingStore
+	processor  *adaptive.Processor
+	aggregator strategystore.Aggregator
+
+	// the probabilities served to each service, cleared at every calculation interval
+	probabilitiesMu sync.RWMutex
+	probabilities   map[string]*serviceProbabilities
+
+	done chan struct{}
+	wg   sync.WaitGroup
+}
+
+// serviceProbabilities are the sampling probabilities of a strategy served to a service.
+type serviceProbabilities struct {
+	defaultProbability float64
+	operations         map[string]float64
+}
+
+func newAdaptiveSource(ctx context.Context, host component.Host, id component.ID, cfg *AdaptiveSettings, logger *zap.Logger) (*adaptiveSource, error) {
+	opts := cfg.options()
+
+	client, err := getStorageClient(ctx, host, cfg.StorageID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// keep enough throughput for the calculation to catch up after a restart
+	retention := time.Duration(opts.AggregationBuckets+1)*opts.CalculationInterval + opts.Delay
+	store := internal.NewSamplingStore(client, retention)
+	if err = store.Load(ctx); err != nil {
+		return nil, err
+	}
+
+	// the collector is the only participant, it always holds the leader lock
+	processor, err := adaptive.NewStrategyStore(opts, metrics.NullFactory, logger, localLock{}, store)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create the adaptive strategy store: %w", err)
+	}
+	if err = processor.Start(); err != nil {
+		return nil, fmt.Errorf("failed to start the adaptive strategy store: %w", err)
+	}
+
+	aggregator := adaptive.NewAggregator(metrics.NullFactory, opts.CalculationInterval, store)
+	aggregator.Start()
+
+	as := &adaptiveSource{
+		logger:        logger,
+		client:        client,
+		store:         store,
+		processor:     processor,
+		aggregator:    aggregator,
+		probabilities: map[string]*serviceProbabilities{},
+		done:          make(chan struct{}),
+	}
+	as.wg.Add(1)
+	go as.clearProbabilities(opts.CalculationInterval)
+	return as, nil
+}
+
+// clearProbabilities forgets the cached probabilities at every calculation interval, as the
+// processor may have calculated new strategies. The probabilities are served at most one
+// interval late, which is already the case for the clients polling the strategies.
+func (as *adaptiveSource) clearProbabilities(interval time.Duration) {
+	defer as.wg.Done()
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			as.probabilitiesMu.Lock()
+			as.probabilities = map[string]*serviceProbabilities{}
+			as.probabilitiesMu.Unlock()
+		case <-as.done:
+			return
+		}
+	}
+}
+
+func (as *adaptiveSource) observe(ctx context.Context, td ptrace.Traces) {
+	for i := 0; i < td.ResourceSpans().Len(); i++ {
+		rs := td.ResourceSpans().At(i)
+		service, ok := rs.Resource().Attributes().Get(conventions.AttributeServiceName)
+		if !ok || service.Str() == "" {
+			continue
+		}
+
+		for j := 0; j < rs.ScopeSpans().Len(); j++ {
+			spans := rs.ScopeSpans().At(j).Spans()
+			for k := 0; k < spans.Len(); k++ {
+				span := spans.At(k)
+				if !span.ParentSpanID().IsEmpty() || span.Name() == "" {
+					continue
+				}
+
+				samplerType, samplerParam, ok := samplerParams(span.Attributes())
+				if !ok {
+					// clients not reporting their sampler are assumed to apply the strategy we serve
+					samplerType, samplerParam = samplerTypeProbabilistic, as.currentProbability(ctx, service.Str(), span.Name())
+				}
+				as.aggregator.RecordThroughput(service.Str(), span.Name(), samplerType, samplerParam)
+			}
+		}
+	}
+}
+
+// currentProbability returns the sampling probability currently served for the given operation.
+func (as *adaptiveSource) currentProbability(ctx context.Context, service, operation string) float64 {
+	as.probabilitiesMu.RLock()
+	probabilities, ok := as.probabilities[service]
+	as.probabilitiesMu.RUnlock()
+	if !ok {
+		probabilities = as.serviceProbabilities(ctx, service)
+		as.probabilitiesMu.Lock()
+		as.probabilities[service] = probabilities
+		as.probabilitiesMu.Unlock()
+	}
+
+	if probability, ok := probabilities.operations[operation]; ok {
+		return probability
+	}
+	return probabilities.defaultProbability
+}
+
+// serviceProbabilities returns the probabilities of the strategy currently served to the given service.
+func (as *adaptiveSource) serviceProbabilities(ctx context.Context, service string) *serviceProbabilities {
+	resp, err := as.processor.GetSamplingStrategy(ctx, service)
+	if err != nil || resp.OperationSampling == nil {
+		return &serviceProbabilities{defaultProbability: defaultInitialSamplingProbability}
+	}
+
+	probabilities := &serviceProbabilities{
+		defaultProbability: resp.OperationSampling.DefaultSamplingProbability,
+		operations:         make(map[string]float64, len(resp.OperationSampling.PerOperationStrategies)),
+	}
+	for _, strategy := range resp.OperationSampling.PerOperationStrategies {
+		if strategy.ProbabilisticSampling == nil {
+			continue
+		}
+		// the first strategy of an operation is the one served
+		if _, ok := probabilities.operations[strategy.Operation]; !ok {
+			probabilities.operations[strategy.Operation] = strategy.ProbabilisticSampling.SamplingRate
+		}
+	}
+	return probabilities
+}
+
+func (as *adaptiveSource) shutdown(ctx context.Context) error {
+	close(as.done)
+	as.wg.Wait()
+	return multierr.Combine(
+		as.aggregator.Close(),
+		as.processor.Close(),
+		as.client.Close(ctx),
+	)
+}
+
+// samplerParams returns the sampler type and parameter reported by the Jaeger clients on root spans.
+func samplerParams(attrs pcommon.Map) (string, float64, bool) {
+	samplerType, ok := attrs.Get(samplerTypeTagKey)
+	if !ok || samplerType.Type() != pcommon.ValueTypeStr {
+		return "", 0, false
+	}
+	switch samplerType.Str() {
+	case samplerTypeProbabilistic, samplerTypeLowerBound, samplerTypeRateLimiting:
+	default:
+		return "", 0, false
+	}
+
+	samplerParam, ok := attrs.Get(samplerParamTagKey)
+	if !ok {
+		return "", 0, false
+	}
+	switch samplerParam.Type() {
+	case pcommon.ValueTypeDouble:
+		return samplerType.Str(), samplerParam.Double(), true
+	case pcommon.ValueTypeInt:
+		return samplerType.Str(), float64(samplerParam.Int()), true
+	case pcommon.ValueTypeStr:
+		param, err := strconv.ParseFloat(samplerParam.Str(), 64)
+		if err != nil {
+			return "", 0, false
+		}
+		return samplerType.Str(), param, true
+	default:
+		return "", 0, false
+	}
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, id component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindExtension, id, "")
+}
+
+// options returns the Jaeger adaptive sampling options, replacing the zero values by the defaults.
+func (cfg *AdaptiveSettings) options() adaptive.Options {
+	opts := adaptive.Options{
+		TargetSamplesPerSecond:       cfg.TargetSamplesPerSecond,
+		DeltaTolerance:               cfg.DeltaTolerance,
+		CalculationInterval:          cfg.CalculationInterval,
+		AggregationBuckets:           cfg.AggregationBuckets,
+		BucketsForCalculation:        cfg.BucketsForCalculation,
+		Delay:                        cfg.Delay,
+		InitialSamplingProbability:   cfg.InitialSamplingProbability,
+		MinSamplingProbability:       cfg.MinSamplingProbability,
+		MinSamplesPerSecond:          cfg.MinSamplesPerSecond,
+		LeaderLeaseRefreshInterval:   leaderLeaseRefreshInterval,
+		FollowerLeaseRefreshInterval: followerLeaseRefreshInterval,
+	}
+	if opts.TargetSamplesPerSecond == 0 {
+		opts.TargetSamplesPerSecond = defaultTargetSamplesPerSecond
+	}
+	if opts.DeltaTolerance == 0 {
+		opts.DeltaTolerance = defaultDeltaTolerance
+	}
+	if opts.CalculationInterval == 0 {
+		opts.CalculationInterval = defaultCalculationInterval
+	}
+	if opts.AggregationBuckets == 0 {
+		opts.AggregationBuckets = defaultAggregationBuckets
+	}
+	if opts.BucketsForCalculation == 0 {
+		opts.BucketsForCalculation = defaultBucketsForCalculation
+	}
+	if opts.Delay == 0 {
+		opts.Delay = defaultDelay
+	}
+	if opts.InitialSamplingProbability == 0 {
+		opts.InitialSamplingProbability = defaultInitialSamplingProbability
+	}
+	if opts.MinSamplingProbability == 0 {
+		opts.MinSamplingProbability = defaultMinSamplingProbability
+	}
+	if opts.MinSamplesPerSecond == 0 {
+		opts.MinSamplesPerSecond = defaultMinSamplesPerSecond
+	}
+	return opts
+}
+
+var _ distributedlock.Lock = localLock{}
+
+// localLock is a distributedlock.Lock that is always acquired, as the strategies are
+// calculated by a single collector.
+type localLock struct{}
+
+func (localLock) Acquire(string, time.Duration) (bool, error) {
+	return true, nil
+}
+
+func (localLock) Forfeit(string) (bool, error) {
+	return true, nil
+}
@@ -24,9 +24,11 @@ import (
 )
 
 var (
-	errTooManySources     = errors.New("too many sources specified, has to be either 'file' or 'remote'")
-	errNoSources          = errors.New("no sources specified, has to be either 'file' or 'remote'")
-	errAtLeastOneProtocol = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+	errTooManySources           = errors.New("too many sources specified, has to be one of 'file', 'remote' or 'adaptive'")
+	errNoSources                = errors.New("no sources specified, has to be one of 'file', 'remote' or 'adaptive'")
+	errAtLeastOneProtocol       = errors.New("no protocols selected to serve the strategies, use 'grpc', 'http', or both")
+	errNegativeAdaptiveSetting  = errors.New("adaptive settings can't be negative")
+	errInvalidAdaptiveThreshold = errors.New("adaptive sampling probabilities have to be between 0 and 1")
 )
 
 // Config has the configuration for the extension enabling the health check
@@ -35,7 +37,7 @@ type Config struct {
 	*confighttp.HTTPServerSettings `mapstructure:"http"`
 	*configgrpc.GRPCServerSettings `mapstructure:"grpc"`
 
-	// Source configures the source for the strategies file. One of `remote` or `file` has to be specified.
+	// Source configures the source for the strategies file. One of `remote`, `file` or `adaptive` has to be specified.
 	Source Source `mapstructure:"source"`
 }
 
@@ -48,6 +50,44 @@ type Source struct {
 
 	// ReloadInterval determines the periodicity to refresh the strategies
 	ReloadInterval time.Duration `mapstructure:"reload_interval"`
+
+	// Adaptive calculates the strategies from the traces observed by the jaegeradaptivesampling processor
+	Adaptive *AdaptiveSettings `mapstructure:"adaptive"`
+}
+
+// AdaptiveSettings configures the adaptive sampling strategies, calculated per service and operation
+// so that each of them is sampled at the target rate. Zero values are replaced by the defaults.
+type AdaptiveSettings struct {
+	// TargetSamplesPerSecond is the target rate of sampled traces per operation.
+	TargetSamplesPerSecond float64 `mapstructure:"target_samples_per_second"`
+
+	// DeltaTolerance is the acceptable deviation between the observed and the target rate, as a ratio.
+	DeltaTolerance float64 `mapstructure:"delta_tolerance"`
+
+	// CalculationInterval determines how often new probabilities are calculated.
+	CalculationInterval time.Duration `mapstructure:"calculation_interval"`
+
+	// AggregationBuckets is the number of throughput buckets of CalculationInterval kept in memory.
+	AggregationBuckets int `mapstructure:"aggregation_buckets"`
+
+	// BucketsForCalculation is the number of recent buckets used to calculate the probabilities.
+	BucketsForCalculation int `mapstructure:"buckets_for_calculation"`
+
+	// Delay is how far behind the current time the calculation runs, giving the clients time to
+	// poll the latest strategies.
+	Delay time.Duration `mapstructure:"delay"`
+
+	// InitialSamplingProbability is the sampling probability for new operations.
+	InitialSamplingProbability float64 `mapstructure:"initial_sampling_probability"`
+
+	// MinSamplingProbability is the minimum sampling probability for all operations.
+	MinSamplingProbability float64 `mapstructure:"min_sampling_probability"`
+
+	// MinSamplesPerSecond is the minimum rate of sampled traces per operation.
+	MinSamplesPerSecond float64 `mapstructure:"min_samples_per_second"`
+
+	// StorageID is the storage extension used to persist the throughput and probabilities across restarts.
+	StorageID *component.ID `mapstructure:"storage"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -58,13 +98,44 @@ func (cfg *Config) Validate() error {
 		return errAtLeastOneProtocol
 	}
 
-	if cfg.Source.File != "" && cfg.Source.Remote != nil {
+	sources := 0
+	if cfg.Source.File != "" {
+		sources++
+	}
+	if cfg.Source.Remote != nil {
+		sources++
+	}
+	if cfg.Source.Adaptive != nil {
+		sources++
+	}
+
+	if sources > 1 {
 		return errTooManySources
 	}
 
-	if cfg.Source.File == "" && cfg.Source.Remote == nil {
+	if sources == 0 {
 		return errNoSources
 	}
 
+	if cfg.Source.Adaptive != nil {
+		return cfg.Source.Adaptive.Validate()
+	}
+
+	return nil
+}
+
+// Validate checks if the adaptive settings are valid
+func (cfg *AdaptiveSettings) Validate() error {
+	if cfg.TargetSamplesPerSecond < 0 || cfg.DeltaTolerance < 0 || cfg.CalculationInterval < 0 ||
+		cfg.AggregationBuckets < 0 || cfg.BucketsForCalculation < 0 || cfg.Delay < 0 || cfg.MinSamplesPerSecond < 0 {
+		return errNegativeAdaptiveSetting
+	}
+
+	for _, p := range []float64{cfg.InitialSamplingProbability, cfg.MinSamplingProbability} {
+		if p < 0 || p > 1 {
+			return errInvalidAdaptiveThreshold
+		}
+	}
+
 	return nil
 }
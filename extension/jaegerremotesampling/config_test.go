@@ -31,6 +31,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
+
 	tests := []struct {
 		id       component.ID
 		expected component.Config
@@ -64,6 +66,24 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "adaptive"),
+			expected: &Config{
+				HTTPServerSettings: &confighttp.HTTPServerSettings{Endpoint: ":5778"},
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{NetAddr: confignet.NetAddr{
+					Endpoint:  ":14250",
+					Transport: "tcp",
+				}},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{
+						TargetSamplesPerSecond:     2,
+						CalculationInterval:        30 * time.Second,
+						InitialSamplingProbability: 0.01,
+						StorageID:                  &storageID,
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.id.String(), func(t *testing.T) {
@@ -110,6 +130,46 @@ func TestValidate(t *testing.T) {
 			},
 			expected: errTooManySources,
 		},
+		{
+			desc: "file and adaptive sources",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					File:     "/tmp/some-file",
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+			expected: errTooManySources,
+		},
+		{
+			desc: "adaptive source",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{},
+				},
+			},
+		},
+		{
+			desc: "negative adaptive setting",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{CalculationInterval: -time.Second},
+				},
+			},
+			expected: errNegativeAdaptiveSetting,
+		},
+		{
+			desc: "invalid adaptive probability",
+			cfg: Config{
+				GRPCServerSettings: &configgrpc.GRPCServerSettings{},
+				Source: Source{
+					Adaptive: &AdaptiveSettings{MinSamplingProbability: 1.5},
+				},
+			},
+			expected: errInvalidAdaptiveThreshold,
+		},
 	}
 	for _, tC := range testCases {
 		t.Run(tC.desc, func(t *testing.T) {
@@ -23,6 +23,7 @@ import (
 	"github.com/jaegertracing/jaeger/plugin/sampling/strategystore/static"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/extension"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"go.uber.org/zap"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
@@ -31,20 +32,23 @@ import (
 var _ extension.Extension = (*jrsExtension)(nil)
 
 type jrsExtension struct {
+	id        component.ID
 	cfg       *Config
 	telemetry component.TelemetrySettings
 
 	httpServer    component.Component
 	grpcServer    component.Component
 	samplingStore strategystore.StrategyStore
+	adaptive      *adaptiveSource
 
 	closers []func() error
 }
 
-func newExtension(cfg *Config, telemetry component.TelemetrySettings) *jrsExtension {
+func newExtension(cfg *Config, set extension.CreateSettings) *jrsExtension {
 	jrse := &jrsExtension{
+		id:        set.ID,
 		cfg:       cfg,
-		telemetry: telemetry,
+		telemetry: set.TelemetrySettings,
 	}
 	return jrse
 }
@@ -54,6 +58,7 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	// source of the sampling config:
 	// - remote (gRPC)
 	// - local file
+	// - adaptive
 	// we can then use a simplified logic here to assign the appropriate store
 	if jrse.cfg.Source.File != "" {
 		opts := static.Options{
@@ -82,6 +87,19 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 		})
 	}
 
+	if jrse.cfg.Source.Adaptive != nil {
+		as, err := newAdaptiveSource(ctx, host, jrse.id, jrse.cfg.Source.Adaptive, jrse.telemetry.Logger)
+		if err != nil {
+			return fmt.Errorf("failed to create the adaptive strategy store: %w", err)
+		}
+
+		jrse.adaptive = as
+		jrse.samplingStore = as.processor
+		jrse.closers = append(jrse.closers, func() error {
+			return as.shutdown(context.Background())
+		})
+	}
+
 	if jrse.cfg.HTTPServerSettings != nil {
 		httpServer, err := internal.NewHTTP(jrse.telemetry, *jrse.cfg.HTTPServerSettings, jrse.samplingStore)
 		if err != nil {
@@ -109,6 +127,15 @@ func (jrse *jrsExtension) Start(ctx context.Context, host component.Host) error
 	return nil
 }
 
+// ObserveTraces records the throughput of the root spans in the given traces for the adaptive source.
+func (jrse *jrsExtension) ObserveTraces(ctx context.Context, td ptrace.Traces) error {
+	if jrse.adaptive == nil {
+		return errAdaptiveNotConfigured
+	}
+	jrse.adaptive.observe(ctx, td)
+	return nil
+}
+
 func (jrse *jrsExtension) Shutdown(ctx context.Context) error {
 	// we probably don't want to break whenever an error occurs, we want to continue and close the other resources
 	if jrse.httpServer != nil {
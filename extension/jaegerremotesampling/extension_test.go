@@ -20,12 +20,18 @@ import (
 	"net"
 	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
 	"github.com/jaegertracing/jaeger/proto-gen/api_v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/configgrpc"
+	"go.opentelemetry.io/collector/extension/extensiontest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/ptrace"
 	"google.golang.org/grpc"
 )
 
@@ -33,7 +39,7 @@ func TestNewExtension(t *testing.T) {
 	// test
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
 
 	// verify
 	assert.NotNil(t, e)
@@ -44,7 +50,7 @@ func TestStartAndShutdownLocalFile(t *testing.T) {
 	cfg := testConfig()
 	cfg.Source.File = filepath.Join("testdata", "strategy.json")
 
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
 	require.NotNil(t, e)
 	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
 
@@ -76,7 +82,7 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	}
 
 	// create the extension
-	e := newExtension(cfg, componenttest.NewNopTelemetrySettings())
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
 	require.NotNil(t, e)
 
 	// test
@@ -84,6 +90,224 @@ func TestStartAndShutdownRemote(t *testing.T) {
 	assert.NoError(t, e.Shutdown(context.Background()))
 }
 
+func TestStartAndShutdownAdaptive(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveSettings{}
+
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
+	require.NotNil(t, e)
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+
+	// test and verify
+	assert.NoError(t, e.Shutdown(context.Background()))
+}
+
+func TestStartAdaptiveMissingStorage(t *testing.T) {
+	// prepare
+	storageID := component.NewID("file_storage")
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveSettings{StorageID: &storageID}
+
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
+	require.NotNil(t, e)
+
+	// test and verify
+	assert.ErrorContains(t, e.Start(context.Background(), componenttest.NewNopHost()), "storage extension 'file_storage' not found")
+}
+
+func TestObserveTraces(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveSettings{CalculationInterval: 10 * time.Millisecond}
+
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, e.Shutdown(context.Background())) }()
+
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "foo")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+
+	// a root span reported by a jaeger client
+	span := spans.AppendEmpty()
+	span.SetName("GET /")
+	span.Attributes().PutStr("sampler.type", "probabilistic")
+	span.Attributes().PutDouble("sampler.param", 0.5)
+
+	// a root span without sampler tags, counted with the served probability
+	span = spans.AppendEmpty()
+	span.SetName("GET /")
+
+	// a lowerbound sampled root span, recorded without counting
+	span = spans.AppendEmpty()
+	span.SetName("POST /")
+	span.Attributes().PutStr("sampler.type", "lowerbound")
+	span.Attributes().PutDouble("sampler.param", 0.001)
+
+	// a child span, ignored
+	span = spans.AppendEmpty()
+	span.SetName("GET /")
+	span.SetParentSpanID([8]byte{1, 2, 3, 4, 5, 6, 7, 8})
+
+	// a resource without service name, ignored
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+
+	// test
+	require.NoError(t, e.ObserveTraces(context.Background(), td))
+
+	// verify
+	var throughput []*model.Throughput
+	assert.Eventually(t, func() bool {
+		throughput, _ = e.adaptive.store.GetThroughput(time.Time{}, time.Now().Add(time.Hour))
+		return len(throughput) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	counts := map[string]int64{}
+	for _, tp := range throughput {
+		assert.Equal(t, "foo", tp.Service)
+		counts[tp.Operation] = tp.Count
+	}
+	assert.Equal(t, map[string]int64{"GET /": 2, "POST /": 0}, counts)
+}
+
+func TestCurrentProbabilityCache(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveSettings{CalculationInterval: time.Hour, InitialSamplingProbability: 0.25}
+
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, e.Shutdown(context.Background())) }()
+	as := e.adaptive
+
+	// test and verify
+	assert.Equal(t, 0.25, as.currentProbability(context.Background(), "foo", "GET /"))
+	require.Contains(t, as.probabilities, "foo")
+
+	// the cached probabilities are served until the end of the calculation interval
+	as.probabilities["foo"] = &serviceProbabilities{defaultProbability: 0.1, operations: map[string]float64{"GET /": 0.5}}
+	assert.Equal(t, 0.5, as.currentProbability(context.Background(), "foo", "GET /"))
+	assert.Equal(t, 0.1, as.currentProbability(context.Background(), "foo", "POST /"))
+}
+
+func TestCurrentProbabilityCacheCleared(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.Adaptive = &AdaptiveSettings{CalculationInterval: 10 * time.Millisecond}
+
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, e.Shutdown(context.Background())) }()
+	as := e.adaptive
+
+	// test
+	as.currentProbability(context.Background(), "foo", "GET /")
+
+	// verify
+	assert.Eventually(t, func() bool {
+		as.probabilitiesMu.RLock()
+		defer as.probabilitiesMu.RUnlock()
+		return len(as.probabilities) == 0
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestObserveTracesNotAdaptive(t *testing.T) {
+	// prepare
+	cfg := testConfig()
+	cfg.Source.File = filepath.Join("testdata", "strategy.json")
+
+	e := newExtension(cfg, extensiontest.NewNopCreateSettings())
+	require.NoError(t, e.Start(context.Background(), componenttest.NewNopHost()))
+	defer func() { assert.NoError(t, e.Shutdown(context.Background())) }()
+
+	// test and verify
+	assert.Equal(t, errAdaptiveNotConfigured, e.ObserveTraces(context.Background(), ptrace.NewTraces()))
+}
+
+func TestSamplerParams(t *testing.T) {
+	testCases := []struct {
+		desc         string
+		samplerType  interface{}
+		samplerParam interface{}
+		expectedType string
+		expectedParm float64
+		expectedOk   bool
+	}{
+		{
+			desc:         "probabilistic",
+			samplerType:  "probabilistic",
+			samplerParam: 0.1,
+			expectedType: "probabilistic",
+			expectedParm: 0.1,
+			expectedOk:   true,
+		},
+		{
+			desc:         "ratelimiting with int param",
+			samplerType:  "ratelimiting",
+			samplerParam: int64(2),
+			expectedType: "ratelimiting",
+			expectedParm: 2,
+			expectedOk:   true,
+		},
+		{
+			desc:         "lowerbound with string param",
+			samplerType:  "lowerbound",
+			samplerParam: "0.25",
+			expectedType: "lowerbound",
+			expectedParm: 0.25,
+			expectedOk:   true,
+		},
+		{
+			desc:         "const sampler",
+			samplerType:  "const",
+			samplerParam: int64(1),
+		},
+		{
+			desc:         "invalid string param",
+			samplerType:  "probabilistic",
+			samplerParam: "foo",
+		},
+		{
+			desc:        "missing param",
+			samplerType: "probabilistic",
+		},
+		{
+			desc: "no tags",
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			attrs := pcommon.NewMap()
+			if tC.samplerType != nil {
+				require.NoError(t, attrs.PutEmpty("sampler.type").FromRaw(tC.samplerType))
+			}
+			if tC.samplerParam != nil {
+				require.NoError(t, attrs.PutEmpty("sampler.param").FromRaw(tC.samplerParam))
+			}
+
+			samplerType, samplerParam, ok := samplerParams(attrs)
+			assert.Equal(t, tC.expectedType, samplerType)
+			assert.Equal(t, tC.expectedParm, samplerParam)
+			assert.Equal(t, tC.expectedOk, ok)
+		})
+	}
+}
+
+func TestAdaptiveOptionsDefaults(t *testing.T) {
+	opts := (&AdaptiveSettings{TargetSamplesPerSecond: 5}).options()
+	assert.Equal(t, 5.0, opts.TargetSamplesPerSecond)
+	assert.Equal(t, defaultDeltaTolerance, opts.DeltaTolerance)
+	assert.Equal(t, defaultCalculationInterval, opts.CalculationInterval)
+	assert.Equal(t, defaultAggregationBuckets, opts.AggregationBuckets)
+	assert.Equal(t, defaultBucketsForCalculation, opts.BucketsForCalculation)
+	assert.Equal(t, defaultDelay, opts.Delay)
+	assert.Equal(t, defaultInitialSamplingProbability, opts.InitialSamplingProbability)
+	assert.Equal(t, defaultMinSamplingProbability, opts.MinSamplingProbability)
+	assert.Equal(t, defaultMinSamplesPerSecond, opts.MinSamplesPerSecond)
+}
+
 type samplingServer struct {
 	api_v2.UnimplementedSamplingManagerServer
 }
@@ -0,0 +1,165 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling/internal"
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/jaegertracing/jaeger/storage/samplingstore"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+const (
+	throughputKey    = "throughput"
+	probabilitiesKey = "probabilities"
+)
+
+var _ samplingstore.Store = (*SamplingStore)(nil)
+
+type throughputBucket struct {
+	Timestamp  time.Time           `json:"timestamp"`
+	Throughput []*model.Throughput `json:"throughput"`
+}
+
+type probabilitiesAndQPS struct {
+	Hostname      string                              `json:"hostname"`
+	Probabilities model.ServiceOperationProbabilities `json:"probabilities"`
+	QPS           model.ServiceOperationQPS           `json:"qps"`
+}
+
+// SamplingStore is a samplingstore.Store keeping the throughput and the calculated
+// probabilities in memory. The state is written through to the given storage client,
+// so that it survives restarts when a storage extension is configured.
+type SamplingStore struct {
+	mu            sync.Mutex
+	client        storage.Client
+	retention     time.Duration
+	buckets       []throughputBucket
+	probabilities probabilitiesAndQPS
+	now           func() time.Time
+}
+
+// NewSamplingStore creates a SamplingStore backed by the given storage client, keeping
+// throughput data for the given retention period.
+func NewSamplingStore(client storage.Client, retention time.Duration) *SamplingStore {
+	return &SamplingStore{
+		client:    client,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// Load restores the state previously persisted in the storage client.
+func (s *SamplingStore) Load(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	raw, err := s.client.Get(ctx, throughputKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the throughput from storage: %w", err)
+	}
+	if raw != nil {
+		if err = json.Unmarshal(raw, &s.buckets); err != nil {
+			return fmt.Errorf("failed to decode the throughput from storage: %w", err)
+		}
+		s.prune()
+	}
+
+	raw, err = s.client.Get(ctx, probabilitiesKey)
+	if err != nil {
+		return fmt.Errorf("failed to read the probabilities from storage: %w", err)
+	}
+	if raw != nil {
+		if err = json.Unmarshal(raw, &s.probabilities); err != nil {
+			return fmt.Errorf("failed to decode the probabilities from storage: %w", err)
+		}
+	}
+	return nil
+}
+
+// InsertThroughput inserts aggregated throughput for operations into storage.
+func (s *SamplingStore) InsertThroughput(throughput []*model.Throughput) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.buckets = append(s.buckets, throughputBucket{Timestamp: s.now(), Throughput: throughput})
+	s.prune()
+	return s.persist(throughputKey, s.buckets)
+}
+
+// InsertProbabilitiesAndQPS inserts calculated sampling probabilities and measured qps into storage.
+func (s *SamplingStore) InsertProbabilitiesAndQPS(hostname string, probabilities model.ServiceOperationProbabilities, qps model.ServiceOperationQPS) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.probabilities = probabilitiesAndQPS{
+		Hostname:      hostname,
+		Probabilities: probabilities,
+		QPS:           qps,
+	}
+	return s.persist(probabilitiesKey, s.probabilities)
+}
+
+// GetThroughput retrieves aggregated throughput for operations within a time range.
+func (s *SamplingStore) GetThroughput(start, end time.Time) ([]*model.Throughput, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var throughput []*model.Throughput
+	for _, bucket := range s.buckets {
+		if !bucket.Timestamp.Before(start) && bucket.Timestamp.Before(end) {
+			throughput = append(throughput, bucket.Throughput...)
+		}
+	}
+	return throughput, nil
+}
+
+// GetLatestProbabilities retrieves the latest sampling probabilities.
+func (s *SamplingStore) GetLatestProbabilities() (model.ServiceOperationProbabilities, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	probabilities := make(model.ServiceOperationProbabilities, len(s.probabilities.Probabilities))
+	for service, operations := range s.probabilities.Probabilities {
+		probabilities[service] = make(map[string]float64, len(operations))
+		for operation, probability := range operations {
+			probabilities[service][operation] = probability
+		}
+	}
+	return probabilities, nil
+}
+
+// prune drops the throughput buckets older than the retention period.
+func (s *SamplingStore) prune() {
+	cutoff := s.now().Add(-s.retention)
+	i := 0
+	for i < len(s.buckets) && s.buckets[i].Timestamp.Before(cutoff) {
+		i++
+	}
+	s.buckets = s.buckets[i:]
+}
+
+func (s *SamplingStore) persist(key string, value interface{}) error {
+	raw, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	return s.client.Set(context.Background(), key, raw)
+}
@@ -0,0 +1,125 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package internal
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jaegertracing/jaeger/cmd/collector/app/sampling/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+)
+
+func TestSamplingStoreThroughput(t *testing.T) {
+	// prepare
+	now := time.Unix(1000, 0)
+	s := NewSamplingStore(storage.NewNopClient(), time.Minute)
+	s.now = func() time.Time { return now }
+
+	// test
+	require.NoError(t, s.InsertThroughput([]*model.Throughput{{Service: "foo", Operation: "a", Count: 1}}))
+	now = now.Add(30 * time.Second)
+	require.NoError(t, s.InsertThroughput([]*model.Throughput{{Service: "foo", Operation: "b", Count: 2}}))
+
+	// verify
+	throughput, err := s.GetThroughput(time.Unix(1000, 0), time.Unix(1030, 0))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Throughput{{Service: "foo", Operation: "a", Count: 1}}, throughput)
+
+	throughput, err = s.GetThroughput(time.Unix(1000, 0), time.Unix(1031, 0))
+	require.NoError(t, err)
+	assert.Len(t, throughput, 2)
+
+	// the first bucket is pruned once it falls out of the retention
+	now = now.Add(45 * time.Second)
+	require.NoError(t, s.InsertThroughput(nil))
+	throughput, err = s.GetThroughput(time.Time{}, now.Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Throughput{{Service: "foo", Operation: "b", Count: 2}}, throughput)
+}
+
+func TestSamplingStorePersistence(t *testing.T) {
+	// prepare
+	client := newMapClient()
+	s := NewSamplingStore(client, time.Hour)
+	probabilities := model.ServiceOperationProbabilities{"foo": {"a": 0.5}}
+	qps := model.ServiceOperationQPS{"foo": {"a": 2}}
+
+	require.NoError(t, s.Load(context.Background()))
+	require.NoError(t, s.InsertThroughput([]*model.Throughput{{Service: "foo", Operation: "a", Count: 3, Probabilities: map[string]struct{}{"0.500000": {}}}}))
+	require.NoError(t, s.InsertProbabilitiesAndQPS("host", probabilities, qps))
+
+	// test
+	restored := NewSamplingStore(client, time.Hour)
+	require.NoError(t, restored.Load(context.Background()))
+
+	// verify
+	latest, err := restored.GetLatestProbabilities()
+	require.NoError(t, err)
+	assert.Equal(t, probabilities, latest)
+
+	throughput, err := restored.GetThroughput(time.Time{}, time.Now().Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []*model.Throughput{{Service: "foo", Operation: "a", Count: 3, Probabilities: map[string]struct{}{"0.500000": {}}}}, throughput)
+}
+
+func TestSamplingStoreLoadErrors(t *testing.T) {
+	// prepare
+	client := newMapClient()
+	client.data[throughputKey] = []byte("not json")
+	s := NewSamplingStore(client, time.Hour)
+
+	// test and verify
+	assert.ErrorContains(t, s.Load(context.Background()), "failed to decode the throughput from storage")
+
+	client.err = errors.New("storage unavailable")
+	assert.ErrorContains(t, s.Load(context.Background()), "storage unavailable")
+}
+
+// mapClient is a storage.Client keeping the data in memory.
+type mapClient struct {
+	data map[string][]byte
+	err  error
+}
+
+func newMapClient() *mapClient {
+	return &mapClient{data: map[string][]byte{}}
+}
+
+func (c *mapClient) Get(_ context.Context, key string) ([]byte, error) {
+	return c.data[key], c.err
+}
+
+func (c *mapClient) Set(_ context.Context, key string, value []byte) error {
+	c.data[key] = value
+	return c.err
+}
+
+func (c *mapClient) Delete(_ context.Context, key string) error {
+	delete(c.data, key)
+	return c.err
+}
+
+func (c *mapClient) Batch(context.Context, ...storage.Operation) error {
+	return c.err
+}
+
+func (c *mapClient) Close(context.Context) error {
+	return nil
+}
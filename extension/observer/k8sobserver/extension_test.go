@@ -229,3 +229,64 @@ func TestExtensionObserveNodes(t *testing.T) {
 
 	require.NoError(t, ext.Shutdown(context.Background()))
 }
+
+func TestExtensionObserveServicesAndIngresses(t *testing.T) {
+	factory := NewFactory()
+	config := factory.CreateDefaultConfig().(*Config)
+	config.ObservePods = false
+	config.ObserveServices = true
+	config.ObserveIngresses = true
+	mockServiceHost(t, config)
+
+	set := extensiontest.NewNopCreateSettings()
+	set.ID = component.NewID(typeStr)
+	ext, err := newObserver(config, set)
+	require.NoError(t, err)
+	require.NotNil(t, ext)
+
+	obs := ext.(*k8sObserver)
+	require.Nil(t, obs.podListerWatcher)
+	require.NotNil(t, obs.serviceListerWatcher)
+	require.NotNil(t, obs.ingressListerWatcher)
+
+	serviceListerWatcher := framework.NewFakeControllerSource()
+	obs.serviceListerWatcher = serviceListerWatcher
+	ingressListerWatcher := framework.NewFakeControllerSource()
+	obs.ingressListerWatcher = ingressListerWatcher
+
+	serviceListerWatcher.Add(service1V1)
+	ingressListerWatcher.Add(NewIngress("ingress1"))
+
+	require.NoError(t, ext.Start(context.Background(), componenttest.NewNopHost()))
+
+	sink := &endpointSink{}
+	obs.ListAndWatch(sink)
+
+	// Two service ports and two ingress rules.
+	requireSink(t, sink, func() bool {
+		return len(sink.added) == 4
+	})
+
+	endpointTypes := map[observer.EndpointType]int{}
+	for _, e := range sink.added {
+		endpointTypes[e.Details.Type()]++
+	}
+	assert.Equal(t, map[observer.EndpointType]int{observer.K8sServiceType: 2, observer.K8sIngressType: 2}, endpointTypes)
+
+	serviceListerWatcher.Modify(service1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.changed) == 2
+	})
+	for _, e := range sink.changed {
+		assert.Equal(t, "2", e.Details.(*observer.K8sService).Labels["service-version"])
+	}
+
+	serviceListerWatcher.Delete(service1V2)
+
+	requireSink(t, sink, func() bool {
+		return len(sink.removed) == 2
+	})
+
+	require.NoError(t, ext.Shutdown(context.Background()))
+}
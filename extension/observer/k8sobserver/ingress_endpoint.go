@@ -0,0 +1,102 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"strings"
+
+	networkingv1 "k8s.io/api/networking/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertIngressToEndpoints converts an ingress instance into a k8s.ingress observer.Endpoint for each host and
+// path of its rules. Rules without a host use the first load balancer address of the ingress. Wildcard hosts
+// are skipped since they cannot be contacted. The Target is the URL of the host and path.
+func convertIngressToEndpoints(idNamespace string, ingress *networkingv1.Ingress) []observer.Endpoint {
+	ingressID := observer.EndpointID(fmt.Sprintf("%s/%s-%s", idNamespace, ingress.Name, ingress.UID))
+
+	tlsHosts := map[string]bool{}
+	for _, tls := range ingress.Spec.TLS {
+		for _, host := range tls.Hosts {
+			tlsHosts[host] = true
+		}
+	}
+
+	var loadBalancerHost string
+	for _, lbIngress := range ingress.Status.LoadBalancer.Ingress {
+		if lbIngress.Hostname != "" {
+			loadBalancerHost = lbIngress.Hostname
+			break
+		}
+		if lbIngress.IP != "" {
+			loadBalancerHost = lbIngress.IP
+			break
+		}
+	}
+
+	var endpoints []observer.Endpoint
+	seen := map[observer.EndpointID]bool{}
+	for _, rule := range ingress.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = loadBalancerHost
+		}
+		if host == "" || strings.Contains(host, "*") {
+			continue
+		}
+
+		scheme := "http"
+		if tlsHosts[rule.Host] {
+			scheme = "https"
+		}
+
+		paths := []string{"/"}
+		if rule.HTTP != nil && len(rule.HTTP.Paths) > 0 {
+			paths = paths[:0]
+			for _, path := range rule.HTTP.Paths {
+				if path.Path == "" {
+					paths = append(paths, "/")
+				} else {
+					paths = append(paths, path.Path)
+				}
+			}
+		}
+
+		for _, path := range paths {
+			endpointID := observer.EndpointID(fmt.Sprintf("%s/%s%s", ingressID, host, path))
+			if seen[endpointID] {
+				continue
+			}
+			seen[endpointID] = true
+			endpoints = append(endpoints, observer.Endpoint{
+				ID:     endpointID,
+				Target: fmt.Sprintf("%s://%s%s", scheme, host, path),
+				Details: &observer.K8sIngress{
+					Name:        ingress.Name,
+					UID:         string(ingress.UID),
+					Namespace:   ingress.Namespace,
+					Annotations: ingress.Annotations,
+					Labels:      ingress.Labels,
+					Scheme:      scheme,
+					Host:        host,
+					Path:        path,
+				},
+			})
+		}
+	}
+
+	return endpoints
+}
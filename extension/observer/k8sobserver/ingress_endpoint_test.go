@@ -0,0 +1,55 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestIngressObjectToK8sIngressEndpoints(t *testing.T) {
+	expected := []observer.Endpoint{
+		{
+			ID:     "namespace/ingress1-ingress1-UID/secure.example.com/api",
+			Target: "https://secure.example.com/api",
+			Details: &observer.K8sIngress{
+				Name:      "ingress1",
+				UID:       "ingress1-UID",
+				Namespace: "default",
+				Labels:    map[string]string{"env": "prod"},
+				Scheme:    "https",
+				Host:      "secure.example.com",
+				Path:      "/api",
+			},
+		},
+		{
+			ID:     "namespace/ingress1-ingress1-UID/192.168.1.1/",
+			Target: "http://192.168.1.1/",
+			Details: &observer.K8sIngress{
+				Name:      "ingress1",
+				UID:       "ingress1-UID",
+				Namespace: "default",
+				Labels:    map[string]string{"env": "prod"},
+				Scheme:    "http",
+				Host:      "192.168.1.1",
+				Path:      "/",
+			},
+		},
+	}
+
+	require.Equal(t, expected, convertIngressToEndpoints("namespace", NewIngress("ingress1")))
+}
@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package k8sobserver // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer/k8sobserver"
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+// convertServiceToEndpoints converts a service instance into a k8s.service observer.Endpoint for each of its
+// ports. The Target is the cluster IP of the service, the external name for ExternalName services or the
+// service DNS name for headless services, joined with the port.
+func convertServiceToEndpoints(idNamespace string, service *v1.Service) []observer.Endpoint {
+	serviceID := observer.EndpointID(fmt.Sprintf("%s/%s-%s", idNamespace, service.Name, service.UID))
+
+	var host string
+	switch {
+	case service.Spec.Type == v1.ServiceTypeExternalName:
+		host = service.Spec.ExternalName
+	case service.Spec.ClusterIP != "" && service.Spec.ClusterIP != v1.ClusterIPNone:
+		host = service.Spec.ClusterIP
+	default:
+		host = fmt.Sprintf("%s.%s.svc", service.Name, service.Namespace)
+	}
+
+	endpoints := make([]observer.Endpoint, 0, len(service.Spec.Ports))
+	for _, port := range service.Spec.Ports {
+		endpoints = append(endpoints, observer.Endpoint{
+			ID:     observer.EndpointID(fmt.Sprintf("%s/%s(%d)", serviceID, port.Name, port.Port)),
+			Target: net.JoinHostPort(host, strconv.Itoa(int(port.Port))),
+			Details: &observer.K8sService{
+				Name:        service.Name,
+				UID:         string(service.UID),
+				Namespace:   service.Namespace,
+				Annotations: service.Annotations,
+				Labels:      service.Labels,
+				ServiceType: string(service.Spec.Type),
+				ClusterIP:   service.Spec.ClusterIP,
+				PortName:    port.Name,
+				Port:        uint16(port.Port),
+				Transport:   getTransport(port.Protocol),
+			},
+		})
+	}
+
+	return endpoints
+}
@@ -0,0 +1,100 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package k8sobserver
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	v1 "k8s.io/api/core/v1"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/observer"
+)
+
+func TestServiceObjectToK8sServiceEndpoints(t *testing.T) {
+	details := func(portName string, port uint16, transport observer.Transport, serviceType v1.ServiceType, clusterIP string) *observer.K8sService {
+		return &observer.K8sService{
+			Name:        "service1",
+			UID:         "service1-UID",
+			Namespace:   "default",
+			Annotations: map[string]string{"annotation-key": "annotation-value"},
+			Labels:      map[string]string{"env": "prod"},
+			ServiceType: string(serviceType),
+			ClusterIP:   clusterIP,
+			PortName:    portName,
+			Port:        port,
+			Transport:   transport,
+		}
+	}
+
+	tests := []struct {
+		name     string
+		service  *v1.Service
+		expected []observer.Endpoint
+	}{
+		{
+			name:    "cluster ip",
+			service: service1V1,
+			expected: []observer.Endpoint{
+				{
+					ID:      "namespace/service1-service1-UID/http(80)",
+					Target:  "10.0.0.10:80",
+					Details: details("http", 80, observer.ProtocolTCP, v1.ServiceTypeClusterIP, "10.0.0.10"),
+				},
+				{
+					ID:      "namespace/service1-service1-UID/dns(53)",
+					Target:  "10.0.0.10:53",
+					Details: details("dns", 53, observer.ProtocolUDP, v1.ServiceTypeClusterIP, "10.0.0.10"),
+				},
+			},
+		},
+		{
+			name: "headless",
+			service: func() *v1.Service {
+				service := NewService("service1", v1.ClusterIPNone)
+				service.Spec.Ports = service.Spec.Ports[:1]
+				return service
+			}(),
+			expected: []observer.Endpoint{
+				{
+					ID:      "namespace/service1-service1-UID/http(80)",
+					Target:  "service1.default.svc:80",
+					Details: details("http", 80, observer.ProtocolTCP, v1.ServiceTypeClusterIP, v1.ClusterIPNone),
+				},
+			},
+		},
+		{
+			name: "external name",
+			service: func() *v1.Service {
+				service := NewService("service1", "")
+				service.Spec.Type = v1.ServiceTypeExternalName
+				service.Spec.ExternalName = "db.example.com"
+				service.Spec.Ports = service.Spec.Ports[:1]
+				return service
+			}(),
+			expected: []observer.Endpoint{
+				{
+					ID:      "namespace/service1-service1-UID/http(80)",
+					Target:  "db.example.com:80",
+					Details: details("http", 80, observer.ProtocolTCP, v1.ServiceTypeExternalName, ""),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, convertServiceToEndpoints("namespace", tt.service))
+		})
+	}
+}
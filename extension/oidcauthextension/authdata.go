@@ -14,7 +14,11 @@
 
 package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
 
-import "go.opentelemetry.io/collector/client"
+import (
+	"sort"
+
+	"go.opentelemetry.io/collector/client"
+)
 
 var _ client.AuthData = (*authData)(nil)
 
@@ -22,6 +26,8 @@ type authData struct {
 	raw        string
 	subject    string
 	membership []string
+	// claims holds the attributes mapped from the token claims, either a string or []string.
+	claims map[string]interface{}
 }
 
 func (a *authData) GetAttribute(name string) interface{} {
@@ -33,10 +39,16 @@ func (a *authData) GetAttribute(name string) interface{} {
 	case "raw":
 		return a.raw
 	default:
-		return nil
+		return a.claims[name]
 	}
 }
 
-func (*authData) GetAttributeNames() []string {
-	return []string{"subject", "membership", "raw"}
+func (a *authData) GetAttributeNames() []string {
+	names := []string{"subject", "membership", "raw"}
+	claimNames := make([]string, 0, len(a.claims))
+	for name := range a.claims {
+		claimNames = append(claimNames, name)
+	}
+	sort.Strings(claimNames)
+	return append(names, claimNames...)
 }
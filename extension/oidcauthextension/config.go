@@ -14,6 +14,19 @@
 
 package oidcauthextension // import "github.com/open-telemetry/opentelemetry-collector-contrib/extension/oidcauthextension"
 
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	errEmptyClaimName         = errors.New("claims_to_context can't map an empty claim name")
+	errReservedAttributeName  = errors.New("claims_to_context can't map a claim to a reserved attribute name")
+	errEmptyAttributeName     = errors.New("claims_to_context can't map a claim to an empty attribute name")
+	errDuplicateAttributeName = errors.New("claims_to_context can't map several claims to the same attribute name")
+)
+
 // Config has the configuration for the OIDC Authenticator extension.
 type Config struct {
 
@@ -40,4 +53,37 @@ type Config struct {
 	// The claim that holds the subject's group membership information.
 	// Optional.
 	GroupsClaim string `mapstructure:"groups_claim"`
+
+	// ClaimsToContext maps claims from the verified token to auth data attributes,
+	// keyed by the claim name. Nested claims can be referenced with dots, e.g. "org.id".
+	// The attributes are available to processors via the `auth.<attribute>` context keys.
+	// Optional.
+	ClaimsToContext map[string]string `mapstructure:"claims_to_context"`
+}
+
+func (cfg *Config) Validate() error {
+	claims := make([]string, 0, len(cfg.ClaimsToContext))
+	for claim := range cfg.ClaimsToContext {
+		claims = append(claims, claim)
+	}
+	sort.Strings(claims)
+
+	attributes := make(map[string]string, len(claims))
+	for _, claim := range claims {
+		attr := cfg.ClaimsToContext[claim]
+		switch attr {
+		case "":
+			return fmt.Errorf("claim %q: %w", claim, errEmptyAttributeName)
+		case "subject", "membership", "raw":
+			return fmt.Errorf("claim %q to %q: %w", claim, attr, errReservedAttributeName)
+		}
+		if claim == "" {
+			return fmt.Errorf("attribute %q: %w", attr, errEmptyClaimName)
+		}
+		if previous, ok := attributes[attr]; ok {
+			return fmt.Errorf("claims %q and %q to %q: %w", previous, claim, attr, errDuplicateAttributeName)
+		}
+		attributes[attr] = claim
+	}
+	return nil
 }
@@ -18,6 +18,7 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
+	"encoding/json"
 	"encoding/pem"
 	"errors"
 	"fmt"
@@ -25,6 +26,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strconv"
 	"strings"
 	"time"
 
@@ -66,7 +68,6 @@ func newExtension(cfg *Config, logger *zap.Logger) (auth.Server, error) {
 	if cfg.Attribute == "" {
 		cfg.Attribute = defaultAttribute
 	}
-
 	oe := &oidcExtension{
 		cfg:    cfg,
 		logger: logger,
@@ -132,6 +133,7 @@ func (e *oidcExtension) authenticate(ctx context.Context, headers map[string][]s
 		raw:        raw,
 		subject:    subject,
 		membership: membership,
+		claims:     getContextClaims(claims, e.cfg.ClaimsToContext),
 	}
 	return client.NewContext(ctx, cl), nil
 }
@@ -179,6 +181,70 @@ func getGroupsFromClaims(claims map[string]interface{}, groupsClaim string) ([]s
 	return []string{}, nil
 }
 
+// getContextClaims returns the claims configured in claimsToContext, keyed by their attribute
+// name. Claims not present in the token are skipped. The values are converted to string or
+// []string, the types understood by the processors reading auth data from the context.
+func getContextClaims(claims map[string]interface{}, claimsToContext map[string]string) map[string]interface{} {
+	if len(claimsToContext) == 0 {
+		return nil
+	}
+
+	attrs := make(map[string]interface{}, len(claimsToContext))
+	for claim, attr := range claimsToContext {
+		value, ok := lookupClaim(claims, claim)
+		if !ok || value == nil {
+			continue
+		}
+		if values, isSlice := value.([]interface{}); isSlice {
+			strs := make([]string, 0, len(values))
+			for _, v := range values {
+				strs = append(strs, claimToString(v))
+			}
+			attrs[attr] = strs
+			continue
+		}
+		attrs[attr] = claimToString(value)
+	}
+	return attrs
+}
+
+// lookupClaim returns the claim with the given name. If there is no top-level claim with
+// that name, the name is treated as a dot-separated path into nested claims.
+func lookupClaim(claims map[string]interface{}, name string) (interface{}, bool) {
+	if value, ok := claims[name]; ok {
+		return value, true
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(name, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if current, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func claimToString(value interface{}) string {
+	switch v := value.(type) {
+	case string:
+		return v
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return fmt.Sprintf("%v", v)
+		}
+		return string(b)
+	}
+}
+
 func getProviderForConfig(config *Config) (*oidc.Provider, error) {
 	t := &http.Transport{
 		Proxy: http.ProxyFromEnvironment,
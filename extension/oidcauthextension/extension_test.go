@@ -31,6 +31,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/client"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.uber.org/zap"
 )
@@ -74,6 +75,147 @@ func TestOIDCAuthenticationSucceeded(t *testing.T) {
 	// TODO(jpkroehling): assert that the authentication routine set the subject/membership to the resource
 }
 
+func TestOIDCAuthenticationClaimsToContext(t *testing.T) {
+	// prepare
+	oidcServer, err := newOIDCServer()
+	require.NoError(t, err)
+	oidcServer.Start()
+	defer oidcServer.Close()
+
+	config := &Config{
+		IssuerURL: oidcServer.URL,
+		Audience:  "unit-test",
+		ClaimsToContext: map[string]string{
+			"tenant_id":   "tenant",
+			"org.name":    "org",
+			"roles":       "roles",
+			"missing":     "missing",
+			"tenant_tier": "tier",
+		},
+	}
+	p, err := newExtension(config, zap.NewNop())
+	require.NoError(t, err)
+
+	err = p.Start(context.Background(), componenttest.NewNopHost())
+	require.NoError(t, err)
+
+	payload, _ := json.Marshal(map[string]interface{}{
+		"sub":         "jdoe@example.com",
+		"iss":         oidcServer.URL,
+		"aud":         "unit-test",
+		"exp":         time.Now().Add(time.Minute).Unix(),
+		"tenant_id":   "acme",
+		"tenant_tier": 2,
+		"org":         map[string]interface{}{"name": "engineering"},
+		"roles":       []string{"admin", "viewer"},
+	})
+	token, err := oidcServer.token(payload)
+	require.NoError(t, err)
+
+	// test
+	ctx, err := p.Authenticate(context.Background(), map[string][]string{"authorization": {fmt.Sprintf("Bearer %s", token)}})
+
+	// verify
+	require.NoError(t, err)
+	cl := client.FromContext(ctx)
+	require.NotNil(t, cl.Auth)
+	assert.Equal(t, "jdoe@example.com", cl.Auth.GetAttribute("subject"))
+	assert.Equal(t, "acme", cl.Auth.GetAttribute("tenant"))
+	assert.Equal(t, "2", cl.Auth.GetAttribute("tier"))
+	assert.Equal(t, "engineering", cl.Auth.GetAttribute("org"))
+	assert.Equal(t, []string{"admin", "viewer"}, cl.Auth.GetAttribute("roles"))
+	assert.Nil(t, cl.Auth.GetAttribute("missing"))
+	assert.Equal(t, []string{"subject", "membership", "raw", "org", "roles", "tenant", "tier"}, cl.Auth.GetAttributeNames())
+}
+
+func TestClaimsToContextValidate(t *testing.T) {
+	for _, tt := range []struct {
+		casename        string
+		claimsToContext map[string]string
+		expectedError   error
+	}{
+		{
+			"valid",
+			map[string]string{"tenant_id": "tenant", "org.id": "org"},
+			nil,
+		},
+		{
+			"reserved",
+			map[string]string{"tenant_id": "subject"},
+			errReservedAttributeName,
+		},
+		{
+			"empty attribute",
+			map[string]string{"tenant_id": ""},
+			errEmptyAttributeName,
+		},
+		{
+			"empty claim",
+			map[string]string{"": "tenant"},
+			errEmptyClaimName,
+		},
+		{
+			"duplicate attribute",
+			map[string]string{"tenant_id": "tenant", "org.tenant": "tenant"},
+			errDuplicateAttributeName,
+		},
+	} {
+		t.Run(tt.casename, func(t *testing.T) {
+			config := &Config{
+				IssuerURL:       "http://example.com/",
+				Audience:        "unit-test",
+				ClaimsToContext: tt.claimsToContext,
+			}
+			err := config.Validate()
+			if tt.expectedError == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.expectedError)
+			}
+		})
+	}
+}
+
+func TestContextClaims(t *testing.T) {
+	// prepare
+	claims := map[string]interface{}{
+		"tenant":     "acme",
+		"org.id":     "top-level",
+		"org":        map[string]interface{}{"id": "nested", "region": map[string]interface{}{"name": "eu"}},
+		"verified":   true,
+		"quota":      1.5,
+		"groups":     []interface{}{"a", 1.0},
+		"attributes": map[string]interface{}{"k": "v"},
+		"nothing":    nil,
+	}
+
+	// test
+	attrs := getContextClaims(claims, map[string]string{
+		"tenant":          "tenant",
+		"org.id":          "org_id",
+		"org.region.name": "region",
+		"verified":        "verified",
+		"quota":           "quota",
+		"groups":          "groups",
+		"attributes":      "attributes",
+		"nothing":         "nothing",
+		"org.missing":     "missing",
+		"tenant.id":       "tenant_id",
+	})
+
+	// verify
+	assert.Equal(t, map[string]interface{}{
+		"tenant":     "acme",
+		"org_id":     "top-level",
+		"region":     "eu",
+		"verified":   "true",
+		"quota":      "1.5",
+		"groups":     []string{"a", "1"},
+		"attributes": `{"k":"v"}`,
+	}, attrs)
+	assert.Nil(t, getContextClaims(claims, nil))
+}
+
 func TestOIDCProviderForConfigWithTLS(t *testing.T) {
 	// prepare the CA cert for the TLS handler
 	cert := x509.Certificate{
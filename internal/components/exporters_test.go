@@ -49,6 +49,7 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/elasticsearchexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/f5cloudexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fileexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/fluentforwardexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/humioexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/influxdbexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/instanaexporter"
@@ -68,7 +69,9 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/signalfxexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/skywalkingexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/splunkhecexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sqlexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/sumologicexporter"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/syslogexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/tanzuobservabilityexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/tencentcloudlogserviceexporter"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/exporter/zipkinexporter"
@@ -95,6 +98,14 @@ func TestDefaultExporters(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			exporter: "fluentforward",
+			getConfigFn: func() component.Config {
+				cfg := expFactories["fluentforward"].CreateDefaultConfig().(*fluentforwardexporter.Config)
+				cfg.Endpoint = endpoint
+				return cfg
+			},
+		},
 		{
 			exporter: "jaeger",
 			getConfigFn: func() component.Config {
@@ -207,6 +218,15 @@ func TestDefaultExporters(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			exporter: "sql",
+			getConfigFn: func() component.Config {
+				cfg := expFactories["sql"].CreateDefaultConfig().(*sqlexporter.Config)
+				cfg.Driver = "sqlite"
+				cfg.DataSource = "file:" + filepath.Join(t.TempDir(), "otel.db")
+				return cfg
+			},
+		},
 		{
 			exporter: "zipkin",
 			getConfigFn: func() component.Config {
@@ -430,6 +450,14 @@ func TestDefaultExporters(t *testing.T) {
 				return cfg
 			},
 		},
+		{
+			exporter: "syslog",
+			getConfigFn: func() component.Config {
+				cfg := expFactories["syslog"].CreateDefaultConfig().(*syslogexporter.Config)
+				cfg.Endpoint = endpoint
+				return cfg
+			},
+		},
 		{
 			exporter: "tanzuobservability",
 			getConfigFn: func() component.Config {
@@ -78,6 +78,10 @@ func TestDefaultProcessors(t *testing.T) {
 		{
 			processor: "groupbytrace",
 		},
+		{
+			processor:     "jaegeradaptivesampling",
+			skipLifecycle: true, // Requires a jaegerremotesampling extension with the adaptive source
+		},
 		{
 			processor:     "k8sattributes",
 			skipLifecycle: true, // Requires a k8s API to communicate with
@@ -248,6 +248,9 @@ func TestDefaultReceivers(t *testing.T) {
 		{
 			receiver: "otlp",
 		},
+		{
+			receiver: "otlphttppull",
+		},
 		{
 			receiver: "otlpjsonfile",
 			getConfigFn: func() component.Config {
@@ -36,6 +36,7 @@ const (
 const (
 	formatJSON   string = "json"
 	formatLogfmt string = "logfmt"
+	formatRaw    string = "raw"
 )
 
 var defaultExporterLabels = model.LabelSet{"exporter": "OTLP"}
@@ -164,14 +165,23 @@ func convertLogToLogfmtEntry(lr plog.LogRecord, res pcommon.Resource, scope pcom
 	}, nil
 }
 
+func convertLogToRawEntry(lr plog.LogRecord) *logproto.Entry {
+	return &logproto.Entry{
+		Timestamp: timestampFromLogRecord(lr),
+		Line:      lr.Body().AsString(),
+	}
+}
+
 func convertLogToLokiEntry(lr plog.LogRecord, res pcommon.Resource, format string, scope pcommon.InstrumentationScope) (*logproto.Entry, error) {
 	switch format {
 	case formatJSON:
 		return convertLogToJSONEntry(lr, res, scope)
 	case formatLogfmt:
 		return convertLogToLogfmtEntry(lr, res, scope)
+	case formatRaw:
+		return convertLogToRawEntry(lr), nil
 	default:
-		return nil, fmt.Errorf("invalid format %s. Expected one of: %s, %s", format, formatJSON, formatLogfmt)
+		return nil, fmt.Errorf("invalid format %s. Expected one of: %s, %s, %s", format, formatJSON, formatLogfmt, formatRaw)
 	}
 
 }
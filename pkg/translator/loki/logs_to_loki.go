@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/prometheus/common/model"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
 
@@ -40,6 +41,24 @@ const (
 	levelAttributeName = "level"
 )
 
+// Options customize the conversion of logs into Loki requests.
+type Options struct {
+	// Labels are templates of stream labels, rendered for each log record. They take
+	// precedence over the labels from the hints, and a label rendered as an empty string
+	// is not added.
+	Labels map[model.LabelName]*LabelTemplate
+
+	// Format, when set, overrides the format from the `loki.format` hint. It is one of
+	// "json", "logfmt" or "raw", the latter using the body of the log records as lines.
+	Format string
+
+	// StreamLabels, when set, is called with the labels of each log record and returns
+	// the labels of the stream the record is added to, e.g. to limit the number of streams.
+	// The attributes promoted to labels by the hints are only removed from the line when
+	// they are part of the returned labels.
+	StreamLabels func(tenant string, labels model.LabelSet) model.LabelSet
+}
+
 // LogsToLokiRequests converts a Logs pipeline data into Loki PushRequests grouped
 // by tenant. The tenant value is inferred from the `loki.tenant` resource or log
 // attribute hint. If the `loki.tenant` attribute is present in both resource or
@@ -58,6 +77,12 @@ const (
 // to make this decision, as it includes all of the errors that were encountered,
 // as well as the number of items dropped and submitted.
 func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
+	return LogsToLokiRequestsWithOptions(ld, Options{})
+}
+
+// LogsToLokiRequestsWithOptions is LogsToLokiRequests with the stream labels and the
+// format of the lines customized by the given options.
+func LogsToLokiRequestsWithOptions(ld plog.Logs, opts Options) map[string]PushRequest {
 	groups := map[string]pushRequestGroup{}
 
 	rls := ld.ResourceLogs()
@@ -91,15 +116,30 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 					groups[tenant] = group
 				}
 
-				format := getFormatFromFormatHint(log.Attributes(), resource.Attributes())
+				format := opts.Format
+				if format == "" {
+					format = getFormatFromFormatHint(log.Attributes(), resource.Attributes())
+				}
 
 				mergedLabels := convertAttributesAndMerge(log.Attributes(), resource.Attributes())
+				streamLabels := mergedLabels
+				if len(opts.Labels) > 0 {
+					streamLabels = streamLabels.Merge(renderLabels(opts.Labels, log, resource))
+				}
+				if opts.StreamLabels != nil {
+					streamLabels = opts.StreamLabels(tenant, streamLabels)
+				}
+
 				// remove the attributes that were promoted to labels
-				removeAttributes(log.Attributes(), mergedLabels)
-				removeAttributes(resource.Attributes(), mergedLabels)
+				promotedLabels := mergedLabels
+				if len(opts.Labels) > 0 || opts.StreamLabels != nil {
+					promotedLabels = keptLabels(mergedLabels, streamLabels)
+				}
+				removeAttributes(log.Attributes(), promotedLabels)
+				removeAttributes(resource.Attributes(), promotedLabels)
 
 				// create the stream name based on the labels
-				labels := mergedLabels.String()
+				labels := streamLabels.String()
 				entry, err := convertLogToLokiEntry(log, resource, format, scope)
 				if err != nil {
 					// Couldn't convert so dropping log.
@@ -142,6 +182,27 @@ func LogsToLokiRequests(ld plog.Logs) map[string]PushRequest {
 	return requests
 }
 
+func renderLabels(templates map[model.LabelName]*LabelTemplate, lr plog.LogRecord, res pcommon.Resource) model.LabelSet {
+	out := make(model.LabelSet, len(templates))
+	for name, template := range templates {
+		if value := template.Render(lr, res); value != "" {
+			out[name] = model.LabelValue(value)
+		}
+	}
+	return out
+}
+
+// keptLabels returns the labels that are part of the stream labels with the same value.
+func keptLabels(labels model.LabelSet, streamLabels model.LabelSet) model.LabelSet {
+	out := make(model.LabelSet, len(labels))
+	for name, value := range labels {
+		if streamLabels[name] == value {
+			out[name] = value
+		}
+	}
+	return out
+}
+
 func getFormatFromFormatHint(logAttr pcommon.Map, resourceAttr pcommon.Map) string {
 	format := formatJSON
 	formatVal, found := resourceAttr.Get(hintFormat)
@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/plog"
@@ -381,6 +382,88 @@ func TestLogsToLokiRequestWithoutTenant(t *testing.T) {
 	}
 }
 
+func TestLogsToLokiRequestWithOptions(t *testing.T) {
+	namespaceTemplate, err := ParseLabelTemplate(`{{ resource["k8s.namespace.name"] }}`)
+	assert.NoError(t, err)
+	appTemplate, err := ParseLabelTemplate(`app-{{ attributes["app"] }}`)
+	assert.NoError(t, err)
+
+	testCases := []struct {
+		desc          string
+		opts          Options
+		expectedLabel string
+		expectedLine  string
+	}{
+		{
+			desc: "templated labels",
+			opts: Options{
+				Labels: map[model.LabelName]*LabelTemplate{
+					"namespace": namespaceTemplate,
+					"app":       appTemplate,
+				},
+			},
+			expectedLabel: `{app="app-checkout", exporter="OTLP", host_name="guarana", namespace="shop"}`,
+			expectedLine:  `{"body":"hello","attributes":{"app":"checkout"},"resources":{"k8s.namespace.name":"shop"}}`,
+		},
+		{
+			desc: "templated label overriding a hint label",
+			opts: Options{
+				Labels: map[model.LabelName]*LabelTemplate{
+					"host_name": namespaceTemplate,
+				},
+			},
+			expectedLabel: `{exporter="OTLP", host_name="shop"}`,
+			// the attribute promoted by the hint isn't a label anymore, it is kept in the line
+			expectedLine: `{"body":"hello","attributes":{"app":"checkout","host_name":"guarana"},"resources":{"k8s.namespace.name":"shop"}}`,
+		},
+		{
+			desc:          "raw format",
+			opts:          Options{Format: formatRaw},
+			expectedLabel: `{exporter="OTLP", host_name="guarana"}`,
+			expectedLine:  `hello`,
+		},
+		{
+			desc:          "logfmt format",
+			opts:          Options{Format: formatLogfmt},
+			expectedLabel: `{exporter="OTLP", host_name="guarana"}`,
+			expectedLine:  `hello= attribute_app=checkout resource_k8s.namespace.name=shop`,
+		},
+		{
+			desc: "stream labels replaced",
+			opts: Options{
+				StreamLabels: func(tenant string, labels model.LabelSet) model.LabelSet {
+					assert.Equal(t, "", tenant)
+					return model.LabelSet{"exporter": "OTLP", "overflow": "true"}
+				},
+			},
+			expectedLabel: `{exporter="OTLP", overflow="true"}`,
+			expectedLine:  `{"body":"hello","attributes":{"app":"checkout","host_name":"guarana"},"resources":{"k8s.namespace.name":"shop"}}`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.desc, func(t *testing.T) {
+			ld := plog.NewLogs()
+			rl := ld.ResourceLogs().AppendEmpty()
+			rl.Resource().Attributes().PutStr("k8s.namespace.name", "shop")
+			lr := rl.ScopeLogs().AppendEmpty().LogRecords().AppendEmpty()
+			lr.Body().SetStr("hello")
+			lr.Attributes().PutStr("app", "checkout")
+			lr.Attributes().PutStr("host_name", "guarana")
+			lr.Attributes().PutStr(hintAttributes, "host_name")
+
+			requests := LogsToLokiRequestsWithOptions(ld, tt.opts)
+			assert.Len(t, requests, 1)
+			request := requests[""]
+
+			assert.Empty(t, request.Report.Errors)
+			assert.Len(t, request.Streams, 1)
+			assert.Equal(t, tt.expectedLabel, request.Streams[0].Labels)
+			assert.Len(t, request.Streams[0].Entries, 1)
+			assert.Equal(t, tt.expectedLine, request.Streams[0].Entries[0].Line)
+		})
+	}
+}
+
 func TestLogsToLoki(t *testing.T) {
 	testCases := []struct {
 		desc                 string
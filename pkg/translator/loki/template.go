@@ -0,0 +1,118 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+const (
+	templateSourceResource   = "resource"
+	templateSourceAttributes = "attributes"
+	templateSourceSeverity   = "severity"
+)
+
+var templateAttributeRE = regexp.MustCompile(`^(resource|attributes)\[\s*(?:"([^"]*)"|'([^']*)')\s*\]$`)
+
+// LabelTemplate renders the value of a stream label from a log record and its resource.
+// A template is made of text and of expressions between double braces, which can be
+// `resource["<name>"]` for a resource attribute, `attributes["<name>"]` for a log record
+// attribute and `severity` for the severity text of the log record, e.g.
+// `{{ resource["k8s.namespace.name"] }}/{{ attributes["app"] }}`. Missing attributes are
+// rendered as empty strings.
+type LabelTemplate struct {
+	parts []templatePart
+}
+
+type templatePart struct {
+	// text is rendered as is when source is empty
+	text   string
+	source string
+	key    string
+}
+
+// ParseLabelTemplate parses a label template.
+func ParseLabelTemplate(text string) (*LabelTemplate, error) {
+	t := &LabelTemplate{}
+	rest := text
+	for len(rest) > 0 {
+		before, after, found := strings.Cut(rest, "{{")
+		if before != "" {
+			t.parts = append(t.parts, templatePart{text: before})
+		}
+		if !found {
+			break
+		}
+
+		expr, remaining, closed := strings.Cut(after, "}}")
+		if !closed {
+			return nil, fmt.Errorf("unclosed expression in template %q", text)
+		}
+		part, err := parseTemplateExpression(strings.TrimSpace(expr))
+		if err != nil {
+			return nil, fmt.Errorf("invalid template %q: %w", text, err)
+		}
+		t.parts = append(t.parts, part)
+		rest = remaining
+	}
+	return t, nil
+}
+
+func parseTemplateExpression(expr string) (templatePart, error) {
+	if expr == templateSourceSeverity {
+		return templatePart{source: templateSourceSeverity}, nil
+	}
+
+	matches := templateAttributeRE.FindStringSubmatch(expr)
+	if matches == nil {
+		return templatePart{}, fmt.Errorf("unsupported expression %q, expected resource[\"<name>\"], attributes[\"<name>\"] or severity", expr)
+	}
+	return templatePart{source: matches[1], key: matches[2] + matches[3]}, nil
+}
+
+// Render renders the template for the given log record and resource.
+func (t *LabelTemplate) Render(lr plog.LogRecord, res pcommon.Resource) string {
+	var b strings.Builder
+	for _, part := range t.parts {
+		switch part.source {
+		case "":
+			b.WriteString(part.text)
+		case templateSourceSeverity:
+			b.WriteString(lr.SeverityText())
+		case templateSourceResource:
+			b.WriteString(lookupAttribute(res.Attributes(), part.key))
+		case templateSourceAttributes:
+			b.WriteString(lookupAttribute(lr.Attributes(), part.key))
+		}
+	}
+	return b.String()
+}
+
+func lookupAttribute(attributes pcommon.Map, name string) string {
+	av, ok := attributes.Get(name)
+	if !ok {
+		// perhaps it's a nested attribute?
+		av, ok = getNestedAttribute(name, attributes)
+	}
+	if !ok {
+		return ""
+	}
+	return av.AsString()
+}
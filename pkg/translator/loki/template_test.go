@@ -0,0 +1,87 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package loki // import "github.com/open-telemetry/opentelemetry-collector-contrib/pkg/translator/loki"
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+)
+
+func TestLabelTemplate(t *testing.T) {
+	lr := plog.NewLogRecord()
+	lr.SetSeverityText("ERROR")
+	lr.Attributes().PutStr("app", "checkout")
+	lr.Attributes().PutEmptyMap("http").PutInt("status", 500)
+	res := pcommon.NewResource()
+	res.Attributes().PutStr("k8s.namespace.name", "shop")
+
+	testCases := []struct {
+		desc     string
+		template string
+		expected string
+		err      string
+	}{
+		{
+			desc:     "resource attribute",
+			template: `{{ resource["k8s.namespace.name"] }}`,
+			expected: "shop",
+		},
+		{
+			desc:     "text and expressions",
+			template: `{{resource['k8s.namespace.name']}}/{{ attributes["app"] }}-{{ severity }}`,
+			expected: "shop/checkout-ERROR",
+		},
+		{
+			desc:     "nested attribute",
+			template: `{{ attributes["http.status"] }}`,
+			expected: "500",
+		},
+		{
+			desc:     "missing attribute",
+			template: `{{ attributes["missing"] }}`,
+			expected: "",
+		},
+		{
+			desc:     "text only",
+			template: "static",
+			expected: "static",
+		},
+		{
+			desc:     "unclosed expression",
+			template: `{{ resource["k8s.namespace.name"]`,
+			err:      `unclosed expression in template "{{ resource[\"k8s.namespace.name\"]"`,
+		},
+		{
+			desc:     "unsupported expression",
+			template: `{{ body }}`,
+			err:      `invalid template "{{ body }}": unsupported expression "body", expected resource["<name>"], attributes["<name>"] or severity`,
+		},
+	}
+	for _, tt := range testCases {
+		t.Run(tt.desc, func(t *testing.T) {
+			template, err := ParseLabelTemplate(tt.template)
+			if tt.err != "" {
+				assert.EqualError(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, template.Render(lr, res))
+		})
+	}
+}
@@ -41,10 +41,11 @@ type State struct {
 }
 
 type DeltaValue struct {
-	StartTimestamp pcommon.Timestamp
-	FloatValue     float64
-	IntValue       int64
-	HistogramValue *HistogramPoint
+	StartTimestamp    pcommon.Timestamp
+	FloatValue        float64
+	IntValue          int64
+	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExponentialHistogramPoint
 }
 
 func NewMetricTracker(ctx context.Context, logger *zap.Logger, maxStaleness time.Duration) *MetricTracker {
@@ -92,10 +93,11 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 	if !ok {
 		if metricID.MetricIsMonotonic {
 			out = DeltaValue{
-				StartTimestamp: metricPoint.ObservedTimestamp,
-				FloatValue:     metricPoint.FloatValue,
-				IntValue:       metricPoint.IntValue,
-				HistogramValue: metricPoint.HistogramValue,
+				StartTimestamp:    metricPoint.ObservedTimestamp,
+				FloatValue:        metricPoint.FloatValue,
+				IntValue:          metricPoint.IntValue,
+				HistogramValue:    metricPoint.HistogramValue,
+				ExpHistogramValue: metricPoint.ExpHistogramValue,
 			}
 			valid = true
 		}
@@ -133,6 +135,35 @@ func (t *MetricTracker) Convert(in MetricPoint) (out DeltaValue, valid bool) {
 		}
 
 		out.HistogramValue = &delta
+	case pmetric.MetricTypeExponentialHistogram:
+		value := metricPoint.ExpHistogramValue
+		prevValue := state.PrevPoint.ExpHistogramValue
+		if math.IsNaN(value.Sum) {
+			value.Sum = prevValue.Sum
+		}
+
+		// The scale of a cumulative exponential histogram may be lowered between points to fit more values, both
+		// points are compared at the lowest of their scales.
+		scale := value.Scale
+		if prevValue.Scale < scale {
+			scale = prevValue.Scale
+		}
+		current := value.Downscale(scale)
+		prev := prevValue.Downscale(scale)
+		delta := current.Clone()
+
+		// Calculate deltas unless the histogram was reset
+		positive, positiveValid := current.Positive.subtract(prev.Positive)
+		negative, negativeValid := current.Negative.subtract(prev.Negative)
+		if positiveValid && negativeValid && current.Count >= prev.Count && current.ZeroCount >= prev.ZeroCount {
+			delta.Count -= prev.Count
+			delta.Sum -= prev.Sum
+			delta.ZeroCount -= prev.ZeroCount
+			delta.Positive = positive
+			delta.Negative = negative
+		}
+
+		out.ExpHistogramValue = &delta
 	case pmetric.MetricTypeSum:
 		if metricID.IsFloatVal() {
 			value := metricPoint.FloatValue
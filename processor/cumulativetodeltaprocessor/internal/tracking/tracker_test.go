@@ -151,6 +151,162 @@ func TestMetricTracker_Convert(t *testing.T) {
 	})
 }
 
+func TestMetricTracker_ConvertExponentialHistogram(t *testing.T) {
+	mi := MetricIdentity{
+		Resource:               pcommon.NewResource(),
+		InstrumentationLibrary: pcommon.NewInstrumentationScope(),
+		MetricType:             pmetric.MetricTypeExponentialHistogram,
+		MetricIsMonotonic:      true,
+		MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+		Attributes:             pcommon.NewMap(),
+	}
+
+	m := NewMetricTracker(context.Background(), zap.NewNop(), 0)
+
+	tests := []struct {
+		name    string
+		value   ValuePoint
+		wantOut DeltaValue
+	}{
+		{
+			name: "Initial Value recorded",
+			value: ValuePoint{
+				ObservedTimestamp: 10,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:     1,
+					Count:     11,
+					Sum:       10,
+					ZeroCount: 1,
+					Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 2, 3, 4}},
+				},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 10,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:     1,
+					Count:     11,
+					Sum:       10,
+					ZeroCount: 1,
+					Positive:  ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 2, 3, 4}},
+				},
+			},
+		},
+		{
+			name: "Offset shifted",
+			value: ValuePoint{
+				ObservedTimestamp: 20,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:     1,
+					Count:     17,
+					Sum:       20,
+					ZeroCount: 2,
+					Positive:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{1, 2, 3, 4, 5}},
+				},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 10,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:     1,
+					Count:     6,
+					Sum:       10,
+					ZeroCount: 1,
+					Positive:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{1, 1, 1, 1, 1}},
+					Negative:  ExponentialBuckets{BucketCounts: []uint64{}},
+				},
+			},
+		},
+		{
+			name: "Scale lowered",
+			value: ValuePoint{
+				ObservedTimestamp: 30,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:     0,
+					Count:     21,
+					Sum:       30,
+					ZeroCount: 2,
+					Positive:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{2, 6, 10, 1}},
+				},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 20,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:     0,
+					Count:     4,
+					Sum:       10,
+					ZeroCount: 0,
+					Positive:  ExponentialBuckets{Offset: -1, BucketCounts: []uint64{1, 1, 1, 1}},
+					Negative:  ExponentialBuckets{BucketCounts: []uint64{}},
+				},
+			},
+		},
+		{
+			name: "Reset",
+			value: ValuePoint{
+				ObservedTimestamp: 40,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:    0,
+					Count:    3,
+					Sum:      1,
+					Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{3}},
+				},
+			},
+			wantOut: DeltaValue{
+				StartTimestamp: 30,
+				ExpHistogramValue: &ExponentialHistogramPoint{
+					Scale:    0,
+					Count:    3,
+					Sum:      1,
+					Positive: ExponentialBuckets{Offset: 0, BucketCounts: []uint64{3}},
+					Negative: ExponentialBuckets{BucketCounts: []uint64{}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotOut, valid := m.Convert(MetricPoint{Identity: mi, Value: tt.value})
+			if !valid || !reflect.DeepEqual(gotOut, tt.wantOut) {
+				t.Errorf("MetricTracker.Convert(MetricTypeExponentialHistogram) = %v, want %v", gotOut, tt.wantOut)
+			}
+		})
+	}
+}
+
+func TestExponentialBuckets_downscale(t *testing.T) {
+	tests := []struct {
+		name    string
+		buckets ExponentialBuckets
+		by      int32
+		want    ExponentialBuckets
+	}{
+		{
+			name:    "positive offset",
+			buckets: ExponentialBuckets{Offset: 1, BucketCounts: []uint64{1, 2, 3, 4}},
+			by:      1,
+			want:    ExponentialBuckets{Offset: 0, BucketCounts: []uint64{1, 5, 4}},
+		},
+		{
+			name:    "negative offset",
+			buckets: ExponentialBuckets{Offset: -3, BucketCounts: []uint64{1, 2, 3, 4}},
+			by:      2,
+			want:    ExponentialBuckets{Offset: -1, BucketCounts: []uint64{6, 4}},
+		},
+		{
+			name:    "empty",
+			buckets: ExponentialBuckets{Offset: -3},
+			by:      1,
+			want:    ExponentialBuckets{Offset: -2},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.buckets.downscale(tt.by); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExponentialBuckets.downscale() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_metricTracker_removeStale(t *testing.T) {
 	currentTime := pcommon.Timestamp(100)
 	freshPoint := ValuePoint{
@@ -21,6 +21,7 @@ type ValuePoint struct {
 	FloatValue        float64
 	IntValue          int64
 	HistogramValue    *HistogramPoint
+	ExpHistogramValue *ExponentialHistogramPoint
 }
 
 type HistogramPoint struct {
@@ -39,3 +40,86 @@ func (point *HistogramPoint) Clone() HistogramPoint {
 		Buckets: bucketValues,
 	}
 }
+
+type ExponentialHistogramPoint struct {
+	Scale     int32
+	Count     uint64
+	Sum       float64
+	ZeroCount uint64
+	Positive  ExponentialBuckets
+	Negative  ExponentialBuckets
+}
+
+// ExponentialBuckets are the bucket counts of one range of an exponential histogram, the first count being the one
+// of the bucket at index Offset.
+type ExponentialBuckets struct {
+	Offset       int32
+	BucketCounts []uint64
+}
+
+func (point *ExponentialHistogramPoint) Clone() ExponentialHistogramPoint {
+	return ExponentialHistogramPoint{
+		Scale:     point.Scale,
+		Count:     point.Count,
+		Sum:       point.Sum,
+		ZeroCount: point.ZeroCount,
+		Positive:  point.Positive.Clone(),
+		Negative:  point.Negative.Clone(),
+	}
+}
+
+// Downscale returns a copy of the point with its buckets merged to the given scale, which must not be greater than
+// the scale of the point.
+func (point *ExponentialHistogramPoint) Downscale(scale int32) ExponentialHistogramPoint {
+	out := point.Clone()
+	if scale < point.Scale {
+		out.Positive = point.Positive.downscale(point.Scale - scale)
+		out.Negative = point.Negative.downscale(point.Scale - scale)
+		out.Scale = scale
+	}
+	return out
+}
+
+func (buckets ExponentialBuckets) Clone() ExponentialBuckets {
+	bucketCounts := make([]uint64, len(buckets.BucketCounts))
+	copy(bucketCounts, buckets.BucketCounts)
+	return ExponentialBuckets{
+		Offset:       buckets.Offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// downscale merges the buckets of a scale into the buckets of the scale lower by the given amount. Each decrease of
+// the scale merges pairs of adjacent buckets, so the bucket at index i goes to the bucket at index i >> by.
+func (buckets ExponentialBuckets) downscale(by int32) ExponentialBuckets {
+	if len(buckets.BucketCounts) == 0 {
+		return ExponentialBuckets{Offset: buckets.Offset >> by}
+	}
+	offset := buckets.Offset >> by
+	last := (buckets.Offset + int32(len(buckets.BucketCounts)) - 1) >> by
+	bucketCounts := make([]uint64, last-offset+1)
+	for i, count := range buckets.BucketCounts {
+		bucketCounts[((buckets.Offset+int32(i))>>by)-offset] += count
+	}
+	return ExponentialBuckets{
+		Offset:       offset,
+		BucketCounts: bucketCounts,
+	}
+}
+
+// subtract returns the bucket counts of buckets minus the ones of prev, using the offset of buckets. Both must have
+// the same scale. It returns false if a bucket of prev has a higher count, which means the histogram was reset.
+func (buckets ExponentialBuckets) subtract(prev ExponentialBuckets) (ExponentialBuckets, bool) {
+	delta := buckets.Clone()
+	for i, prevCount := range prev.BucketCounts {
+		if prevCount == 0 {
+			continue
+		}
+		index := prev.Offset + int32(i) - buckets.Offset
+		if index < 0 || int(index) >= len(delta.BucketCounts) || delta.BucketCounts[index] < prevCount {
+			return buckets, false
+		}
+		delta.BucketCounts[index] -= prevCount
+	}
+	return delta, true
+}
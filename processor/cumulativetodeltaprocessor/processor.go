@@ -121,6 +121,34 @@ func (ctdp *cumulativeToDeltaProcessor) processMetrics(_ context.Context, md pme
 
 					ctdp.convertHistogramDataPoints(ms.DataPoints(), baseIdentity)
 
+					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
+					return ms.DataPoints().Len() == 0
+				case pmetric.MetricTypeExponentialHistogram:
+					if !ctdp.histogramSupportEnabled {
+						return false
+					}
+
+					ms := m.ExponentialHistogram()
+					if ms.AggregationTemporality() != pmetric.AggregationTemporalityCumulative {
+						return false
+					}
+
+					if ms.DataPoints().Len() == 0 {
+						return false
+					}
+
+					baseIdentity := tracking.MetricIdentity{
+						Resource:               rm.Resource(),
+						InstrumentationLibrary: ilm.Scope(),
+						MetricType:             m.Type(),
+						MetricName:             m.Name(),
+						MetricUnit:             m.Unit(),
+						MetricIsMonotonic:      true,
+						MetricValueType:        pmetric.NumberDataPointValueTypeInt,
+					}
+
+					ctdp.convertExponentialHistogramDataPoints(ms.DataPoints(), baseIdentity)
+
 					ms.SetAggregationTemporality(pmetric.AggregationTemporalityDelta)
 					return ms.DataPoints().Len() == 0
 				default:
@@ -226,3 +254,57 @@ func (ctdp *cumulativeToDeltaProcessor) convertHistogramDataPoints(in interface{
 		})
 	}
 }
+
+func (ctdp *cumulativeToDeltaProcessor) convertExponentialHistogramDataPoints(in interface{}, baseIdentity tracking.MetricIdentity) {
+
+	if dps, ok := in.(pmetric.ExponentialHistogramDataPointSlice); ok {
+		dps.RemoveIf(func(dp pmetric.ExponentialHistogramDataPoint) bool {
+			id := baseIdentity
+			id.StartTimestamp = dp.StartTimestamp()
+			id.Attributes = dp.Attributes()
+
+			point := tracking.ValuePoint{
+				ObservedTimestamp: dp.Timestamp(),
+				ExpHistogramValue: &tracking.ExponentialHistogramPoint{
+					Scale:     dp.Scale(),
+					Count:     dp.Count(),
+					Sum:       dp.Sum(),
+					ZeroCount: dp.ZeroCount(),
+					Positive: tracking.ExponentialBuckets{
+						Offset:       dp.Positive().Offset(),
+						BucketCounts: dp.Positive().BucketCounts().AsRaw(),
+					},
+					Negative: tracking.ExponentialBuckets{
+						Offset:       dp.Negative().Offset(),
+						BucketCounts: dp.Negative().BucketCounts().AsRaw(),
+					},
+				},
+			}
+
+			trackingPoint := tracking.MetricPoint{
+				Identity: id,
+				Value:    point,
+			}
+			delta, valid := ctdp.deltaCalculator.Convert(trackingPoint)
+
+			if valid {
+				dp.SetStartTimestamp(delta.StartTimestamp)
+				dp.SetScale(delta.ExpHistogramValue.Scale)
+				dp.SetCount(delta.ExpHistogramValue.Count)
+				if dp.HasSum() && !math.IsNaN(dp.Sum()) {
+					dp.SetSum(delta.ExpHistogramValue.Sum)
+				}
+				dp.SetZeroCount(delta.ExpHistogramValue.ZeroCount)
+				dp.Positive().SetOffset(delta.ExpHistogramValue.Positive.Offset)
+				dp.Positive().BucketCounts().FromRaw(delta.ExpHistogramValue.Positive.BucketCounts)
+				dp.Negative().SetOffset(delta.ExpHistogramValue.Negative.Offset)
+				dp.Negative().BucketCounts().FromRaw(delta.ExpHistogramValue.Negative.BucketCounts)
+				dp.RemoveMin()
+				dp.RemoveMax()
+				return false
+			}
+
+			return !valid
+		})
+	}
+}
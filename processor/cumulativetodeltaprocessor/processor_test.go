@@ -425,6 +425,71 @@ func TestCumulativeToDeltaProcessor(t *testing.T) {
 	}
 }
 
+func TestCumulativeToDeltaProcessorExponentialHistogram(t *testing.T) {
+	next := new(consumertest.MetricsSink)
+	factory := NewFactory()
+	mgp, err := factory.CreateMetricsProcessor(
+		context.Background(),
+		processortest.NewNopCreateSettings(),
+		factory.CreateDefaultConfig(),
+		next,
+	)
+	require.NoError(t, err)
+	ctx := context.Background()
+	require.NoError(t, mgp.Start(ctx, nil))
+
+	in := pmetric.NewMetrics()
+	m := in.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("metric_1")
+	m.SetEmptyExponentialHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	now := time.Now()
+	for i, scale := range []int32{2, 1} {
+		dp := m.ExponentialHistogram().DataPoints().AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(now.Add(time.Duration(i) * time.Second)))
+		dp.SetScale(scale)
+		dp.SetMin(1)
+		dp.SetMax(10)
+		if scale == 2 {
+			dp.SetCount(7)
+			dp.SetSum(20)
+			dp.SetZeroCount(1)
+			dp.Positive().SetOffset(3)
+			dp.Positive().BucketCounts().FromRaw([]uint64{1, 2, 3})
+		} else {
+			dp.SetCount(12)
+			dp.SetSum(35)
+			dp.SetZeroCount(1)
+			dp.Positive().SetOffset(1)
+			dp.Positive().BucketCounts().FromRaw([]uint64{4, 6, 1})
+		}
+	}
+
+	require.NoError(t, mgp.ConsumeMetrics(ctx, in))
+	got := next.AllMetrics()
+	require.Len(t, got, 1)
+	out := got[0].ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).ExponentialHistogram()
+	assert.Equal(t, pmetric.AggregationTemporalityDelta, out.AggregationTemporality())
+	require.Equal(t, 2, out.DataPoints().Len())
+
+	first := out.DataPoints().At(0)
+	assert.Equal(t, uint64(7), first.Count())
+	assert.Equal(t, []uint64{1, 2, 3}, first.Positive().BucketCounts().AsRaw())
+	assert.False(t, first.HasMin())
+	assert.False(t, first.HasMax())
+
+	// The first point downscaled to scale 1 has the buckets [1, 5] at offset 1.
+	second := out.DataPoints().At(1)
+	assert.Equal(t, int32(1), second.Scale())
+	assert.Equal(t, uint64(5), second.Count())
+	assert.Equal(t, 15.0, second.Sum())
+	assert.Equal(t, uint64(0), second.ZeroCount())
+	assert.Equal(t, int32(1), second.Positive().Offset())
+	assert.Equal(t, []uint64{3, 1, 1}, second.Positive().BucketCounts().AsRaw())
+	assert.Equal(t, first.Timestamp(), second.StartTimestamp())
+
+	require.NoError(t, mgp.Shutdown(ctx))
+}
+
 func generateTestSumMetrics(tm testSumMetric) pmetric.Metrics {
 	md := pmetric.NewMetrics()
 	now := time.Now()
@@ -0,0 +1,67 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegeradaptivesamplingprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/jaegeradaptivesamplingprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/extension/jaegerremotesampling"
+)
+
+type adaptiveSamplingProcessor struct {
+	logger   *zap.Logger
+	cfg      *Config
+	observer jaegerremotesampling.TracesObserver
+}
+
+func newAdaptiveSamplingProcessor(logger *zap.Logger, cfg *Config) *adaptiveSamplingProcessor {
+	return &adaptiveSamplingProcessor{
+		logger: logger,
+		cfg:    cfg,
+	}
+}
+
+func (p *adaptiveSamplingProcessor) start(ctx context.Context, host component.Host) error {
+	ext, ok := host.GetExtensions()[p.cfg.Extension]
+	if !ok {
+		return fmt.Errorf("extension %q not found", p.cfg.Extension)
+	}
+
+	observer, ok := ext.(jaegerremotesampling.TracesObserver)
+	if !ok {
+		return fmt.Errorf("extension %q is not a jaegerremotesampling extension", p.cfg.Extension)
+	}
+
+	// observing no traces checks that the extension is configured with the adaptive source
+	if err := observer.ObserveTraces(ctx, ptrace.NewTraces()); err != nil {
+		return fmt.Errorf("extension %q can't observe traces: %w", p.cfg.Extension, err)
+	}
+
+	p.observer = observer
+	return nil
+}
+
+// processTraces records the throughput of the traces and passes them on unchanged.
+func (p *adaptiveSamplingProcessor) processTraces(ctx context.Context, td ptrace.Traces) (ptrace.Traces, error) {
+	if err := p.observer.ObserveTraces(ctx, td); err != nil {
+		p.logger.Debug("failed to observe traces", zap.Error(err))
+	}
+	return td, nil
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package jaegeradaptivesamplingprocessor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+func TestProcessorObservesTraces(t *testing.T) {
+	// prepare
+	observer := &mockObserver{}
+	sink := &consumertest.TracesSink{}
+	cfg := createDefaultConfig().(*Config)
+	tp, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), newHost(cfg.Extension, observer)))
+
+	td := ptrace.NewTraces()
+	td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty().SetName("GET /")
+
+	// test
+	require.NoError(t, tp.ConsumeTraces(context.Background(), td))
+
+	// verify
+	require.Len(t, observer.observed, 2)
+	assert.Equal(t, 0, observer.observed[0].SpanCount(), "the start check observes no traces")
+	assert.Equal(t, td, observer.observed[1])
+	require.Len(t, sink.AllTraces(), 1)
+	assert.Equal(t, td, sink.AllTraces()[0])
+	assert.NoError(t, tp.Shutdown(context.Background()))
+}
+
+func TestProcessorObserverFailureDoesNotDropData(t *testing.T) {
+	// prepare
+	observer := &mockObserver{}
+	sink := &consumertest.TracesSink{}
+	cfg := createDefaultConfig().(*Config)
+	tp, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, tp.Start(context.Background(), newHost(cfg.Extension, observer)))
+	observer.err = errors.New("not adaptive")
+
+	// test
+	require.NoError(t, tp.ConsumeTraces(context.Background(), ptrace.NewTraces()))
+
+	// verify
+	assert.Len(t, sink.AllTraces(), 1)
+}
+
+func TestProcessorStartErrors(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+
+	testCases := []struct {
+		desc     string
+		host     component.Host
+		expected string
+	}{
+		{
+			desc:     "extension not found",
+			host:     componenttest.NewNopHost(),
+			expected: `extension "jaegerremotesampling" not found`,
+		},
+		{
+			desc:     "not a jaegerremotesampling extension",
+			host:     newHost(cfg.Extension, &nopExtension{}),
+			expected: `extension "jaegerremotesampling" is not a jaegerremotesampling extension`,
+		},
+		{
+			desc:     "extension without adaptive source",
+			host:     newHost(cfg.Extension, &mockObserver{err: errors.New("not adaptive")}),
+			expected: `extension "jaegerremotesampling" can't observe traces: not adaptive`,
+		},
+	}
+	for _, tC := range testCases {
+		t.Run(tC.desc, func(t *testing.T) {
+			tp, err := createTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+			require.NoError(t, err)
+			assert.EqualError(t, tp.Start(context.Background(), tC.host), tC.expected)
+		})
+	}
+}
+
+type nopExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+}
+
+type mockObserver struct {
+	component.StartFunc
+	component.ShutdownFunc
+	observed []ptrace.Traces
+	err      error
+}
+
+func (m *mockObserver) ObserveTraces(_ context.Context, td ptrace.Traces) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.observed = append(m.observed, td)
+	return nil
+}
+
+type mockHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func newHost(id component.ID, ext component.Component) component.Host {
+	return &mockHost{
+		Host:       componenttest.NewNopHost(),
+		extensions: map[component.ID]component.Component{id: ext},
+	}
+}
+
+func (h *mockHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
@@ -0,0 +1,385 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+)
+
+// seriesAggregator aggregates the metrics matched by `aggregate` transforms across batches. It keeps the state of
+// every output series, ie.: the series left after removing the attributes not in the label set, and of the input
+// series contributing to them so cumulative values can be turned into increments and resets can be detected. Only the
+// data point attributes are reduced to the label set, the output series of different resources are kept apart.
+type seriesAggregator struct {
+	maxStaleness time.Duration
+
+	mu     sync.Mutex
+	series map[string]*aggregatedSeries
+}
+
+// aggregatedSeries is the state of an output series.
+type aggregatedSeries struct {
+	resource        pcommon.Resource
+	scope           pcommon.InstrumentationScope
+	descriptor      pmetric.Metric
+	attributes      pcommon.Map
+	aggregationType AggregationType
+	isInt           bool
+
+	startTime pcommon.Timestamp
+	updated   bool
+	lastSeen  time.Time
+
+	// value is the running total of sums.
+	value float64
+	// count, sum, bounds and bucketCounts are the running totals of histograms.
+	count        uint64
+	sum          float64
+	bounds       []float64
+	bucketCounts []uint64
+
+	inputs map[string]*inputSeries
+}
+
+// inputSeries is the last point received from a series contributing to an output series.
+type inputSeries struct {
+	startTime    pcommon.Timestamp
+	timestamp    pcommon.Timestamp
+	value        float64
+	count        uint64
+	sum          float64
+	bucketCounts []uint64
+	lastSeen     time.Time
+}
+
+func newSeriesAggregator(maxStaleness time.Duration) *seriesAggregator {
+	return &seriesAggregator{
+		maxStaleness: maxStaleness,
+		series:       map[string]*aggregatedSeries{},
+	}
+}
+
+// add records the data points of metric, which was named sourceName before being transformed, keeping only the
+// attributes in labelSet. It returns false if the metric type can't be aggregated, in which case the metric is
+// left untouched.
+func (a *seriesAggregator) add(resource pcommon.Resource, scope pcommon.InstrumentationScope, sourceName string,
+	metric pmetric.Metric, labelSet map[string]bool, aggType AggregationType, now time.Time) bool {
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge, pmetric.MetricTypeSum, pmetric.MetricTypeHistogram:
+	default:
+		return false
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	scopeKey := hashKey(resource.Attributes().AsRaw(), scope.Name(), scope.Version())
+	switch metric.Type() {
+	case pmetric.MetricTypeGauge:
+		dps := metric.Gauge().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s, in, isNew := a.lookup(scopeKey, resource, scope, sourceName, metric, dps.At(i).Attributes(), labelSet, aggType)
+			a.addNumberDataPoint(s, in, isNew, metric, dps.At(i), now)
+		}
+	case pmetric.MetricTypeSum:
+		dps := metric.Sum().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			s, in, isNew := a.lookup(scopeKey, resource, scope, sourceName, metric, dps.At(i).Attributes(), labelSet, aggType)
+			a.addNumberDataPoint(s, in, isNew, metric, dps.At(i), now)
+		}
+	case pmetric.MetricTypeHistogram:
+		dps := metric.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			s, in, isNew := a.lookup(scopeKey, resource, scope, sourceName, metric, dp.Attributes(), labelSet, aggType,
+				dp.ExplicitBounds().AsRaw())
+			a.addHistogramDataPoint(s, in, isNew, metric, dp, now)
+		}
+	}
+	return true
+}
+
+// lookup returns the output series and the input series of a data point, creating them if needed.
+func (a *seriesAggregator) lookup(scopeKey string, resource pcommon.Resource, scope pcommon.InstrumentationScope,
+	sourceName string, metric pmetric.Metric, attrs pcommon.Map, labelSet map[string]bool, aggType AggregationType,
+	other ...interface{}) (*aggregatedSeries, *inputSeries, bool) {
+	outAttrs := pcommon.NewMap()
+	attrs.CopyTo(outAttrs)
+	outAttrs.RemoveIf(func(k string, _ pcommon.Value) bool {
+		return !labelSet[k]
+	})
+
+	key := hashKey(append([]interface{}{scopeKey, metric.Name(), metric.Type().String(), outAttrs.AsRaw()}, other...)...)
+	s, ok := a.series[key]
+	if !ok {
+		s = &aggregatedSeries{
+			resource:        pcommon.NewResource(),
+			scope:           pcommon.NewInstrumentationScope(),
+			descriptor:      pmetric.NewMetric(),
+			attributes:      outAttrs,
+			aggregationType: aggType,
+			inputs:          map[string]*inputSeries{},
+		}
+		resource.CopyTo(s.resource)
+		scope.CopyTo(s.scope)
+		copyMetricDetails(metric, s.descriptor)
+		s.descriptor.SetDescription(metric.Description())
+		a.series[key] = s
+	}
+
+	inputKey := hashKey(sourceName, attrs.AsRaw())
+	in, ok := s.inputs[inputKey]
+	if !ok {
+		in = &inputSeries{}
+		s.inputs[inputKey] = in
+	}
+	return s, in, !ok
+}
+
+func (a *seriesAggregator) addNumberDataPoint(s *aggregatedSeries, in *inputSeries, isNew bool, metric pmetric.Metric,
+	dp pmetric.NumberDataPoint, now time.Time) {
+	// Ignore duplicated and out of order points.
+	if !isNew && dp.Timestamp() <= in.timestamp {
+		return
+	}
+	value := doubleVal(dp)
+	if s.startTime == 0 {
+		s.isInt = dp.ValueType() == pmetric.NumberDataPointValueTypeInt
+		s.startTime = startTimeOf(dp.StartTimestamp(), dp.Timestamp())
+	}
+
+	switch {
+	case metric.Type() == pmetric.MetricTypeGauge:
+	case metric.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta:
+		s.value += value
+	default:
+		// A reset restarts the input series from zero, so its whole value is an increment.
+		increment := value
+		if !isNew && !isReset(in.startTime, dp.StartTimestamp()) && !(metric.Sum().IsMonotonic() && value < in.value) {
+			increment = value - in.value
+		}
+		s.value += increment
+	}
+
+	in.value = value
+	in.startTime = dp.StartTimestamp()
+	in.timestamp = dp.Timestamp()
+	in.lastSeen = now
+	s.updated = true
+	s.lastSeen = now
+}
+
+func (a *seriesAggregator) addHistogramDataPoint(s *aggregatedSeries, in *inputSeries, isNew bool, metric pmetric.Metric,
+	dp pmetric.HistogramDataPoint, now time.Time) {
+	if !isNew && dp.Timestamp() <= in.timestamp {
+		return
+	}
+	if s.startTime == 0 {
+		s.startTime = startTimeOf(dp.StartTimestamp(), dp.Timestamp())
+		s.bounds = dp.ExplicitBounds().AsRaw()
+		s.bucketCounts = make([]uint64, dp.BucketCounts().Len())
+	}
+	// The bounds are part of the series key, a mismatch can only come from an invalid data point.
+	if dp.BucketCounts().Len() != len(s.bucketCounts) {
+		return
+	}
+
+	count, sum, bucketCounts := dp.Count(), dp.Sum(), dp.BucketCounts().AsRaw()
+	if metric.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta ||
+		isNew || isReset(in.startTime, dp.StartTimestamp()) || count < in.count || len(in.bucketCounts) != len(bucketCounts) {
+		s.count += count
+		s.sum += sum
+		for i, c := range bucketCounts {
+			s.bucketCounts[i] += c
+		}
+	} else {
+		s.count += count - in.count
+		s.sum += sum - in.sum
+		for i, c := range bucketCounts {
+			// Guard against buckets decreasing without the count decreasing, which would underflow.
+			if c >= in.bucketCounts[i] {
+				s.bucketCounts[i] += c - in.bucketCounts[i]
+			}
+		}
+	}
+
+	in.count = count
+	in.sum = sum
+	in.bucketCounts = bucketCounts
+	in.startTime = dp.StartTimestamp()
+	in.timestamp = dp.Timestamp()
+	in.lastSeen = now
+	s.updated = true
+	s.lastSeen = now
+}
+
+// collect returns the aggregated series at the time now and removes the stale ones. Delta series are reset.
+func (a *seriesAggregator) collect(now time.Time) pmetric.Metrics {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	md := pmetric.NewMetrics()
+	ts := pcommon.NewTimestampFromTime(now)
+
+	keys := make([]string, 0, len(a.series))
+	for key := range a.series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	scopeMetrics := map[string]pmetric.MetricSlice{}
+	metrics := map[string]pmetric.Metric{}
+	for _, key := range keys {
+		s := a.series[key]
+		for inputKey, in := range s.inputs {
+			if now.Sub(in.lastSeen) > a.maxStaleness {
+				delete(s.inputs, inputKey)
+			}
+		}
+		if now.Sub(s.lastSeen) > a.maxStaleness {
+			delete(a.series, key)
+			continue
+		}
+		if !s.hasPoint() {
+			continue
+		}
+
+		scopeKey := hashKey(s.resource.Attributes().AsRaw(), s.scope.Name(), s.scope.Version())
+		ms, ok := scopeMetrics[scopeKey]
+		if !ok {
+			rm := md.ResourceMetrics().AppendEmpty()
+			s.resource.CopyTo(rm.Resource())
+			sm := rm.ScopeMetrics().AppendEmpty()
+			s.scope.CopyTo(sm.Scope())
+			ms = sm.Metrics()
+			scopeMetrics[scopeKey] = ms
+		}
+		metricKey := hashKey(scopeKey, s.descriptor.Name(), s.descriptor.Type().String())
+		metric, ok := metrics[metricKey]
+		if !ok {
+			metric = ms.AppendEmpty()
+			copyMetricDetails(s.descriptor, metric)
+			metric.SetDescription(s.descriptor.Description())
+			metrics[metricKey] = metric
+		}
+		s.appendTo(metric, ts)
+	}
+	return md
+}
+
+// hasPoint returns true if the series has a point to emit.
+func (s *aggregatedSeries) hasPoint() bool {
+	switch s.descriptor.Type() {
+	case pmetric.MetricTypeGauge:
+		return len(s.inputs) > 0
+	case pmetric.MetricTypeSum:
+		return s.updated || s.descriptor.Sum().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+	case pmetric.MetricTypeHistogram:
+		return s.updated || s.descriptor.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityCumulative
+	}
+	return false
+}
+
+// appendTo appends the current point of the series to metric.
+func (s *aggregatedSeries) appendTo(metric pmetric.Metric, ts pcommon.Timestamp) {
+	switch s.descriptor.Type() {
+	case pmetric.MetricTypeGauge:
+		dp := metric.Gauge().DataPoints().AppendEmpty()
+		s.attributes.CopyTo(dp.Attributes())
+		dp.SetTimestamp(ts)
+		s.setValue(dp, s.aggregateInputs())
+	case pmetric.MetricTypeSum:
+		dp := metric.Sum().DataPoints().AppendEmpty()
+		s.attributes.CopyTo(dp.Attributes())
+		dp.SetStartTimestamp(s.startTime)
+		dp.SetTimestamp(ts)
+		s.setValue(dp, s.value)
+		if metric.Sum().AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+			s.value = 0
+			s.startTime = ts
+		}
+	case pmetric.MetricTypeHistogram:
+		dp := metric.Histogram().DataPoints().AppendEmpty()
+		s.attributes.CopyTo(dp.Attributes())
+		dp.SetStartTimestamp(s.startTime)
+		dp.SetTimestamp(ts)
+		dp.SetCount(s.count)
+		dp.SetSum(s.sum)
+		dp.ExplicitBounds().FromRaw(s.bounds)
+		dp.BucketCounts().FromRaw(s.bucketCounts)
+		if metric.Histogram().AggregationTemporality() == pmetric.AggregationTemporalityDelta {
+			s.count = 0
+			s.sum = 0
+			s.bucketCounts = make([]uint64, len(s.bucketCounts))
+			s.startTime = ts
+		}
+	}
+	s.updated = false
+}
+
+// aggregateInputs aggregates the last values of the input series of a gauge.
+func (s *aggregatedSeries) aggregateInputs() float64 {
+	var value float64
+	first := true
+	for _, in := range s.inputs {
+		switch {
+		case first:
+			value = in.value
+		case s.aggregationType == Max:
+			value = math.Max(value, in.value)
+		case s.aggregationType == Min:
+			value = math.Min(value, in.value)
+		default:
+			value += in.value
+		}
+		first = false
+	}
+	if s.aggregationType == Mean {
+		value /= float64(len(s.inputs))
+	}
+	return value
+}
+
+func (s *aggregatedSeries) setValue(dp pmetric.NumberDataPoint, value float64) {
+	if s.isInt {
+		dp.SetIntValue(int64(math.Round(value)))
+	} else {
+		dp.SetDoubleValue(value)
+	}
+}
+
+// isReset returns true if the start time of an input series changed.
+func isReset(previous, current pcommon.Timestamp) bool {
+	return current != 0 && previous != 0 && current != previous
+}
+
+func startTimeOf(start, ts pcommon.Timestamp) pcommon.Timestamp {
+	if start != 0 {
+		return start
+	}
+	return ts
+}
+
+func hashKey(parts ...interface{}) string {
+	jsonStr, _ := json.Marshal(parts)
+	return string(jsonStr)
+}
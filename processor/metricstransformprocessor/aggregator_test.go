@@ -0,0 +1,325 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metricstransformprocessor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/processor/processortest"
+)
+
+var (
+	aggregatorStart = time.Unix(1000, 0)
+	deploymentSet   = map[string]bool{"deployment": true}
+)
+
+func sumMetric(temporality pmetric.AggregationTemporality, points ...numberPoint) pmetric.Metric {
+	m := pmetric.NewMetric()
+	m.SetName("requests")
+	m.SetEmptySum().SetAggregationTemporality(temporality)
+	m.Sum().SetIsMonotonic(true)
+	for _, p := range points {
+		p.appendTo(m.Sum().DataPoints())
+	}
+	return m
+}
+
+func gaugeMetric(points ...numberPoint) pmetric.Metric {
+	m := pmetric.NewMetric()
+	m.SetName("memory")
+	m.SetEmptyGauge()
+	for _, p := range points {
+		p.appendTo(m.Gauge().DataPoints())
+	}
+	return m
+}
+
+type numberPoint struct {
+	pod   string
+	start int64
+	ts    int64
+	value int64
+}
+
+func (p numberPoint) appendTo(dps pmetric.NumberDataPointSlice) {
+	dp := dps.AppendEmpty()
+	dp.Attributes().PutStr("deployment", "checkout")
+	dp.Attributes().PutStr("pod", p.pod)
+	dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+	dp.SetTimestamp(pcommon.Timestamp(p.ts))
+	dp.SetIntValue(p.value)
+}
+
+func collectNumberPoints(t *testing.T, a *seriesAggregator, now time.Time) pmetric.NumberDataPointSlice {
+	md := a.collect(now)
+	if md.ResourceMetrics().Len() == 0 {
+		return pmetric.NewNumberDataPointSlice()
+	}
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 1, metrics.Len())
+	m := metrics.At(0)
+	if m.Type() == pmetric.MetricTypeGauge {
+		return m.Gauge().DataPoints()
+	}
+	return m.Sum().DataPoints()
+}
+
+func TestAggregatorCumulativeSum(t *testing.T) {
+	a := newSeriesAggregator(defaultMaxStaleness)
+	res, scope := pcommon.NewResource(), pcommon.NewInstrumentationScope()
+	cumulative := pmetric.AggregationTemporalityCumulative
+
+	assert.True(t, a.add(res, scope, "requests", sumMetric(cumulative,
+		numberPoint{pod: "a", start: 1, ts: 10, value: 5},
+		numberPoint{pod: "b", start: 2, ts: 10, value: 7},
+	), deploymentSet, "", aggregatorStart))
+
+	dps := collectNumberPoints(t, a, aggregatorStart)
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(12), dps.At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(1), dps.At(0).StartTimestamp())
+	assert.Equal(t, map[string]interface{}{"deployment": "checkout"}, dps.At(0).Attributes().AsRaw())
+
+	// Pod a increases by 3, pod b restarts and reports 2, a new pod c reports 4, the duplicated point is ignored.
+	a.add(res, scope, "requests", sumMetric(cumulative,
+		numberPoint{pod: "a", start: 1, ts: 20, value: 8},
+		numberPoint{pod: "b", start: 15, ts: 20, value: 2},
+		numberPoint{pod: "c", start: 12, ts: 20, value: 4},
+		numberPoint{pod: "c", start: 12, ts: 20, value: 4},
+	), deploymentSet, "", aggregatorStart)
+	dps = collectNumberPoints(t, a, aggregatorStart.Add(time.Minute))
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(21), dps.At(0).IntValue())
+
+	// A decreasing monotonic sum without start time is also a reset.
+	a.add(res, scope, "requests", sumMetric(cumulative,
+		numberPoint{pod: "a", ts: 30, value: 1},
+	), deploymentSet, "", aggregatorStart)
+	dps = collectNumberPoints(t, a, aggregatorStart.Add(2*time.Minute))
+	assert.Equal(t, int64(22), dps.At(0).IntValue())
+
+	// Cumulative series are emitted even if not updated.
+	dps = collectNumberPoints(t, a, aggregatorStart.Add(3*time.Minute))
+	assert.Equal(t, int64(22), dps.At(0).IntValue())
+}
+
+func TestAggregatorDeltaSum(t *testing.T) {
+	a := newSeriesAggregator(defaultMaxStaleness)
+	res, scope := pcommon.NewResource(), pcommon.NewInstrumentationScope()
+	delta := pmetric.AggregationTemporalityDelta
+
+	a.add(res, scope, "requests", sumMetric(delta,
+		numberPoint{pod: "a", start: 1, ts: 10, value: 5},
+		numberPoint{pod: "b", start: 1, ts: 10, value: 7},
+	), deploymentSet, "", aggregatorStart)
+	a.add(res, scope, "requests", sumMetric(delta,
+		numberPoint{pod: "a", start: 10, ts: 20, value: 1},
+	), deploymentSet, "", aggregatorStart)
+
+	dps := collectNumberPoints(t, a, aggregatorStart)
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(13), dps.At(0).IntValue())
+
+	// Nothing is emitted if there was no update during the interval.
+	dps = collectNumberPoints(t, a, aggregatorStart.Add(time.Minute))
+	assert.Equal(t, 0, dps.Len())
+
+	a.add(res, scope, "requests", sumMetric(delta,
+		numberPoint{pod: "a", start: 20, ts: 30, value: 2},
+	), deploymentSet, "", aggregatorStart)
+	dps = collectNumberPoints(t, a, aggregatorStart.Add(2*time.Minute))
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(2), dps.At(0).IntValue())
+	assert.Equal(t, pcommon.NewTimestampFromTime(aggregatorStart), dps.At(0).StartTimestamp())
+}
+
+func TestAggregatorGaugeStaleness(t *testing.T) {
+	a := newSeriesAggregator(time.Minute)
+	res, scope := pcommon.NewResource(), pcommon.NewInstrumentationScope()
+
+	a.add(res, scope, "memory", gaugeMetric(
+		numberPoint{pod: "a", ts: 10, value: 10},
+		numberPoint{pod: "b", ts: 10, value: 20},
+	), deploymentSet, Mean, aggregatorStart)
+	dps := collectNumberPoints(t, a, aggregatorStart)
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(15), dps.At(0).IntValue())
+
+	// Pod b stops reporting and is forgotten once stale.
+	a.add(res, scope, "memory", gaugeMetric(
+		numberPoint{pod: "a", ts: 20, value: 30},
+	), deploymentSet, Mean, aggregatorStart.Add(90*time.Second))
+	dps = collectNumberPoints(t, a, aggregatorStart.Add(90*time.Second))
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, int64(30), dps.At(0).IntValue())
+
+	dps = collectNumberPoints(t, a, aggregatorStart.Add(5*time.Minute))
+	assert.Equal(t, 0, dps.Len())
+	assert.Empty(t, a.series)
+}
+
+func TestAggregatorCumulativeHistogram(t *testing.T) {
+	a := newSeriesAggregator(defaultMaxStaleness)
+	res, scope := pcommon.NewResource(), pcommon.NewInstrumentationScope()
+	histogram := func(points ...struct {
+		pod    string
+		start  int64
+		ts     int64
+		counts []uint64
+	}) pmetric.Metric {
+		m := pmetric.NewMetric()
+		m.SetName("latency")
+		m.SetEmptyHistogram().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+		for _, p := range points {
+			dp := m.Histogram().DataPoints().AppendEmpty()
+			dp.Attributes().PutStr("deployment", "checkout")
+			dp.Attributes().PutStr("pod", p.pod)
+			dp.SetStartTimestamp(pcommon.Timestamp(p.start))
+			dp.SetTimestamp(pcommon.Timestamp(p.ts))
+			dp.ExplicitBounds().FromRaw([]float64{1})
+			dp.BucketCounts().FromRaw(p.counts)
+			dp.SetCount(p.counts[0] + p.counts[1])
+			dp.SetSum(float64(dp.Count()))
+		}
+		return m
+	}
+	type point = struct {
+		pod    string
+		start  int64
+		ts     int64
+		counts []uint64
+	}
+
+	a.add(res, scope, "latency", histogram(
+		point{pod: "a", start: 1, ts: 10, counts: []uint64{1, 2}},
+		point{pod: "b", start: 1, ts: 10, counts: []uint64{3, 4}},
+	), deploymentSet, "", aggregatorStart)
+	a.add(res, scope, "latency", histogram(
+		point{pod: "a", start: 1, ts: 20, counts: []uint64{2, 2}},
+		point{pod: "b", start: 15, ts: 20, counts: []uint64{0, 1}},
+	), deploymentSet, "", aggregatorStart)
+
+	md := a.collect(aggregatorStart)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	dps := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Histogram().DataPoints()
+	require.Equal(t, 1, dps.Len())
+	assert.Equal(t, []uint64{5, 7}, dps.At(0).BucketCounts().AsRaw())
+	assert.Equal(t, uint64(12), dps.At(0).Count())
+	assert.Equal(t, 12.0, dps.At(0).Sum())
+	assert.Equal(t, []float64{1}, dps.At(0).ExplicitBounds().AsRaw())
+}
+
+func TestAggregatorKeepsResources(t *testing.T) {
+	a := newSeriesAggregator(defaultMaxStaleness)
+	scope := pcommon.NewInstrumentationScope()
+	cumulative := pmetric.AggregationTemporalityCumulative
+
+	for _, node := range []string{"node-1", "node-2"} {
+		res := pcommon.NewResource()
+		res.Attributes().PutStr("k8s.node.name", node)
+		a.add(res, scope, "requests", sumMetric(cumulative,
+			numberPoint{pod: node + "-a", start: 1, ts: 10, value: 5},
+			numberPoint{pod: node + "-b", start: 1, ts: 10, value: 7},
+		), deploymentSet, "", aggregatorStart)
+	}
+
+	// The pods are aggregated per node, the resource attributes aren't reduced to the label set.
+	md := a.collect(aggregatorStart)
+	require.Equal(t, 2, md.ResourceMetrics().Len())
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		assert.Equal(t, []string{"k8s.node.name"}, keys(rm.Resource().Attributes()))
+		dps := rm.ScopeMetrics().At(0).Metrics().At(0).Sum().DataPoints()
+		require.Equal(t, 1, dps.Len())
+		assert.Equal(t, int64(12), dps.At(0).IntValue())
+		assert.Equal(t, map[string]interface{}{"deployment": "checkout"}, dps.At(0).Attributes().AsRaw())
+	}
+}
+
+func keys(m pcommon.Map) []string {
+	var keys []string
+	m.Range(func(k string, _ pcommon.Value) bool {
+		keys = append(keys, k)
+		return true
+	})
+	return keys
+}
+
+func TestAggregatorUnsupportedType(t *testing.T) {
+	a := newSeriesAggregator(defaultMaxStaleness)
+	m := pmetric.NewMetric()
+	m.SetEmptySummary().DataPoints().AppendEmpty()
+	assert.False(t, a.add(pcommon.NewResource(), pcommon.NewInstrumentationScope(), "summary", m, deploymentSet, "", aggregatorStart))
+	assert.Empty(t, a.series)
+}
+
+func TestMetricsTransformProcessorAggregate(t *testing.T) {
+	factory := NewFactory()
+	cfg := factory.CreateDefaultConfig().(*Config)
+	cfg.AggregationInterval = time.Hour
+	cfg.Transforms = []Transform{
+		{
+			MetricIncludeFilter: FilterConfig{Include: "requests", MatchType: StrictMatchType},
+			Action:              Aggregate,
+			NewName:             "requests_by_deployment",
+			LabelSet:            []string{"deployment"},
+		},
+	}
+	sink := new(consumertest.MetricsSink)
+	mp, err := factory.CreateMetricsProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, mp.Start(context.Background(), componenttest.NewNopHost()))
+
+	for ts := int64(10); ts <= 20; ts += 10 {
+		md := pmetric.NewMetrics()
+		rm := md.ResourceMetrics().AppendEmpty()
+		rm.Resource().Attributes().PutStr("k8s.cluster.name", "prod")
+		metrics := rm.ScopeMetrics().AppendEmpty().Metrics()
+		sumMetric(pmetric.AggregationTemporalityCumulative,
+			numberPoint{pod: "a", start: 1, ts: ts, value: ts},
+			numberPoint{pod: "b", start: 1, ts: ts, value: 2 * ts},
+		).MoveTo(metrics.AppendEmpty())
+		gaugeMetric(numberPoint{pod: "a", ts: ts, value: 1}).MoveTo(metrics.AppendEmpty())
+		require.NoError(t, mp.ConsumeMetrics(context.Background(), md))
+	}
+
+	// Only the metrics not aggregated are forwarded with the batch.
+	require.Len(t, sink.AllMetrics(), 2)
+	for _, md := range sink.AllMetrics() {
+		assert.Equal(t, 1, md.MetricCount())
+		assert.Equal(t, "memory", md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0).Name())
+	}
+
+	// The aggregated series are flushed on shutdown.
+	require.NoError(t, mp.Shutdown(context.Background()))
+	require.Len(t, sink.AllMetrics(), 3)
+	aggregated := sink.AllMetrics()[2]
+	rm := aggregated.ResourceMetrics().At(0)
+	assert.Equal(t, map[string]interface{}{"k8s.cluster.name": "prod"}, rm.Resource().Attributes().AsRaw())
+	m := rm.ScopeMetrics().At(0).Metrics().At(0)
+	assert.Equal(t, "requests_by_deployment", m.Name())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, m.Sum().AggregationTemporality())
+	require.Equal(t, 1, m.Sum().DataPoints().Len())
+	assert.Equal(t, int64(60), m.Sum().DataPoints().At(0).IntValue())
+}
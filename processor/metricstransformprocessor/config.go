@@ -14,6 +14,8 @@
 
 package metricstransformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
 
+import "time"
+
 const (
 	// IncludeFieldName is the mapstructure field name for Include field
 	IncludeFieldName = "include"
@@ -47,6 +49,12 @@ const (
 
 	// SubmatchCaseFieldName is the mapstructure field name for SubmatchCase field
 	SubmatchCaseFieldName = "submatch_case"
+
+	// AggregationIntervalFieldName is the mapstructure field name for AggregationInterval field
+	AggregationIntervalFieldName = "aggregation_interval"
+
+	// MaxStalenessFieldName is the mapstructure field name for MaxStaleness field
+	MaxStalenessFieldName = "max_staleness"
 )
 
 // Config defines configuration for Resource processor.
@@ -54,6 +62,14 @@ type Config struct {
 
 	// Transform specifies a list of transforms on metrics with each transform focusing on one metric.
 	Transforms []Transform `mapstructure:"transforms"`
+
+	// AggregationInterval is the interval at which the series aggregated by the `aggregate` transforms are emitted.
+	AggregationInterval time.Duration `mapstructure:"aggregation_interval"`
+
+	// MaxStaleness is the time after which the aggregated series and the series contributing to them are forgotten
+	// if they are not updated. It must be positive when `aggregate` transforms are configured, so that the state
+	// they keep is bounded.
+	MaxStaleness time.Duration `mapstructure:"max_staleness"`
 }
 
 // Transform defines the transformation applied to the specific metric
@@ -88,6 +104,9 @@ type Transform struct {
 	// REQUIRED only if Action is COMBINE.
 	AggregationType AggregationType `mapstructure:"aggregation_type"`
 
+	// LabelSet is a list of labels to keep when Action is AGGREGATE. All other labels are aggregated away.
+	LabelSet []string `mapstructure:"label_set"`
+
 	// SubmatchCase specifies what case to use for label values created from regexp submatches.
 	SubmatchCase SubmatchCase `mapstructure:"submatch_case"`
 
@@ -165,9 +184,13 @@ const (
 
 	// Group groups mutiple metrics matching the predicate into multiple ResourceMetrics messages
 	Group ConfigAction = "group"
+
+	// Aggregate removes the matching metrics from the batch and aggregates them across batches, keeping only the
+	// labels in LabelSet. The aggregated series are emitted every AggregationInterval.
+	Aggregate ConfigAction = "aggregate"
 )
 
-var actions = []ConfigAction{Insert, Update, Combine, Group}
+var actions = []ConfigAction{Insert, Update, Combine, Group, Aggregate}
 
 func (ca ConfigAction) isValid() bool {
 	for _, configAction := range actions {
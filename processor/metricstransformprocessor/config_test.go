@@ -17,6 +17,7 @@ package metricstransformprocessor
 import (
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -34,6 +35,8 @@ func TestLoadConfig(t *testing.T) {
 			configFile: "config_full.yaml",
 			id:         component.NewID(typeStr),
 			expected: &Config{
+				AggregationInterval: defaultAggregationInterval,
+				MaxStaleness:        defaultMaxStaleness,
 				Transforms: []Transform{
 					{
 						MetricIncludeFilter: FilterConfig{
@@ -50,6 +53,8 @@ func TestLoadConfig(t *testing.T) {
 			configFile: "config_full.yaml",
 			id:         component.NewIDWithName(typeStr, "multiple"),
 			expected: &Config{
+				AggregationInterval: defaultAggregationInterval,
+				MaxStaleness:        defaultMaxStaleness,
 				Transforms: []Transform{
 					{
 						MetricIncludeFilter: FilterConfig{
@@ -151,6 +156,24 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			configFile: "config_full.yaml",
+			id:         component.NewIDWithName(typeStr, "aggregate"),
+			expected: &Config{
+				AggregationInterval: 30 * time.Second,
+				MaxStaleness:        5 * time.Minute,
+				Transforms: []Transform{
+					{
+						MetricIncludeFilter: FilterConfig{
+							Include:   "http.server.requests",
+							MatchType: "strict",
+						},
+						Action:   "aggregate",
+						LabelSet: []string{"deployment"},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
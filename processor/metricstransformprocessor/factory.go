@@ -19,6 +19,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
@@ -43,8 +44,16 @@ func NewFactory() processor.Factory {
 		processor.WithMetrics(createMetricsProcessor, stability))
 }
 
+const (
+	defaultAggregationInterval = 60 * time.Second
+	defaultMaxStaleness        = 5 * time.Minute
+)
+
 func createDefaultConfig() component.Config {
-	return &Config{}
+	return &Config{
+		AggregationInterval: defaultAggregationInterval,
+		MaxStaleness:        defaultMaxStaleness,
+	}
 }
 
 func createMetricsProcessor(
@@ -63,6 +72,14 @@ func createMetricsProcessor(
 		return nil, err
 	}
 	metricsProcessor := newMetricsTransformProcessor(set.Logger, hCfg)
+	for _, t := range hCfg {
+		if t.Action == Aggregate {
+			metricsProcessor.aggregator = newSeriesAggregator(oCfg.MaxStaleness)
+			metricsProcessor.aggregationInterval = oCfg.AggregationInterval
+			metricsProcessor.nextConsumer = nextConsumer
+			break
+		}
+	}
 
 	return processorhelper.NewMetricsProcessor(
 		ctx,
@@ -70,7 +87,9 @@ func createMetricsProcessor(
 		cfg,
 		nextConsumer,
 		metricsProcessor.processMetrics,
-		processorhelper.WithCapabilities(consumerCapabilities))
+		processorhelper.WithCapabilities(consumerCapabilities),
+		processorhelper.WithStart(metricsProcessor.start),
+		processorhelper.WithShutdown(metricsProcessor.shutdown))
 }
 
 // validateConfiguration validates the input configuration has all of the required fields for the processor
@@ -104,6 +123,14 @@ func validateConfiguration(config *Config) error {
 			return fmt.Errorf("missing required field %q while %q is %v", GroupResourceLabelsFieldName, ActionFieldName, Group)
 		}
 
+		if transform.Action == Aggregate && config.AggregationInterval <= 0 {
+			return fmt.Errorf("%q must be positive while %q is %v", AggregationIntervalFieldName, ActionFieldName, Aggregate)
+		}
+
+		if transform.Action == Aggregate && config.MaxStaleness <= 0 {
+			return fmt.Errorf("%q must be positive while %q is %v", MaxStalenessFieldName, ActionFieldName, Aggregate)
+		}
+
 		if transform.AggregationType != "" && !transform.AggregationType.isValid() {
 			return fmt.Errorf("%q must be in %q", AggregationTypeFieldName, aggregationTypes)
 		}
@@ -160,6 +187,9 @@ func buildHelperConfig(config *Config, version string) ([]internalTransform, err
 			AggregationType:     t.AggregationType,
 			Operations:          make([]internalOperation, len(t.Operations)),
 		}
+		if t.Action == Aggregate {
+			helperT.labelSetMap = sliceToSet(t.LabelSet)
+		}
 
 		for j, op := range t.Operations {
 			op.NewValue = strings.ReplaceAll(op.NewValue, "{{version}}", version)
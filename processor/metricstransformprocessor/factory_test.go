@@ -39,7 +39,7 @@ func TestType(t *testing.T) {
 func TestCreateDefaultConfig(t *testing.T) {
 	factory := NewFactory()
 	cfg := factory.CreateDefaultConfig()
-	assert.Equal(t, cfg, &Config{})
+	assert.Equal(t, cfg, &Config{AggregationInterval: defaultAggregationInterval, MaxStaleness: defaultMaxStaleness})
 	assert.NoError(t, componenttest.CheckConfigStruct(cfg))
 }
 
@@ -110,6 +110,16 @@ func TestCreateProcessors(t *testing.T) {
 			succeed:      false,
 			errorMessage: fmt.Sprintf("operation %v: %q must be in %q", 1, AggregationTypeFieldName, aggregationTypes),
 		},
+		{
+			configName:   "config_invalid_aggregation_interval.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("%q must be positive while %q is %v", AggregationIntervalFieldName, ActionFieldName, Aggregate),
+		},
+		{
+			configName:   "config_invalid_max_staleness.yaml",
+			succeed:      false,
+			errorMessage: fmt.Sprintf("%q must be positive while %q is %v", MaxStalenessFieldName, ActionFieldName, Aggregate),
+		},
 		{
 			configName:   "config_invalid_submatchcase.yaml",
 			succeed:      false,
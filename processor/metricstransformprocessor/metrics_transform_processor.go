@@ -15,9 +15,14 @@
 package metricstransformprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/metricstransformprocessor"
 
 import (
+	"context"
 	"regexp"
 	"strings"
+	"sync"
+	"time"
 
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.uber.org/zap"
@@ -27,6 +32,13 @@ type metricsTransformProcessor struct {
 	transforms               []internalTransform
 	logger                   *zap.Logger
 	otlpDataModelGateEnabled bool
+
+	// aggregator, aggregationInterval and nextConsumer are only set if there are `aggregate` transforms.
+	aggregator          *seriesAggregator
+	aggregationInterval time.Duration
+	nextConsumer        consumer.Metrics
+	done                chan struct{}
+	wg                  sync.WaitGroup
 }
 
 type internalTransform struct {
@@ -37,6 +49,7 @@ type internalTransform struct {
 	AggregationType     AggregationType
 	SubmatchCase        SubmatchCase
 	Operations          []internalOperation
+	labelSetMap         map[string]bool
 }
 
 type internalOperation struct {
@@ -90,6 +103,50 @@ func newMetricsTransformProcessor(logger *zap.Logger, internalTransforms []inter
 	}
 }
 
+func (mtp *metricsTransformProcessor) start(context.Context, component.Host) error {
+	if mtp.aggregator == nil {
+		return nil
+	}
+	mtp.done = make(chan struct{})
+	mtp.wg.Add(1)
+	go func() {
+		defer mtp.wg.Done()
+		ticker := time.NewTicker(mtp.aggregationInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				mtp.exportAggregatedMetrics(context.Background(), time.Now())
+			case <-mtp.done:
+				return
+			}
+		}
+	}()
+	return nil
+}
+
+func (mtp *metricsTransformProcessor) shutdown(ctx context.Context) error {
+	if mtp.aggregator == nil || mtp.done == nil {
+		return nil
+	}
+	close(mtp.done)
+	mtp.wg.Wait()
+	// Flush the series aggregated since the last interval.
+	mtp.exportAggregatedMetrics(ctx, time.Now())
+	return nil
+}
+
+// exportAggregatedMetrics sends the current state of the aggregated series to the next consumer.
+func (mtp *metricsTransformProcessor) exportAggregatedMetrics(ctx context.Context, now time.Time) {
+	md := mtp.aggregator.collect(now)
+	if md.ResourceMetrics().Len() == 0 {
+		return
+	}
+	if err := mtp.nextConsumer.ConsumeMetrics(ctx, md); err != nil {
+		mtp.logger.Error("failed to export aggregated metrics", zap.Error(err))
+	}
+}
+
 func replaceCaseOfSubmatch(replacement SubmatchCase, submatch string) string {
 	switch replacement {
 	case Lower:
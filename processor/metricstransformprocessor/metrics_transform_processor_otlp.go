@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"time"
 
 	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
@@ -236,6 +237,7 @@ func matchAttrs(attrMatchers map[string]StringMatcher, attrs pcommon.Map) bool {
 }
 
 func (mtp *metricsTransformProcessor) processMetrics(_ context.Context, md pmetric.Metrics) (pmetric.Metrics, error) {
+	now := time.Now()
 	rms := md.ResourceMetrics()
 	groupedRMs := pmetric.NewResourceMetricsSlice()
 
@@ -293,6 +295,21 @@ func (mtp *metricsTransformProcessor) processMetrics(_ context.Context, md pmetr
 						// Drop the metric if all the data points were dropped after transformations.
 						return !transformMetric(metric, transform)
 					})
+				case Aggregate:
+					extractedMetrics := pmetric.NewMetricSlice()
+					extractAndRemoveMatchedMetrics(extractedMetrics, transform.MetricIncludeFilter, metrics)
+					for i := 0; i < extractedMetrics.Len(); i++ {
+						metric := extractedMetrics.At(i)
+						sourceName := metric.Name()
+						if !transformMetric(metric, transform) {
+							continue
+						}
+						// Metrics that can't be aggregated are kept in the batch once transformed.
+						if !mtp.aggregator.add(rm.Resource(), sm.Scope(), sourceName, metric, transform.labelSetMap,
+							transform.AggregationType, now) {
+							metric.MoveTo(metrics.AppendEmpty())
+						}
+					}
 				}
 			}
 
@@ -18,6 +18,8 @@ import (
 	"fmt"
 
 	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.uber.org/multierr"
 )
 
 type AttributeSource string
@@ -34,6 +36,34 @@ var validAttributeSource = map[AttributeSource]bool{
 	recordAttributeSource:  true,
 }
 
+// ProbabilityRecording defines how the effective sampling probability is recorded on sampled items.
+type ProbabilityRecording string
+
+const (
+	noneProbabilityRecording       = ProbabilityRecording("none")
+	traceStateProbabilityRecording = ProbabilityRecording("tracestate")
+	attributeProbabilityRecording  = ProbabilityRecording("attribute")
+)
+
+var validProbabilityRecording = map[ProbabilityRecording]bool{
+	noneProbabilityRecording:       true,
+	traceStateProbabilityRecording: true,
+	attributeProbabilityRecording:  true,
+}
+
+// SamplingRule assigns a sampling percentage to the traces or logs matching an OTTL condition.
+type SamplingRule struct {
+	// Name identifies the rule in the processor's own metrics. Defaults to `rule_<index>`.
+	Name string `mapstructure:"name"`
+
+	// Condition is an OTTL condition evaluated against each span or log record. An empty condition matches everything.
+	Condition string `mapstructure:"condition"`
+
+	// SamplingPercentage is the percentage rate applied to the matching spans or log records.
+	// Values greater or equal 100 are treated as "sample all".
+	SamplingPercentage float32 `mapstructure:"sampling_percentage"`
+}
+
 // Config has the configuration guiding the sampler processor.
 type Config struct {
 
@@ -57,6 +87,16 @@ type Config struct {
 	// SamplingPriority (logs only) allows to use a log record attribute designed by the `sampling_priority` key
 	// to be used as the sampling priority of the log record.
 	SamplingPriority string `mapstructure:"sampling_priority"`
+
+	// SamplingRules are evaluated in order against each span or log record and the first matching rule
+	// decides the sampling percentage. Items not matching any rule use SamplingPercentage.
+	SamplingRules []SamplingRule `mapstructure:"sampling_rules"`
+
+	// RecordProbability defines how the effective sampling probability is recorded on sampled items so
+	// backends can re-weight counts. The allowed values are `none`, `tracestate` (traces only, writes the
+	// W3C `ot=th:` threshold on the spans with an `ot=rv:` randomness and the attribute on the others) or
+	// `attribute` (writes the `sampling.adjusted_count` attribute). Default is `none`.
+	RecordProbability ProbabilityRecording `mapstructure:"record_probability"`
 }
 
 var _ component.Config = (*Config)(nil)
@@ -69,5 +109,33 @@ func (cfg *Config) Validate() error {
 	if cfg.AttributeSource != "" && !validAttributeSource[cfg.AttributeSource] {
 		return fmt.Errorf("invalid attribute source: %v. Expected: %v or %v", cfg.AttributeSource, traceIDAttributeSource, recordAttributeSource)
 	}
+	if cfg.RecordProbability != "" && !validProbabilityRecording[cfg.RecordProbability] {
+		return fmt.Errorf("invalid record_probability: %v. Expected: %v, %v or %v", cfg.RecordProbability,
+			noneProbabilityRecording, traceStateProbabilityRecording, attributeProbabilityRecording)
+	}
+	names := make(map[string]bool, len(cfg.SamplingRules))
+	for i, rule := range cfg.SamplingRules {
+		if rule.SamplingPercentage < 0 {
+			return fmt.Errorf("sampling_rules[%d]: negative sampling rate: %.2f", i, rule.SamplingPercentage)
+		}
+		name := rule.Name
+		if name == "" {
+			name = fmt.Sprintf("rule_%d", i)
+		}
+		if names[name] {
+			return fmt.Errorf("sampling_rules[%d]: duplicate name: %s", i, name)
+		}
+		names[name] = true
+	}
+	if len(cfg.SamplingRules) > 0 {
+		// The configuration does not know which pipeline it belongs to, so the conditions only need to be valid for
+		// one of the signals. They are parsed again for the actual signal when the processor is created.
+		set := componenttest.NewNopTelemetrySettings()
+		_, spanErr := newSpanSamplingRules(cfg.SamplingRules, set)
+		_, logErr := newLogSamplingRules(cfg.SamplingRules, set)
+		if spanErr != nil && logErr != nil {
+			return multierr.Combine(spanErr, logErr)
+		}
+	}
 	return nil
 }
@@ -37,6 +37,7 @@ func TestLoadConfig(t *testing.T) {
 				SamplingPercentage: 15.3,
 				HashSeed:           22,
 				AttributeSource:    "traceID",
+				RecordProbability:  "none",
 			},
 		},
 		{
@@ -47,6 +48,26 @@ func TestLoadConfig(t *testing.T) {
 				AttributeSource:    "record",
 				FromAttribute:      "foo",
 				SamplingPriority:   "bar",
+				RecordProbability:  "none",
+			},
+		},
+		{
+			id: component.NewIDWithName(typeStr, "rules"),
+			expected: &Config{
+				SamplingPercentage: 1,
+				AttributeSource:    "traceID",
+				SamplingRules: []SamplingRule{
+					{
+						Name:               "errors",
+						Condition:          `status.code == STATUS_CODE_ERROR`,
+						SamplingPercentage: 100,
+					},
+					{
+						Condition:          `attributes["http.target"] == "/health"`,
+						SamplingPercentage: 10,
+					},
+				},
+				RecordProbability: "tracestate",
 			},
 		},
 	}
@@ -81,3 +102,62 @@ func TestLoadInvalidConfig(t *testing.T) {
 	_, err = otelcoltest.LoadConfigAndValidate(filepath.Join("testdata", "invalid.yaml"), factories)
 	require.ErrorContains(t, err, "negative sampling rate: -15.30")
 }
+
+func TestValidateConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		cfg    *Config
+		errMsg string
+	}{
+		{
+			name: "valid rules",
+			cfg: &Config{
+				SamplingRules: []SamplingRule{
+					{Condition: `attributes["http.target"] == "/health"`, SamplingPercentage: 10},
+					{SamplingPercentage: 100},
+				},
+			},
+		},
+		{
+			name: "negative rule sampling rate",
+			cfg: &Config{
+				SamplingRules: []SamplingRule{{SamplingPercentage: -1}},
+			},
+			errMsg: "sampling_rules[0]: negative sampling rate: -1.00",
+		},
+		{
+			name: "invalid rule condition",
+			cfg: &Config{
+				SamplingRules: []SamplingRule{{Condition: `attributes["foo"] ==`, SamplingPercentage: 10}},
+			},
+			errMsg: "sampling_rules[0]",
+		},
+		{
+			name: "duplicate rule name",
+			cfg: &Config{
+				SamplingRules: []SamplingRule{
+					{Name: "rule_1", Condition: `attributes["http.target"] == "/health"`, SamplingPercentage: 10},
+					{SamplingPercentage: 100},
+				},
+			},
+			errMsg: "sampling_rules[1]: duplicate name: rule_1",
+		},
+		{
+			name: "invalid record_probability",
+			cfg: &Config{
+				RecordProbability: "header",
+			},
+			errMsg: "invalid record_probability: header",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.cfg.Validate()
+			if tt.errMsg == "" {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorContains(t, err, tt.errMsg)
+			}
+		})
+	}
+}
@@ -50,7 +50,8 @@ func NewFactory() processor.Factory {
 
 func createDefaultConfig() component.Config {
 	return &Config{
-		AttributeSource: defaultAttributeSource,
+		AttributeSource:   defaultAttributeSource,
+		RecordProbability: noneProbabilityRecording,
 	}
 }
 
@@ -16,6 +16,7 @@ package probabilisticsamplerprocessor // import "github.com/open-telemetry/opent
 
 import (
 	"context"
+	"fmt"
 
 	"go.opencensus.io/stats"
 	"go.opencensus.io/tag"
@@ -25,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
 )
 
 type logSamplerProcessor struct {
@@ -33,12 +36,22 @@ type logSamplerProcessor struct {
 	traceIDEnabled     bool
 	samplingSource     string
 	samplingPriority   string
+	rules              []samplingRule[ottllog.TransformContext]
+	recordProbability  ProbabilityRecording
 	logger             *zap.Logger
 }
 
 // newLogsProcessor returns a processor.LogsProcessor that will perform head sampling according to the given
 // configuration.
 func newLogsProcessor(ctx context.Context, set processor.CreateSettings, nextConsumer consumer.Logs, cfg *Config) (processor.Logs, error) {
+	if cfg.RecordProbability == traceStateProbabilityRecording {
+		return nil, fmt.Errorf("record_probability %q is not supported for logs", cfg.RecordProbability)
+	}
+
+	rules, err := newLogSamplingRules(cfg.SamplingRules, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
 
 	lsp := &logSamplerProcessor{
 		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
@@ -46,6 +59,8 @@ func newLogsProcessor(ctx context.Context, set processor.CreateSettings, nextCon
 		traceIDEnabled:     cfg.AttributeSource == traceIDAttributeSource,
 		samplingPriority:   cfg.SamplingPriority,
 		samplingSource:     cfg.FromAttribute,
+		rules:              rules,
+		recordProbability:  cfg.RecordProbability,
 		logger:             set.Logger,
 	}
 
@@ -78,6 +93,16 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 					}
 				}
 				priority := lsp.scaledSamplingRate
+				if len(lsp.rules) > 0 {
+					rule, err := matchSamplingRule(ctx, lsp.rules, ottllog.NewTransformContext(l, ill.Scope(), rl.Resource()))
+					if err != nil {
+						lsp.logger.Error("failed to evaluate sampling rules", zap.Error(err))
+					}
+					if rule != nil {
+						tagPolicyValue = rule.name
+						priority = rule.scaledSamplingRate
+					}
+				}
 				if lsp.samplingPriority != "" {
 					if localPriority, ok := l.Attributes().Get(lsp.samplingPriority); ok {
 						switch localPriority.Type() {
@@ -97,6 +122,9 @@ func (lsp *logSamplerProcessor) processLogs(ctx context.Context, ld plog.Logs) (
 						[]tag.Mutator{tag.Upsert(tagPolicyKey, tagPolicyValue), tag.Upsert(tagSampledKey, "true")},
 						statCountLogsSampled.M(int64(1)),
 					)
+					if lsp.recordProbability == attributeProbabilityRecording {
+						recordAdjustedCount(l.Attributes(), effectiveProbability(priority))
+					}
 				} else {
 					err = stats.RecordWithTags(
 						ctx,
@@ -57,6 +57,14 @@ func TestNewLogsProcessor(t *testing.T) {
 				HashSeed:           4321,
 			},
 		},
+		{
+			name:         "invalid_rule_condition",
+			nextConsumer: consumertest.NewNop(),
+			cfg: &Config{
+				SamplingRules: []SamplingRule{{Condition: `attributes["foo"] ==`, SamplingPercentage: 10}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -181,3 +189,78 @@ func TestLogsSampling(t *testing.T) {
 		})
 	}
 }
+
+func TestLogsSamplingRules(t *testing.T) {
+	cfg := &Config{
+		SamplingPercentage: 0,
+		SamplingPriority:   "priority",
+		SamplingRules: []SamplingRule{
+			{
+				Name:               "errors",
+				Condition:          `severity_number >= SEVERITY_NUMBER_ERROR`,
+				SamplingPercentage: 100,
+			},
+			{
+				Name:               "debug",
+				Condition:          `severity_number == SEVERITY_NUMBER_DEBUG`,
+				SamplingPercentage: 50,
+			},
+		},
+		RecordProbability: attributeProbabilityRecording,
+	}
+	sink := new(consumertest.LogsSink)
+	processor, err := newLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), sink, cfg)
+	require.NoError(t, err)
+
+	logs := plog.NewLogs()
+	lr := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	for i := 0; i < 100; i++ {
+		record := lr.AppendEmpty()
+		ib := byte(i)
+		record.SetTraceID([16]byte{0, 0, 0, 0, 0, 0, 0, 0, ib, ib, ib, ib, ib, ib, ib, ib})
+		switch i % 4 {
+		case 0:
+			record.SetSeverityNumber(plog.SeverityNumberError)
+			record.Attributes().PutDouble(adjustedCountAttribute, 2)
+		case 1:
+			record.SetSeverityNumber(plog.SeverityNumberInfo)
+		case 2:
+			record.SetSeverityNumber(plog.SeverityNumberInfo)
+			// sampling_priority overrides the sampling rules.
+			record.Attributes().PutDouble("priority", 100)
+		default:
+			record.SetSeverityNumber(plog.SeverityNumberDebug)
+		}
+	}
+	require.NoError(t, processor.ConsumeLogs(context.Background(), logs))
+
+	sunk := sink.AllLogs()
+	require.Len(t, sunk, 1)
+	sampled := sunk[0].ResourceLogs().At(0).ScopeLogs().At(0).LogRecords()
+	counts := map[plog.SeverityNumber]int{}
+	for i := 0; i < sampled.Len(); i++ {
+		record := sampled.At(i)
+		counts[record.SeverityNumber()]++
+		adjustedCount, ok := record.Attributes().Get(adjustedCountAttribute)
+		require.True(t, ok)
+		switch record.SeverityNumber() {
+		case plog.SeverityNumberError:
+			assert.Equal(t, 2.0, adjustedCount.Double())
+		case plog.SeverityNumberInfo:
+			assert.Equal(t, 1.0, adjustedCount.Double())
+		case plog.SeverityNumberDebug:
+			assert.Equal(t, 2.0, adjustedCount.Double())
+		}
+	}
+	assert.Equal(t, 25, counts[plog.SeverityNumberError])
+	assert.Equal(t, 25, counts[plog.SeverityNumberInfo])
+	assert.Greater(t, counts[plog.SeverityNumberDebug], 0)
+}
+
+func TestNewLogsProcessorTraceStateRecording(t *testing.T) {
+	_, err := newLogsProcessor(context.Background(), processortest.NewNopCreateSettings(), consumertest.NewNop(), &Config{
+		SamplingPercentage: 10,
+		RecordProbability:  traceStateProbabilityRecording,
+	})
+	assert.ErrorContains(t, err, "not supported for logs")
+}
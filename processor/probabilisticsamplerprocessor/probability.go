@@ -0,0 +1,170 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+const (
+	// adjustedCountAttribute holds the number of items represented by a sampled item, ie.: 1/probability.
+	adjustedCountAttribute = "sampling.adjusted_count"
+
+	// otelTraceStateKey is the OpenTelemetry vendor key of the W3C tracestate header.
+	otelTraceStateKey = "ot"
+	// thresholdKey is the sub-key of the OpenTelemetry tracestate entry holding the sampling threshold.
+	thresholdKey = "th"
+	// randomnessKey is the sub-key of the OpenTelemetry tracestate entry holding the explicit trace randomness.
+	randomnessKey = "rv"
+	// thresholdHexDigits is the number of hex digits of a 56-bit sampling threshold or randomness.
+	thresholdHexDigits = 14
+)
+
+// maxThreshold is the exclusive upper bound of a 56-bit sampling threshold, ie.: the threshold rejecting everything.
+var maxThreshold = math.Ldexp(1, 4*thresholdHexDigits)
+
+// effectiveProbability returns the probability actually applied by a scaled sampling rate, which is rounded down to
+// the number of hash buckets.
+func effectiveProbability(scaledSamplingRate uint32) float64 {
+	if scaledSamplingRate >= numHashBuckets {
+		return 1
+	}
+	return float64(scaledSamplingRate) / numHashBuckets
+}
+
+// probabilityToThresholdValue returns the 56-bit rejection threshold of a sampling probability: items are kept when
+// their randomness is greater or equal than the threshold.
+func probabilityToThresholdValue(probability float64) uint64 {
+	if probability >= 1 {
+		return 0
+	}
+	// Scaling the probability rather than its complement avoids losing precision on small probabilities.
+	kept := uint64(math.Round(probability * maxThreshold))
+	if kept == 0 {
+		kept = 1
+	}
+	return uint64(maxThreshold) - kept
+}
+
+// probabilityToThreshold encodes a sampling probability as the W3C tracestate rejection threshold. Trailing zeros are
+// removed, so probability 1 is encoded as "0".
+func probabilityToThreshold(probability float64) string {
+	encoded := strings.TrimRight(fmt.Sprintf("%0*x", thresholdHexDigits, probabilityToThresholdValue(probability)), "0")
+	if encoded == "" {
+		return "0"
+	}
+	return encoded
+}
+
+// thresholdToProbability decodes a W3C tracestate rejection threshold into a sampling probability.
+func thresholdToProbability(threshold string) (float64, error) {
+	if threshold == "" || len(threshold) > thresholdHexDigits {
+		return 0, fmt.Errorf("invalid sampling threshold %q", threshold)
+	}
+	value, err := strconv.ParseUint(threshold+strings.Repeat("0", thresholdHexDigits-len(threshold)), 16, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid sampling threshold %q: %w", threshold, err)
+	}
+	return (maxThreshold - float64(value)) / maxThreshold, nil
+}
+
+// splitTraceState returns the value of the "ot" entry of a tracestate and its other entries.
+func splitTraceState(traceState string) (otValue string, others []string) {
+	for _, member := range strings.Split(traceState, ",") {
+		member = strings.TrimSpace(member)
+		if member == "" {
+			continue
+		}
+		if key, value, ok := strings.Cut(member, "="); ok && key == otelTraceStateKey {
+			otValue = value
+			continue
+		}
+		others = append(others, member)
+	}
+	return otValue, others
+}
+
+// traceStateRandomness returns the explicit randomness of the "ot" entry of a tracestate, if it has a valid one.
+func traceStateRandomness(traceState string) (uint64, bool) {
+	otValue, _ := splitTraceState(traceState)
+	if otValue == "" {
+		return 0, false
+	}
+	for _, field := range strings.Split(otValue, ";") {
+		key, value, _ := strings.Cut(field, ":")
+		if key != randomnessKey || len(value) != thresholdHexDigits {
+			continue
+		}
+		randomness, err := strconv.ParseUint(value, 16, 64)
+		return randomness, err == nil
+	}
+	return 0, false
+}
+
+// recordTraceStateProbability returns the tracestate with the "ot" entry threshold updated to account for the given
+// sampling probability. The items being sampled consistently on their randomness, the lowest of the probability and
+// of the one of a threshold already present applies. The updated entry is moved to the front of the list as required
+// by the W3C Trace Context specification.
+func recordTraceStateProbability(traceState string, probability float64) string {
+	otValue, others := splitTraceState(traceState)
+
+	var fields []string
+	thresholdIdx := -1
+	if otValue != "" {
+		fields = strings.Split(otValue, ";")
+	}
+	for i, field := range fields {
+		key, value, _ := strings.Cut(field, ":")
+		if key != thresholdKey {
+			continue
+		}
+		if previous, err := thresholdToProbability(value); err == nil && previous < probability {
+			probability = previous
+		}
+		thresholdIdx = i
+		break
+	}
+	threshold := thresholdKey + ":" + probabilityToThreshold(probability)
+	if thresholdIdx >= 0 {
+		fields[thresholdIdx] = threshold
+	} else {
+		fields = append([]string{threshold}, fields...)
+	}
+
+	return strings.Join(append([]string{otelTraceStateKey + "=" + strings.Join(fields, ";")}, others...), ",")
+}
+
+// recordAdjustedCount sets the adjusted count attribute for the given sampling probability, multiplying any adjusted
+// count already recorded by a previous sampling stage.
+func recordAdjustedCount(attributes pcommon.Map, probability float64) {
+	if probability <= 0 {
+		return
+	}
+	adjustedCount := 1 / probability
+	if previous, ok := attributes.Get(adjustedCountAttribute); ok {
+		switch previous.Type() {
+		case pcommon.ValueTypeDouble:
+			adjustedCount *= previous.Double()
+		case pcommon.ValueTypeInt:
+			adjustedCount *= float64(previous.Int())
+		}
+	}
+	attributes.PutDouble(adjustedCountAttribute, adjustedCount)
+}
@@ -0,0 +1,122 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+)
+
+func TestProbabilityToThreshold(t *testing.T) {
+	tests := []struct {
+		probability float64
+		threshold   string
+	}{
+		{probability: 1, threshold: "0"},
+		{probability: 0.5, threshold: "8"},
+		{probability: 0.25, threshold: "c"},
+		{probability: 0.1, threshold: "e6666666666666"},
+		{probability: 0.01, threshold: "fd70a3d70a3d71"},
+	}
+	for _, tt := range tests {
+		threshold := probabilityToThreshold(tt.probability)
+		assert.Equal(t, tt.threshold, threshold)
+		probability, err := thresholdToProbability(threshold)
+		require.NoError(t, err)
+		assert.InDelta(t, tt.probability, probability, 1e-12)
+	}
+
+	_, err := thresholdToProbability("xyz")
+	assert.Error(t, err)
+	_, err = thresholdToProbability("123456789abcdef")
+	assert.Error(t, err)
+}
+
+func TestRecordTraceStateProbability(t *testing.T) {
+	tests := []struct {
+		name        string
+		traceState  string
+		probability float64
+		want        string
+	}{
+		{
+			name:        "empty",
+			probability: 0.5,
+			want:        "ot=th:8",
+		},
+		{
+			name:        "other vendors",
+			traceState:  "foo=bar,baz=qux",
+			probability: 0.25,
+			want:        "ot=th:c,foo=bar,baz=qux",
+		},
+		{
+			name:        "existing ot entry moved to front",
+			traceState:  "foo=bar,ot=rv:abcdef",
+			probability: 0.5,
+			want:        "ot=th:8;rv:abcdef,foo=bar",
+		},
+		{
+			name:        "existing threshold combined",
+			traceState:  "ot=rv:abcdef;th:8",
+			probability: 0.25,
+			want:        "ot=rv:abcdef;th:c",
+		},
+		{
+			name:        "lower existing threshold kept",
+			traceState:  "ot=rv:abcdef;th:c",
+			probability: 0.5,
+			want:        "ot=rv:abcdef;th:c",
+		},
+		{
+			name:        "sample all keeps the threshold",
+			traceState:  "ot=th:c",
+			probability: 1,
+			want:        "ot=th:c",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, recordTraceStateProbability(tt.traceState, tt.probability))
+		})
+	}
+}
+
+func TestTraceStateRandomness(t *testing.T) {
+	randomness, ok := traceStateRandomness("foo=bar,ot=th:8;rv:abcdef01234567")
+	require.True(t, ok)
+	assert.Equal(t, uint64(0xabcdef01234567), randomness)
+
+	for _, traceState := range []string{"", "foo=bar", "ot=th:8", "ot=rv:abcdef", "ot=rv:abcdefghijklmn"} {
+		_, ok = traceStateRandomness(traceState)
+		assert.False(t, ok, traceState)
+	}
+}
+
+func TestRecordAdjustedCount(t *testing.T) {
+	attributes := pcommon.NewMap()
+	recordAdjustedCount(attributes, 0.25)
+	adjustedCount, ok := attributes.Get(adjustedCountAttribute)
+	require.True(t, ok)
+	assert.Equal(t, 4.0, adjustedCount.Double())
+
+	attributes.PutInt(adjustedCountAttribute, 10)
+	recordAdjustedCount(attributes, 0.5)
+	adjustedCount, _ = attributes.Get(adjustedCountAttribute)
+	assert.Equal(t, 20.0, adjustedCount.Double())
+}
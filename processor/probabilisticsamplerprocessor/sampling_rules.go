@@ -0,0 +1,110 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package probabilisticsamplerprocessor // import "github.com/open-telemetry/opentelemetry-collector-contrib/processor/probabilisticsamplerprocessor"
+
+import (
+	"context"
+	"fmt"
+
+	"go.opentelemetry.io/collector/component"
+	"go.uber.org/multierr"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottllog"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/ottlfuncs"
+)
+
+// samplingRule is a parsed SamplingRule for the transform context K.
+type samplingRule[K any] struct {
+	name string
+	// condition is nil when the rule matches everything.
+	condition          *ottl.Statement[K]
+	scaledSamplingRate uint32
+}
+
+// matches reports if the rule condition is true for the given transform context.
+func (r *samplingRule[K]) matches(ctx context.Context, tCtx K) (bool, error) {
+	if r.condition == nil {
+		return true, nil
+	}
+	_, matched, err := r.condition.Execute(ctx, tCtx)
+	return matched, err
+}
+
+// matchSamplingRule returns the first rule matching the transform context, or nil if there is none.
+// Rules failing to evaluate are skipped and their errors are returned alongside the match.
+func matchSamplingRule[K any](ctx context.Context, rules []samplingRule[K], tCtx K) (*samplingRule[K], error) {
+	var errs error
+	for i := range rules {
+		matched, err := rules[i].matches(ctx, tCtx)
+		if err != nil {
+			errs = multierr.Append(errs, fmt.Errorf("failed to evaluate sampling rule %q: %w", rules[i].name, err))
+			continue
+		}
+		if matched {
+			return &rules[i], errs
+		}
+	}
+	return nil, errs
+}
+
+func newSpanSamplingRules(rules []SamplingRule, set component.TelemetrySettings) ([]samplingRule[ottlspan.TransformContext], error) {
+	return newSamplingRules(rules, ottlspan.NewParser(functions[ottlspan.TransformContext](), set))
+}
+
+func newLogSamplingRules(rules []SamplingRule, set component.TelemetrySettings) ([]samplingRule[ottllog.TransformContext], error) {
+	return newSamplingRules(rules, ottllog.NewParser(functions[ottllog.TransformContext](), set))
+}
+
+func newSamplingRules[K any](rules []SamplingRule, parser ottl.Parser[K]) ([]samplingRule[K], error) {
+	parsed := make([]samplingRule[K], 0, len(rules))
+	for i, rule := range rules {
+		r := samplingRule[K]{
+			name:               rule.Name,
+			scaledSamplingRate: uint32(rule.SamplingPercentage * percentageScaleFactor),
+		}
+		if r.name == "" {
+			r.name = fmt.Sprintf("rule_%d", i)
+		}
+		if rule.Condition != "" {
+			// OTTL only parses statements, so the condition is attached to a no-op function.
+			statements, err := parser.ParseStatements([]string{"sample() where " + rule.Condition})
+			if err != nil {
+				return nil, fmt.Errorf("sampling_rules[%d]: %w", i, err)
+			}
+			r.condition = statements[0]
+		}
+		parsed = append(parsed, r)
+	}
+	return parsed, nil
+}
+
+func functions[K any]() map[string]interface{} {
+	return map[string]interface{}{
+		"TraceID":     ottlfuncs.TraceID[K],
+		"SpanID":      ottlfuncs.SpanID[K],
+		"IsMatch":     ottlfuncs.IsMatch[K],
+		"Concat":      ottlfuncs.Concat[K],
+		"Split":       ottlfuncs.Split[K],
+		"Int":         ottlfuncs.Int[K],
+		"ConvertCase": ottlfuncs.ConvertCase[K],
+		"sample": func() (ottl.ExprFunc[K], error) {
+			return func(context.Context, K) (interface{}, error) {
+				return true, nil
+			}, nil
+		},
+	}
+}
@@ -26,6 +26,8 @@ import (
 	"go.opentelemetry.io/collector/processor"
 	"go.opentelemetry.io/collector/processor/processorhelper"
 	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/ottl/contexts/ottlspan"
 )
 
 // samplingPriority has the semantic result of parsing the "sampling.priority"
@@ -55,16 +57,25 @@ const (
 type traceSamplerProcessor struct {
 	scaledSamplingRate uint32
 	hashSeed           uint32
+	rules              []samplingRule[ottlspan.TransformContext]
+	recordProbability  ProbabilityRecording
 	logger             *zap.Logger
 }
 
 // newTracesProcessor returns a processor.TracesProcessor that will perform head sampling according to the given
 // configuration.
 func newTracesProcessor(ctx context.Context, set processor.CreateSettings, cfg *Config, nextConsumer consumer.Traces) (processor.Traces, error) {
+	rules, err := newSpanSamplingRules(cfg.SamplingRules, set.TelemetrySettings)
+	if err != nil {
+		return nil, err
+	}
+
 	tsp := &traceSamplerProcessor{
 		// Adjust sampling percentage on private so recalculations are avoided.
 		scaledSamplingRate: uint32(cfg.SamplingPercentage * percentageScaleFactor),
 		hashSeed:           cfg.HashSeed,
+		rules:              rules,
+		recordProbability:  cfg.RecordProbability,
 		logger:             set.Logger,
 	}
 
@@ -100,23 +111,49 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 					statCountTracesSampled.M(int64(1)),
 				)
 
-				// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
-				// with various different criteria to generate trace id and perhaps were already sampled without hashing.
-				// Hashing here prevents bias due to such systems.
-				tidBytes := s.TraceID()
-				sampled := sp == mustSampleSpan ||
-					hash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < tsp.scaledSamplingRate
+				tagPolicyValue := "trace_id_hash"
+				scaledSamplingRate := tsp.scaledSamplingRate
+				if sp == mustSampleSpan {
+					scaledSamplingRate = numHashBuckets
+				} else if len(tsp.rules) > 0 {
+					rule, err := matchSamplingRule(ctx, tsp.rules, ottlspan.NewTransformContext(s, ils.Scope(), rs.Resource()))
+					if err != nil {
+						tsp.logger.Error("failed to evaluate sampling rules", zap.Error(err))
+					}
+					if rule != nil {
+						tagPolicyValue = rule.name
+						scaledSamplingRate = rule.scaledSamplingRate
+					}
+				}
+
+				// The threshold is only recorded in the tracestate when the decision compares the explicit randomness
+				// of the trace with it, so that the following sampling stages make consistent decisions.
+				var sampled bool
+				randomness, consistent := uint64(0), false
+				if tsp.recordProbability == traceStateProbabilityRecording {
+					randomness, consistent = traceStateRandomness(s.TraceState().AsRaw())
+				}
+				if consistent {
+					sampled = randomness >= probabilityToThresholdValue(effectiveProbability(scaledSamplingRate))
+				} else {
+					// If one assumes random trace ids hashing may seems avoidable, however, traces can be coming from sources
+					// with various different criteria to generate trace id and perhaps were already sampled without hashing.
+					// Hashing here prevents bias due to such systems.
+					tidBytes := s.TraceID()
+					sampled = hash(tidBytes[:], tsp.hashSeed)&bitMaskHashBuckets < scaledSamplingRate
+				}
 
 				if sampled {
 					_ = stats.RecordWithTags(
 						ctx,
-						[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, "true")},
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, tagPolicyValue), tag.Upsert(tagSampledKey, "true")},
 						statCountTracesSampled.M(int64(1)),
 					)
+					tsp.recordSpanProbability(s, effectiveProbability(scaledSamplingRate), consistent)
 				} else {
 					_ = stats.RecordWithTags(
 						ctx,
-						[]tag.Mutator{tag.Upsert(tagPolicyKey, "trace_id_hash"), tag.Upsert(tagSampledKey, "false")},
+						[]tag.Mutator{tag.Upsert(tagPolicyKey, tagPolicyValue), tag.Upsert(tagSampledKey, "false")},
 						statCountTracesSampled.M(int64(1)),
 					)
 				}
@@ -134,6 +171,22 @@ func (tsp *traceSamplerProcessor) processTraces(ctx context.Context, td ptrace.T
 	return td, nil
 }
 
+// recordSpanProbability records the probability a sampled span was kept with according to the configuration. The
+// tracestate threshold is only recorded for the spans sampled consistently on their randomness, the adjusted count
+// attribute is recorded instead for the others.
+func (tsp *traceSamplerProcessor) recordSpanProbability(s ptrace.Span, probability float64, consistent bool) {
+	switch tsp.recordProbability {
+	case traceStateProbabilityRecording:
+		if consistent {
+			s.TraceState().FromRaw(recordTraceStateProbability(s.TraceState().AsRaw(), probability))
+		} else {
+			recordAdjustedCount(s.Attributes(), probability)
+		}
+	case attributeProbabilityRecording:
+		recordAdjustedCount(s.Attributes(), probability)
+	}
+}
+
 // parseSpanSamplingPriority checks if the span has the "sampling.priority" tag to
 // decide if the span should be sampled or not. The usage of the tag follows the
 // OpenTracing semantic tags:
@@ -16,6 +16,7 @@ package probabilisticsamplerprocessor
 
 import (
 	"context"
+	"fmt"
 	"math"
 	"math/rand"
 	"testing"
@@ -61,6 +62,14 @@ func TestNewTracesProcessor(t *testing.T) {
 				HashSeed:           4321,
 			},
 		},
+		{
+			name:         "invalid_rule_condition",
+			nextConsumer: consumertest.NewNop(),
+			cfg: &Config{
+				SamplingRules: []SamplingRule{{Condition: `attributes["foo"] ==`, SamplingPercentage: 10}},
+			},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -321,6 +330,92 @@ func Test_tracesamplerprocessor_SpanSamplingPriority(t *testing.T) {
 	}
 }
 
+// Test_tracesamplerprocessor_SamplingRules checks that the first matching rule decides the sampling percentage
+// and that the effective probability is recorded on the sampled spans.
+func Test_tracesamplerprocessor_SamplingRules(t *testing.T) {
+	cfg := &Config{
+		SamplingPercentage: 0,
+		SamplingRules: []SamplingRule{
+			{
+				Name:               "errors",
+				Condition:          `status.code == STATUS_CODE_ERROR`,
+				SamplingPercentage: 100,
+			},
+			{
+				Name:               "health",
+				Condition:          `attributes["http.target"] == "/health"`,
+				SamplingPercentage: 0,
+			},
+			{
+				Name:               "checkout",
+				Condition:          `resource.attributes["service.name"] == "checkout"`,
+				SamplingPercentage: 50,
+			},
+		},
+		RecordProbability: traceStateProbabilityRecording,
+	}
+	sink := new(consumertest.TracesSink)
+	tsp, err := newTracesProcessor(context.Background(), processortest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+
+	consistent := 0
+	td := ptrace.NewTraces()
+	rs := td.ResourceSpans().AppendEmpty()
+	rs.Resource().Attributes().PutStr("service.name", "checkout")
+	spans := rs.ScopeSpans().AppendEmpty().Spans()
+	for i := 0; i < 100; i++ {
+		span := spans.AppendEmpty()
+		span.SetName("span")
+		span.SetTraceID(idutils.UInt64ToTraceID(rand.Uint64(), rand.Uint64()))
+		switch i % 4 {
+		case 0:
+			span.Status().SetCode(ptrace.StatusCodeError)
+			span.Attributes().PutStr("http.target", "/health")
+			span.TraceState().FromRaw("ot=th:8;rv:abcdef01234567,vendor=value")
+		case 1:
+			span.Attributes().PutStr("http.target", "/health")
+		case 3:
+			randomness := rand.Uint64() & (1<<56 - 1)
+			span.TraceState().FromRaw(fmt.Sprintf("ot=rv:%014x", randomness))
+			if randomness >= 1<<55 {
+				consistent++
+			}
+		}
+	}
+
+	require.NoError(t, tsp.ConsumeTraces(context.Background(), td))
+
+	errors, hashed, sampledConsistent := 0, 0, 0
+	for _, sampled := range sink.AllTraces() {
+		sampledSpans := sampled.ResourceSpans().At(0).ScopeSpans().At(0).Spans()
+		for i := 0; i < sampledSpans.Len(); i++ {
+			span := sampledSpans.At(i)
+			if span.Status().Code() == ptrace.StatusCodeError {
+				errors++
+				assert.Equal(t, "ot=th:8;rv:abcdef01234567,vendor=value", span.TraceState().AsRaw())
+				continue
+			}
+			_, ok := span.Attributes().Get("http.target")
+			assert.False(t, ok, "health check spans must not be sampled")
+			if span.TraceState().AsRaw() == "" {
+				// sampled on the hash of the trace ID
+				hashed++
+				adjustedCount, ok := span.Attributes().Get(adjustedCountAttribute)
+				require.True(t, ok)
+				assert.Equal(t, 2.0, adjustedCount.Double())
+				continue
+			}
+			sampledConsistent++
+			assert.Regexp(t, "^ot=th:8;rv:[89a-f][0-9a-f]{13}$", span.TraceState().AsRaw())
+			_, ok = span.Attributes().Get(adjustedCountAttribute)
+			assert.False(t, ok)
+		}
+	}
+	assert.Equal(t, 25, errors)
+	assert.InDelta(t, 12, hashed, 10)
+	assert.Equal(t, consistent, sampledConsistent)
+}
+
 // Test_parseSpanSamplingPriority ensures that the function parsing the attributes is taking "sampling.priority"
 // attribute correctly.
 func Test_parseSpanSamplingPriority(t *testing.T) {
@@ -86,6 +86,20 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "pickle"),
+			expected: &Config{
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "localhost:2004",
+					Transport: "tcp",
+				},
+				TCPIdleTimeout: 30 * time.Second,
+				Parser: &protocol.Config{
+					Type:   "pickle",
+					Config: &protocol.PickleConfig{},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
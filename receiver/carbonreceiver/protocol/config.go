@@ -25,6 +25,7 @@ var (
 	// parserMap has all supported parsers and their respective default
 	// configuration.
 	parserMap = map[string]func() ParserConfig{
+		"pickle":    pickleDefaultConfig,
 		"plaintext": plaintextDefaultConfig,
 		"regex":     regexDefaultConfig,
 	}
@@ -55,6 +55,15 @@ func TestLoadParserConfig(t *testing.T) {
 					}},
 			},
 		},
+		{
+			name:   "default_pickle",
+			cfgMap: map[string]interface{}{"type": "pickle"},
+			cfg:    Config{Type: "pickle"},
+			want: Config{
+				Type:   "pickle",
+				Config: &PickleConfig{},
+			},
+		},
 		{
 			name:   "default_regex",
 			cfgMap: map[string]interface{}{"type": "regex"},
@@ -38,6 +38,17 @@ type Parser interface {
 	Parse(line string) (*metricspb.Metric, error)
 }
 
+// MessageParser is implemented by the parsers of binary protocols, like the
+// pickle protocol, whose messages are prefixed by their size as a 32 bits big
+// endian integer instead of being terminated by a new line.
+type MessageParser interface {
+	Parser
+
+	// ParseMessage receives a message, without its size prefix, and transforms
+	// the metrics it carries to the collector metric format.
+	ParseMessage(msg []byte) ([]*metricspb.Metric, error)
+}
+
 // Below a few helper functions useful to different parsers.
 func buildMetricForSinglePoint(
 	metricName string,
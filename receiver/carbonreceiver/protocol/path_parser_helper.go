@@ -120,36 +120,56 @@ func (pph *PathParserHelper) Parse(line string) (*metricspb.Metric, error) {
 		return nil, fmt.Errorf("invalid carbon metric time [%s]: %w", line, err)
 	}
 
-	var metricType metricspb.MetricDescriptor_Type
 	point := metricspb.Point{
 		Timestamp: convertUnixSec(unixTime),
 	}
+	if err = parsePointValue(valueStr, &point); err != nil {
+		return nil, fmt.Errorf("invalid carbon metric value [%s]: %w", line, err)
+	}
+
+	return buildMetricForParsedPath(&parsedPath, &point), nil
+}
+
+// parsePointValue sets the value of the point from its textual representation,
+// integers being preferred over floating point numbers.
+func parsePointValue(valueStr string, point *metricspb.Point) error {
 	intVal, err := strconv.ParseInt(valueStr, 10, 64)
 	if err == nil {
+		point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
+		return nil
+	}
+
+	dblVal, err := strconv.ParseFloat(valueStr, 64)
+	if err != nil {
+		return err
+	}
+	point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
+	return nil
+}
+
+// buildMetricForParsedPath builds the metric of a single point, its type
+// depending on the type of the value of the point and the MetricType of the
+// parsed path.
+func buildMetricForParsedPath(parsedPath *ParsedPath, point *metricspb.Point) *metricspb.Metric {
+	var metricType metricspb.MetricDescriptor_Type
+	if _, ok := point.Value.(*metricspb.Point_Int64Value); ok {
 		if parsedPath.MetricType == CumulativeMetricType {
 			metricType = metricspb.MetricDescriptor_CUMULATIVE_INT64
 		} else {
 			metricType = metricspb.MetricDescriptor_GAUGE_INT64
 		}
-		point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
 	} else {
-		dblVal, err := strconv.ParseFloat(valueStr, 64)
-		if err != nil {
-			return nil, fmt.Errorf("invalid carbon metric value [%s]: %w", line, err)
-		}
 		if parsedPath.MetricType == CumulativeMetricType {
 			metricType = metricspb.MetricDescriptor_CUMULATIVE_DOUBLE
 		} else {
 			metricType = metricspb.MetricDescriptor_GAUGE_DOUBLE
 		}
-		point.Value = &metricspb.Point_DoubleValue{DoubleValue: dblVal}
 	}
 
-	metric := buildMetricForSinglePoint(
+	return buildMetricForSinglePoint(
 		parsedPath.MetricName,
 		metricType,
 		parsedPath.LabelKeys,
 		parsedPath.LabelValues,
-		&point)
-	return metric, nil
+		point)
 }
@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"go.uber.org/multierr"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+var errPickleLine = errors.New("the pickle parser only parses pickle messages, the transport must be tcp")
+
+// PickleConfig holds the configuration for the pickle parser.
+type PickleConfig struct{}
+
+var _ (ParserConfig) = (*PickleConfig)(nil)
+
+// BuildParser creates a new Parser instance that receives Carbon data in the
+// pickle protocol.
+func (p *PickleConfig) BuildParser() (Parser, error) {
+	return &PickleParser{
+		pathParser: &PlaintextPathParser{},
+	}, nil
+}
+
+// PickleParser parses the messages of the Carbon pickle protocol, see
+// https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-pickle-protocol.
+// The metric paths are parsed as the plaintext parser does, including their
+// Graphite tags.
+type PickleParser struct {
+	pathParser PathParser
+}
+
+var _ MessageParser = (*PickleParser)(nil)
+
+// Parse always fails: the pickle protocol has no text lines.
+func (p *PickleParser) Parse(string) (*metricspb.Metric, error) {
+	return nil, errPickleLine
+}
+
+// ParseMessage receives a pickle message, without its size header, which is a
+// list of tuples in the following format:
+//
+//	[(<metric_path>, (<metric_timestamp>, <metric_value>)), ...]
+//
+// The metrics that can't be parsed are skipped and reported in the returned
+// error, together with the metrics that were parsed.
+func (p *PickleParser) ParseMessage(msg []byte) ([]*metricspb.Metric, error) {
+	v, err := unpickle(msg)
+	if err != nil {
+		return nil, fmt.Errorf("invalid carbon pickle message: %w", err)
+	}
+	items, ok := pickleSequence(v)
+	if !ok {
+		return nil, fmt.Errorf("invalid carbon pickle message: expected a list of metrics, got %T", v)
+	}
+
+	metrics := make([]*metricspb.Metric, 0, len(items))
+	var errs error
+	for _, item := range items {
+		metric, err := p.parseItem(item)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			continue
+		}
+		metrics = append(metrics, metric)
+	}
+	return metrics, errs
+}
+
+func (p *PickleParser) parseItem(item interface{}) (*metricspb.Metric, error) {
+	pathAndPoint, ok := pickleSequence(item)
+	if !ok || len(pathAndPoint) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle metric %v: expected (path, (timestamp, value))", item)
+	}
+	path, ok := pickleString(pathAndPoint[0])
+	if !ok {
+		return nil, fmt.Errorf("invalid carbon pickle metric %v: the path isn't a string", item)
+	}
+	timestampAndValue, ok := pickleSequence(pathAndPoint[1])
+	if !ok || len(timestampAndValue) != 2 {
+		return nil, fmt.Errorf("invalid carbon pickle metric [%s]: expected (timestamp, value)", path)
+	}
+
+	parsedPath := ParsedPath{}
+	if err := p.pathParser.ParsePath(path, &parsedPath); err != nil {
+		return nil, fmt.Errorf("invalid carbon pickle metric [%s]: %w", path, err)
+	}
+
+	point := metricspb.Point{}
+	switch ts := timestampAndValue[0].(type) {
+	case int64:
+		point.Timestamp = convertUnixSec(ts)
+	case float64:
+		sec, frac := math.Modf(ts)
+		point.Timestamp = &timestamppb.Timestamp{Seconds: int64(sec), Nanos: int32(frac * 1e9)}
+	default:
+		return nil, fmt.Errorf("invalid carbon pickle metric time [%s]: %v", path, timestampAndValue[0])
+	}
+
+	switch value := timestampAndValue[1].(type) {
+	case int64:
+		point.Value = &metricspb.Point_Int64Value{Int64Value: value}
+	case float64:
+		point.Value = &metricspb.Point_DoubleValue{DoubleValue: value}
+	case bool:
+		var intVal int64
+		if value {
+			intVal = 1
+		}
+		point.Value = &metricspb.Point_Int64Value{Int64Value: intVal}
+	default:
+		valueStr, ok := pickleString(value)
+		if !ok {
+			return nil, fmt.Errorf("invalid carbon pickle metric value [%s]: %v", path, value)
+		}
+		if err := parsePointValue(valueStr, &point); err != nil {
+			return nil, fmt.Errorf("invalid carbon pickle metric value [%s]: %w", path, err)
+		}
+	}
+
+	return buildMetricForParsedPath(&parsedPath, &point), nil
+}
+
+// pickleSequence returns the items of a pickled list or tuple.
+func pickleSequence(v interface{}) ([]interface{}, bool) {
+	switch s := v.(type) {
+	case *pickleList:
+		return s.items, true
+	case pickleTuple:
+		return s, true
+	}
+	return nil, false
+}
+
+// pickleString returns a pickled string, Python 3 senders may pickle the
+// paths as bytes.
+func pickleString(v interface{}) (string, bool) {
+	switch s := v.(type) {
+	case string:
+		return s, true
+	case []byte:
+		return string(s), true
+	}
+	return "", false
+}
+
+func pickleDefaultConfig() ParserConfig {
+	return &PickleConfig{}
+}
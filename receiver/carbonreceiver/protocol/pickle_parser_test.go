@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	metricspb "github.com/census-instrumentation/opencensus-proto/gen-go/metrics/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func TestPickleParser_ParseMessage(t *testing.T) {
+	p, err := (&PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	pp, ok := p.(MessageParser)
+	require.True(t, ok)
+
+	_, err = p.Parse("tst.int 1 1582230020")
+	assert.Error(t, err)
+
+	// Generated with pickle.dumps on:
+	// [('tst.int;k0=v0', (1582230020, 1)), ('tst.dbl', (1582230020.5, 3.14)), ('tst.long', (1582230020, -1234567890123))]
+	// using str paths but for protocol 4 where the last path is bytes.
+	want := []*metricspb.Metric{
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"tst.int",
+			[]string{"k0"},
+			[]string{"v0"},
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 1},
+			},
+		),
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_DOUBLE,
+			"tst.dbl",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020, Nanos: 500000000},
+				Value:     &metricspb.Point_DoubleValue{DoubleValue: 3.14},
+			},
+		),
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"tst.long",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_Int64Value{Int64Value: -1234567890123},
+			},
+		),
+	}
+
+	tests := []struct {
+		name string
+		msg  string
+	}{
+		{
+			name: "python2_protocol_0",
+			msg:  "(lp0\x0a(S'tst.int;k0=v0'\x0ap1\x0a(I1582230020\x0aI1\x0atp2\x0atp3\x0aa(S'tst.dbl'\x0ap4\x0a(F1582230020.5\x0aF3.14\x0atp5\x0atp6\x0aa(S'tst.long'\x0ap7\x0a(I1582230020\x0aL-1234567890123L\x0atp8\x0atp9\x0aa.",
+		},
+		{
+			name: "protocol_0",
+			msg:  "(lp0\x0a(Vtst.int;k0=v0\x0ap1\x0a(I1582230020\x0aI1\x0atp2\x0atp3\x0aa(Vtst.dbl\x0ap4\x0a(F1582230020.5\x0aF3.14\x0atp5\x0atp6\x0aa(Vtst.long\x0ap7\x0a(I1582230020\x0aL-1234567890123L\x0atp8\x0atp9\x0aa.",
+		},
+		{
+			name: "protocol_2",
+			msg:  "\x80\x02]q\x00(X\x0d\x00\x00\x00tst.int;k0=v0q\x01J\x04\xeaN^K\x01\x86q\x02\x86q\x03X\x07\x00\x00\x00tst.dblq\x04GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1f\x86q\x05\x86q\x06X\x08\x00\x00\x00tst.longq\x07J\x04\xeaN^\x8a\x065\xfb\x04\x8e\xe0\xfe\x86q\x08\x86q\x09e.",
+		},
+		{
+			name: "protocol_4",
+			msg:  "\x80\x04\x95\x5c\x00\x00\x00\x00\x00\x00\x00]\x94(\x8c\x0dtst.int;k0=v0\x94J\x04\xeaN^K\x01\x86\x94\x86\x94\x8c\x07tst.dbl\x94GA\xd7\x93\xba\x81 \x00\x00G@\x09\x1e\xb8Q\xeb\x85\x1f\x86\x94\x86\x94C\x08tst.long\x94J\x04\xeaN^\x8a\x065\xfb\x04\x8e\xe0\xfe\x86\x94\x86\x94e.",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pp.ParseMessage([]byte(tt.msg))
+			require.NoError(t, err)
+			assert.Equal(t, want, got)
+		})
+	}
+}
+
+func TestPickleParser_ParseMessageErrors(t *testing.T) {
+	p, err := (&PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	pp := p.(MessageParser)
+
+	// [('tst.ok', (1582230020, 1)), ('tst.bad', ('now', 1)), (';nope', (1582230020, 1)), 42]
+	got, err := pp.ParseMessage([]byte("\x80\x02]q\x00(X\x06\x00\x00\x00tst.okq\x01J\x04\xeaN^K\x01\x86q\x02\x86q\x03X\x07\x00\x00\x00tst.badq\x04X\x03\x00\x00\x00nowq\x05K\x01\x86q\x06\x86q\x07X\x05\x00\x00\x00;nopeq\x08h\x02\x86q\x09K*e."))
+	assert.EqualError(t, err, "invalid carbon pickle metric time [tst.bad]: now; "+
+		"invalid carbon pickle metric [;nope]: empty metric name extracted from path [;nope]; "+
+		"invalid carbon pickle metric 42: expected (path, (timestamp, value))")
+	assert.Equal(t, []*metricspb.Metric{
+		buildMetric(
+			metricspb.MetricDescriptor_GAUGE_INT64,
+			"tst.ok",
+			nil,
+			nil,
+			&metricspb.Point{
+				Timestamp: &timestamppb.Timestamp{Seconds: 1582230020},
+				Value:     &metricspb.Point_Int64Value{Int64Value: 1},
+			},
+		),
+	}, got)
+
+	tests := []struct {
+		name    string
+		msg     string
+		wantErr string
+	}{
+		{
+			// [('tst', (1, b'x'))] pickled with protocol 2 calls _codecs.encode.
+			name:    "global",
+			msg:     "\x80\x02]q\x00X\x03\x00\x00\x00tstq\x01K\x01c_codecs\x0aencode\x0aq\x02X\x01\x00\x00\x00xq\x03X\x06\x00\x00\x00latin1q\x04\x86q\x05Rq\x06\x86q\x07\x86q\x08a.",
+			wantErr: `invalid carbon pickle message: unsupported pickle opcode 'c' at offset 17`,
+		},
+		{
+			name:    "truncated",
+			msg:     "\x80\x02]q\x00X\x03\x00\x00\x00ts",
+			wantErr: "invalid carbon pickle message: unexpected end of pickle data",
+		},
+		{
+			name:    "not_a_list",
+			msg:     "\x80\x02K\x01.",
+			wantErr: "invalid carbon pickle message: expected a list of metrics, got int64",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := pp.ParseMessage([]byte(tt.msg))
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, got)
+		})
+	}
+}
@@ -49,20 +49,28 @@ type PlaintextPathParser struct{}
 // tag is of the form "key=val", where key can contain any char except ";!^=" and
 // val can contain any char except ";~".
 func (p *PlaintextPathParser) ParsePath(path string, parsedPath *ParsedPath) error {
+	name, keys, values, err := splitTags(path)
+	if err != nil {
+		return err
+	}
+
+	parsedPath.MetricName = name
+	parsedPath.LabelKeys = keys
+	parsedPath.LabelValues = values
+	return nil
+}
+
+// splitTags splits a <metric_path> into the metric name and the label keys and
+// values of its Graphite tags, see PlaintextPathParser.ParsePath for the format.
+func splitTags(path string) (string, []*metricspb.LabelKey, []*metricspb.LabelValue, error) {
 	parts := strings.SplitN(path, ";", 2)
 	if len(parts) < 1 || parts[0] == "" {
-		return fmt.Errorf("empty metric name extracted from path [%s]", path)
+		return "", nil, nil, fmt.Errorf("empty metric name extracted from path [%s]", path)
 	}
 
-	parsedPath.MetricName = parts[0]
-	if len(parts) == 1 {
+	if len(parts) == 1 || parts[1] == "" {
 		// No tags, no more work here.
-		return nil
-	}
-
-	if parts[1] == "" {
-		// Empty tags, nothing to do.
-		return nil
+		return parts[0], nil, nil, nil
 	}
 
 	tags := strings.Split(parts[1], ";")
@@ -71,7 +79,7 @@ func (p *PlaintextPathParser) ParsePath(path string, parsedPath *ParsedPath) err
 	for _, tag := range tags {
 		idx := strings.IndexByte(tag, '=')
 		if idx < 1 {
-			return fmt.Errorf("cannot parse metric path [%s]: incorrect key value separator for [%s]", path, tag)
+			return "", nil, nil, fmt.Errorf("cannot parse metric path [%s]: incorrect key value separator for [%s]", path, tag)
 		}
 
 		key := tag[:idx]
@@ -84,9 +92,7 @@ func (p *PlaintextPathParser) ParsePath(path string, parsedPath *ParsedPath) err
 		})
 	}
 
-	parsedPath.LabelKeys = keys
-	parsedPath.LabelValues = values
-	return nil
+	return parts[0], keys, values, nil
 }
 
 func plaintextDefaultConfig() ParserConfig {
@@ -162,23 +162,27 @@ type regexPathParser struct {
 	rules []*RegexRule
 
 	metricNameSeparator string
-
-	// plaintextParser is used if no rule matches a given metric.
-	plaintextPathParser PlaintextPathParser
 }
 
 // ParsePath converts the <metric_path> of a Carbon line (see PathParserHelper
 // a full description of the line format) according to the RegexParserConfig
-// settings.
+// settings. The rules are matched against the metric name, without its Graphite
+// tags, and the tags are added to the labels extracted by the matching rule,
+// except the tags whose key is already a label of the rule.
 func (rpp *regexPathParser) ParsePath(path string, parsedPath *ParsedPath) error {
+	name, tagKeys, tagValues, err := splitTags(path)
+	if err != nil {
+		return err
+	}
+
 	for _, rule := range rpp.rules {
-		if rule.compRegexp.MatchString(path) {
-			ms := rule.compRegexp.FindStringSubmatch(path)
+		if rule.compRegexp.MatchString(name) {
+			ms := rule.compRegexp.FindStringSubmatch(name)
 			nms := rule.compRegexp.SubexpNames() // regexp pre-computes this slice.
 			metricNameLookup := map[string]string{}
 
-			keys := make([]*metricspb.LabelKey, 0, len(nms)+len(rule.Labels))
-			values := make([]*metricspb.LabelValue, 0, len(nms)+len(rule.Labels))
+			keys := make([]*metricspb.LabelKey, 0, len(nms)+len(rule.Labels)+len(tagKeys))
+			values := make([]*metricspb.LabelValue, 0, len(nms)+len(rule.Labels)+len(tagValues))
 			for i := 1; i < len(ms); i++ {
 				if strings.HasPrefix(nms[i], metricNameCapturePrefix) {
 					metricNameLookup[nms[i]] = ms[i]
@@ -199,6 +203,19 @@ func (rpp *regexPathParser) ParsePath(path string, parsedPath *ParsedPath) error
 				})
 			}
 
+			// the labels extracted by the rule take precedence over the tags with the same key
+			extracted := make(map[string]struct{}, len(keys))
+			for _, k := range keys {
+				extracted[k.Key] = struct{}{}
+			}
+			for i, k := range tagKeys {
+				if _, ok := extracted[k.Key]; ok {
+					continue
+				}
+				keys = append(keys, k)
+				values = append(values, tagValues[i])
+			}
+
 			var actualMetricName string
 			if len(rule.metricNameParts) == 0 {
 				actualMetricName = rule.NamePrefix
@@ -213,7 +230,7 @@ func (rpp *regexPathParser) ParsePath(path string, parsedPath *ParsedPath) error
 			}
 
 			if actualMetricName == "" {
-				actualMetricName = path
+				actualMetricName = name
 			}
 
 			parsedPath.MetricName = actualMetricName
@@ -224,7 +241,10 @@ func (rpp *regexPathParser) ParsePath(path string, parsedPath *ParsedPath) error
 		}
 	}
 
-	return rpp.plaintextPathParser.ParsePath(path, parsedPath)
+	parsedPath.MetricName = name
+	parsedPath.LabelKeys = tagKeys
+	parsedPath.LabelValues = tagValues
+	return nil
 }
 
 func regexDefaultConfig() ParserConfig {
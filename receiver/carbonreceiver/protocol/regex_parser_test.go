@@ -176,6 +176,53 @@ func Test_regexParser_parsePath(t *testing.T) {
 			},
 			wantMetricType: GaugeMetricType,
 		},
+		{
+			name:     "match_rule1_with_tags",
+			path:     "service_name.host01.rpc.count;dc=eu;rack=r1",
+			wantName: "rpc",
+			wantKeys: []*metricspb.LabelKey{
+				{Key: "svc"},
+				{Key: "host"},
+				{Key: "dc"},
+				{Key: "rack"},
+			},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "service_name", HasValue: true},
+				{Value: "host01", HasValue: true},
+				{Value: "eu", HasValue: true},
+				{Value: "r1", HasValue: true},
+			},
+			wantMetricType: CumulativeMetricType,
+		},
+		{
+			name:     "match_rule0_with_duplicate_tags",
+			path:     "service_name.host00.cpu.seconds;host=tagged;k=tagged;dc=eu",
+			wantName: "cpu_seconds",
+			wantKeys: []*metricspb.LabelKey{
+				{Key: "svc"},
+				{Key: "host"},
+				{Key: "k"},
+				{Key: "dc"},
+			},
+			wantValues: []*metricspb.LabelValue{
+				{Value: "service_name", HasValue: true},
+				{Value: "host00", HasValue: true},
+				{Value: "v", HasValue: true},
+				{Value: "eu", HasValue: true},
+			},
+		},
+		{
+			name:       "no_rule_match_with_tags",
+			path:       "service_name.host01.rpc.duration.seconds;dc=eu",
+			wantName:   "service_name.host01.rpc.duration.seconds",
+			wantKeys:   []*metricspb.LabelKey{{Key: "dc"}},
+			wantValues: []*metricspb.LabelValue{{Value: "eu", HasValue: true}},
+		},
+		{
+			name:    "invalid_tags",
+			path:    "service_name.host01.rpc.count;dc",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
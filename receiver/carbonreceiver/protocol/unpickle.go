@@ -0,0 +1,485 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/carbonreceiver/protocol"
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Opcodes of the pickle format, see https://github.com/python/cpython/blob/main/Lib/pickletools.py.
+// Only the opcodes building the basic types used by the Carbon pickle protocol
+// are supported: the opcodes creating arbitrary objects, like GLOBAL or REDUCE,
+// are rejected as the Graphite "safe unpickler" does.
+const (
+	opMark            = '('
+	opStop            = '.'
+	opPop             = '0'
+	opPopMark         = '1'
+	opInt             = 'I'
+	opLong            = 'L'
+	opFloat           = 'F'
+	opString          = 'S'
+	opUnicode         = 'V'
+	opNone            = 'N'
+	opBinInt          = 'J'
+	opBinInt1         = 'K'
+	opBinInt2         = 'M'
+	opBinFloat        = 'G'
+	opBinString       = 'T'
+	opShortBinString  = 'U'
+	opBinUnicode      = 'X'
+	opBinBytes        = 'B'
+	opShortBinBytes   = 'C'
+	opEmptyList       = ']'
+	opList            = 'l'
+	opAppend          = 'a'
+	opAppends         = 'e'
+	opEmptyTuple      = ')'
+	opTuple           = 't'
+	opPut             = 'p'
+	opBinPut          = 'q'
+	opLongBinPut      = 'r'
+	opGet             = 'g'
+	opBinGet          = 'h'
+	opLongBinGet      = 'j'
+	opProto           = '\x80'
+	opTuple1          = '\x85'
+	opTuple2          = '\x86'
+	opTuple3          = '\x87'
+	opNewTrue         = '\x88'
+	opNewFalse        = '\x89'
+	opLong1           = '\x8a'
+	opShortBinUnicode = '\x8c'
+	opBinUnicode8     = '\x8d'
+	opBinBytes8       = '\x8e'
+	opMemoize         = '\x94'
+	opFrame           = '\x95'
+)
+
+var (
+	errUnexpectedEnd  = errors.New("unexpected end of pickle data")
+	errStackUnderflow = errors.New("pickle stack underflow")
+)
+
+// pickleList is a Python list, referenced so that the memoized lists are
+// updated by the APPEND opcodes.
+type pickleList struct {
+	items []interface{}
+}
+
+// pickleTuple is a Python tuple.
+type pickleTuple []interface{}
+
+// unpickler decodes the values of a pickle message: None (nil), bool, int64,
+// float64, string ([]byte for bytes), *pickleList and pickleTuple.
+type unpickler struct {
+	data  []byte
+	pos   int
+	stack []interface{}
+	marks []int
+	memo  map[int]interface{}
+}
+
+// unpickle decodes the value of a pickle message.
+func unpickle(data []byte) (interface{}, error) {
+	u := &unpickler{
+		data: data,
+		memo: map[int]interface{}{},
+	}
+	return u.run()
+}
+
+func (u *unpickler) run() (interface{}, error) {
+	for {
+		op, err := u.readByte()
+		if err != nil {
+			return nil, err
+		}
+
+		switch op {
+		case opStop:
+			return u.pop()
+		case opProto:
+			_, err = u.readByte()
+		case opFrame:
+			_, err = u.read(8)
+		case opMark:
+			u.marks = append(u.marks, len(u.stack))
+		case opPop:
+			_, err = u.pop()
+		case opPopMark:
+			_, err = u.popMark()
+		case opNone:
+			u.push(nil)
+		case opNewTrue:
+			u.push(true)
+		case opNewFalse:
+			u.push(false)
+		case opInt:
+			err = u.loadInt()
+		case opLong:
+			err = u.loadTextLine(func(line string) (interface{}, error) {
+				return strconv.ParseInt(strings.TrimSuffix(line, "L"), 10, 64)
+			})
+		case opFloat:
+			err = u.loadTextLine(func(line string) (interface{}, error) {
+				return strconv.ParseFloat(line, 64)
+			})
+		case opString:
+			err = u.loadTextLine(unquoteString)
+		case opUnicode:
+			err = u.loadTextLine(unquoteUnicode)
+		case opBinInt:
+			err = u.loadBinInt(4, func(b []byte) int64 { return int64(int32(binary.LittleEndian.Uint32(b))) })
+		case opBinInt1:
+			err = u.loadBinInt(1, func(b []byte) int64 { return int64(b[0]) })
+		case opBinInt2:
+			err = u.loadBinInt(2, func(b []byte) int64 { return int64(binary.LittleEndian.Uint16(b)) })
+		case opLong1:
+			err = u.loadLong1()
+		case opBinFloat:
+			var b []byte
+			if b, err = u.read(8); err == nil {
+				u.push(math.Float64frombits(binary.BigEndian.Uint64(b)))
+			}
+		case opShortBinString, opShortBinUnicode:
+			err = u.loadSizedString(1, false)
+		case opBinString, opBinUnicode:
+			err = u.loadSizedString(4, false)
+		case opBinUnicode8:
+			err = u.loadSizedString(8, false)
+		case opShortBinBytes:
+			err = u.loadSizedString(1, true)
+		case opBinBytes:
+			err = u.loadSizedString(4, true)
+		case opBinBytes8:
+			err = u.loadSizedString(8, true)
+		case opEmptyList:
+			u.push(&pickleList{})
+		case opList:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				u.push(&pickleList{items: items})
+			}
+		case opAppend:
+			err = u.appendItems(1)
+		case opAppends:
+			err = u.appendMarkedItems()
+		case opEmptyTuple:
+			u.push(pickleTuple{})
+		case opTuple:
+			var items []interface{}
+			if items, err = u.popMark(); err == nil {
+				u.push(pickleTuple(items))
+			}
+		case opTuple1, opTuple2, opTuple3:
+			err = u.loadTuple(int(op-opTuple1) + 1)
+		case opPut:
+			err = u.loadTextIndex(u.put)
+		case opBinPut:
+			err = u.loadBinIndex(1, u.put)
+		case opLongBinPut:
+			err = u.loadBinIndex(4, u.put)
+		case opMemoize:
+			err = u.put(len(u.memo))
+		case opGet:
+			err = u.loadTextIndex(u.get)
+		case opBinGet:
+			err = u.loadBinIndex(1, u.get)
+		case opLongBinGet:
+			err = u.loadBinIndex(4, u.get)
+		default:
+			return nil, fmt.Errorf("unsupported pickle opcode %q at offset %d", op, u.pos-1)
+		}
+
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+func (u *unpickler) read(n int) ([]byte, error) {
+	if n < 0 || len(u.data)-u.pos < n {
+		return nil, errUnexpectedEnd
+	}
+	b := u.data[u.pos : u.pos+n]
+	u.pos += n
+	return b, nil
+}
+
+func (u *unpickler) readByte() (byte, error) {
+	b, err := u.read(1)
+	if err != nil {
+		return 0, err
+	}
+	return b[0], nil
+}
+
+func (u *unpickler) readLine() (string, error) {
+	idx := bytes.IndexByte(u.data[u.pos:], '\n')
+	if idx < 0 {
+		return "", errUnexpectedEnd
+	}
+	line := string(u.data[u.pos : u.pos+idx])
+	u.pos += idx + 1
+	return line, nil
+}
+
+func (u *unpickler) push(v interface{}) {
+	u.stack = append(u.stack, v)
+}
+
+// floor returns the position of the innermost mark: the items below it can only
+// be reached once the mark is popped, as the Python unpickler does.
+func (u *unpickler) floor() int {
+	if len(u.marks) == 0 {
+		return 0
+	}
+	return u.marks[len(u.marks)-1]
+}
+
+func (u *unpickler) pop() (interface{}, error) {
+	if len(u.stack) <= u.floor() {
+		return nil, errStackUnderflow
+	}
+	v := u.stack[len(u.stack)-1]
+	u.stack = u.stack[:len(u.stack)-1]
+	return v, nil
+}
+
+func (u *unpickler) top() (interface{}, error) {
+	if len(u.stack) <= u.floor() {
+		return nil, errStackUnderflow
+	}
+	return u.stack[len(u.stack)-1], nil
+}
+
+// popMark pops the items pushed since the last mark.
+func (u *unpickler) popMark() ([]interface{}, error) {
+	if len(u.marks) == 0 {
+		return nil, errors.New("pickle mark not found")
+	}
+	mark := u.marks[len(u.marks)-1]
+	u.marks = u.marks[:len(u.marks)-1]
+	if mark > len(u.stack) {
+		return nil, errStackUnderflow
+	}
+	items := make([]interface{}, len(u.stack)-mark)
+	copy(items, u.stack[mark:])
+	u.stack = u.stack[:mark]
+	return items, nil
+}
+
+func (u *unpickler) loadTextLine(parse func(string) (interface{}, error)) error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+	v, err := parse(line)
+	if err != nil {
+		return fmt.Errorf("invalid pickle value %q: %w", line, err)
+	}
+	u.push(v)
+	return nil
+}
+
+func (u *unpickler) loadInt() error {
+	return u.loadTextLine(func(line string) (interface{}, error) {
+		// Python 2 pickles booleans as the INT opcode with these special values.
+		switch line {
+		case "00":
+			return false, nil
+		case "01":
+			return true, nil
+		}
+		return strconv.ParseInt(line, 10, 64)
+	})
+}
+
+func (u *unpickler) loadBinInt(n int, decode func([]byte) int64) error {
+	b, err := u.read(n)
+	if err != nil {
+		return err
+	}
+	u.push(decode(b))
+	return nil
+}
+
+// loadLong1 loads a little endian two's complement integer, which must fit
+// in 64 bits.
+func (u *unpickler) loadLong1() error {
+	n, err := u.readByte()
+	if err != nil {
+		return err
+	}
+	b, err := u.read(int(n))
+	if err != nil {
+		return err
+	}
+	if len(b) > 8 {
+		return fmt.Errorf("pickle integer of %d bytes overflows 64 bits", len(b))
+	}
+
+	var v uint64
+	for i := len(b) - 1; i >= 0; i-- {
+		v = v<<8 | uint64(b[i])
+	}
+	if len(b) > 0 && len(b) < 8 && b[len(b)-1]&0x80 != 0 {
+		// extend the sign
+		v |= math.MaxUint64 << (8 * uint(len(b)))
+	}
+	u.push(int64(v))
+	return nil
+}
+
+func (u *unpickler) loadSizedString(sizeLen int, asBytes bool) error {
+	b, err := u.read(sizeLen)
+	if err != nil {
+		return err
+	}
+
+	var size uint64
+	switch sizeLen {
+	case 1:
+		size = uint64(b[0])
+	case 4:
+		size = uint64(binary.LittleEndian.Uint32(b))
+	default:
+		size = binary.LittleEndian.Uint64(b)
+	}
+	if size > uint64(len(u.data)-u.pos) {
+		return errUnexpectedEnd
+	}
+
+	s, err := u.read(int(size))
+	if err != nil {
+		return err
+	}
+	if asBytes {
+		u.push(append([]byte(nil), s...))
+	} else {
+		u.push(string(s))
+	}
+	return nil
+}
+
+func (u *unpickler) loadTuple(n int) error {
+	if len(u.stack)-u.floor() < n {
+		return errStackUnderflow
+	}
+	items := make(pickleTuple, n)
+	copy(items, u.stack[len(u.stack)-n:])
+	u.stack = u.stack[:len(u.stack)-n]
+	u.push(items)
+	return nil
+}
+
+func (u *unpickler) appendItems(n int) error {
+	if len(u.stack)-u.floor() < n+1 {
+		return errStackUnderflow
+	}
+	items := u.stack[len(u.stack)-n:]
+	list, ok := u.stack[len(u.stack)-n-1].(*pickleList)
+	if !ok {
+		return errors.New("pickle append to a value which isn't a list")
+	}
+	list.items = append(list.items, items...)
+	u.stack = u.stack[:len(u.stack)-n]
+	return nil
+}
+
+func (u *unpickler) appendMarkedItems() error {
+	items, err := u.popMark()
+	if err != nil {
+		return err
+	}
+	top, err := u.top()
+	if err != nil {
+		return err
+	}
+	list, ok := top.(*pickleList)
+	if !ok {
+		return errors.New("pickle append to a value which isn't a list")
+	}
+	list.items = append(list.items, items...)
+	return nil
+}
+
+func (u *unpickler) loadTextIndex(fn func(int) error) error {
+	line, err := u.readLine()
+	if err != nil {
+		return err
+	}
+	idx, err := strconv.Atoi(line)
+	if err != nil {
+		return fmt.Errorf("invalid pickle memo index %q: %w", line, err)
+	}
+	return fn(idx)
+}
+
+func (u *unpickler) loadBinIndex(n int, fn func(int) error) error {
+	b, err := u.read(n)
+	if err != nil {
+		return err
+	}
+	if n == 1 {
+		return fn(int(b[0]))
+	}
+	return fn(int(binary.LittleEndian.Uint32(b)))
+}
+
+func (u *unpickler) put(idx int) error {
+	v, err := u.top()
+	if err != nil {
+		return err
+	}
+	u.memo[idx] = v
+	return nil
+}
+
+func (u *unpickler) get(idx int) error {
+	v, ok := u.memo[idx]
+	if !ok {
+		return fmt.Errorf("pickle memo index %d not found", idx)
+	}
+	u.push(v)
+	return nil
+}
+
+// unquoteString decodes the repr of a Python 2 string, e.g. 'foo.bar'.
+func unquoteString(line string) (interface{}, error) {
+	if len(line) < 2 || line[0] != line[len(line)-1] || (line[0] != '\'' && line[0] != '"') {
+		return nil, errors.New("string is not quoted")
+	}
+	s := line[1 : len(line)-1]
+	if !strings.Contains(s, `\`) {
+		return s, nil
+	}
+	return strconv.Unquote(`"` + strings.NewReplacer(`\'`, `'`, `"`, `\"`).Replace(s) + `"`)
+}
+
+// unquoteUnicode decodes a raw-unicode-escape encoded string.
+func unquoteUnicode(line string) (interface{}, error) {
+	if !strings.Contains(line, `\u`) && !strings.Contains(line, `\U`) {
+		return line, nil
+	}
+	return strconv.Unquote(`"` + strings.NewReplacer(`\u`, `\u`, `\U`, `\U`, `\`, `\\`, `"`, `\"`).Replace(line) + `"`)
+}
@@ -0,0 +1,114 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package protocol
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUnpickle(t *testing.T) {
+	// [('a', (1, 2.5)), ('b', None)]
+	got, err := unpickle([]byte("\x80\x02]q\x00(X\x01\x00\x00\x00aK\x01G@\x04\x00\x00\x00\x00\x00\x00\x86\x86X\x01\x00\x00\x00bN\x86e."))
+	require.NoError(t, err)
+	assert.Equal(t, &pickleList{items: []interface{}{
+		pickleTuple{"a", pickleTuple{int64(1), 2.5}},
+		pickleTuple{"b", nil},
+	}}, got)
+}
+
+func TestUnpickleErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{
+			name:    "pop_below_mark_then_tuple",
+			data:    "N(0t.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "pop_below_mark_then_list",
+			data:    "N(0l.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "pop_below_mark_then_pop_mark",
+			data:    "N(01.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "pop_below_mark_then_appends",
+			data:    "](0e.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "append_below_mark",
+			data:    "]N(a.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "tuple1_below_mark",
+			data:    "N(\x85.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "tuple2_across_mark",
+			data:    "N(N\x86.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "stop_below_mark",
+			data:    "N(.",
+			wantErr: "pickle stack underflow",
+		},
+		{
+			name:    "pop_mark_without_mark",
+			data:    "N1.",
+			wantErr: "pickle mark not found",
+		},
+		{
+			name:    "empty",
+			data:    "",
+			wantErr: "unexpected end of pickle data",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := unpickle([]byte(tt.data))
+			assert.EqualError(t, err, tt.wantErr)
+			assert.Nil(t, got)
+		})
+	}
+}
+
+func FuzzUnpickle(f *testing.F) {
+	for _, seed := range []string{
+		"\x80\x02]q\x00(X\x01\x00\x00\x00aK\x01G@\x04\x00\x00\x00\x00\x00\x00\x86\x86X\x01\x00\x00\x00bN\x86e.",
+		"(lp0\n(S'tst.a'\np1\n(I1582230020\nF1.5\ntp2\ntp3\na.",
+		"N(0t.",
+		"](0e.",
+		"]N(a.",
+	} {
+		f.Add([]byte(seed))
+	}
+	f.Fuzz(func(t *testing.T, data []byte) {
+		// only checks that malformed messages don't panic
+		_, _ = unpickle(data)
+	})
+}
@@ -32,7 +32,7 @@ var (
 	errEmptyEndpoint = errors.New("empty endpoint")
 )
 
-// carbonreceiver implements a receiver.Metrics for Carbon plaintext, aka "line", protocol.
+// carbonreceiver implements a receiver.Metrics for Carbon plaintext, aka "line", and pickle protocols.
 // see https://graphite.readthedocs.io/en/latest/feeding-carbon.html#the-plaintext-protocol.
 type carbonReceiver struct {
 	settings receiver.CreateSettings
@@ -74,6 +74,10 @@ func New(
 		return nil, err
 	}
 
+	if _, ok := parser.(protocol.MessageParser); ok && strings.ToLower(config.Transport) == "udp" {
+		return nil, fmt.Errorf("parser %q is not supported by the udp transport", config.Parser.Type)
+	}
+
 	// This should be the last one built, or if any other error is raised after
 	// it, the server should be closed.
 	server, err := buildTransportServer(config)
@@ -122,6 +122,23 @@ func Test_carbonreceiver_New(t *testing.T) {
 				nextConsumer: consumertest.NewNop(),
 			},
 		},
+		{
+			name: "pickle_parser_udp",
+			args: args{
+				config: Config{
+					NetAddr: confignet.NetAddr{
+						Endpoint:  "localhost:2003",
+						Transport: "udp",
+					},
+					Parser: &protocol.Config{
+						Type:   "pickle",
+						Config: &protocol.PickleConfig{},
+					},
+				},
+				nextConsumer: consumertest.NewNop(),
+			},
+			wantErr: errors.New("parser \"pickle\" is not supported by the udp transport"),
+		},
 		{
 			name: "negative_tcp_idle_timeout",
 			args: args{
@@ -15,6 +15,8 @@
 package transport
 
 import (
+	"encoding/binary"
+	"net"
 	"runtime"
 	"sync"
 	"testing"
@@ -109,3 +111,54 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func Test_TCPServer_ListenAndServePickle(t *testing.T) {
+	addr := testutil.GetAvailableLocalNetworkAddress(t, "tcp")
+
+	svr, err := NewTCPServer(addr, 1*time.Second)
+	require.NoError(t, err)
+
+	mc := new(consumertest.MetricsSink)
+	p, err := (&protocol.PickleConfig{}).BuildParser()
+	require.NoError(t, err)
+	mr := NewMockReporter(2)
+
+	wgListenAndServe := sync.WaitGroup{}
+	wgListenAndServe.Add(1)
+	go func() {
+		defer wgListenAndServe.Done()
+		assert.Error(t, svr.ListenAndServe(p, mc, mr))
+	}()
+
+	runtime.Gosched()
+
+	conn, err := net.Dial("tcp", addr)
+	require.NoError(t, err)
+
+	// [('test.metric;k0=v0', (1582230020, 1)), ('test.metric2', (1582230020, 2.5))] and
+	// [('test.metric3', (1582230020, 3))] pickled with protocol 2.
+	for _, msg := range []string{
+		"\x80\x02]q\x00(X\x11\x00\x00\x00test.metric;k0=v0q\x01J\x04\xeaN^K\x01\x86q\x02\x86q\x03X\x0c\x00\x00\x00test.metric2q\x04J\x04\xeaN^G@\x04\x00\x00\x00\x00\x00\x00\x86q\x05\x86q\x06e.",
+		"\x80\x02]q\x00X\x0c\x00\x00\x00test.metric3q\x01J\x04\xeaN^K\x03\x86q\x02\x86q\x03a.",
+	} {
+		header := make([]byte, 4)
+		binary.BigEndian.PutUint32(header, uint32(len(msg)))
+		_, err = conn.Write(append(header, msg...))
+		require.NoError(t, err)
+	}
+	require.NoError(t, conn.Close())
+
+	mr.WaitAllOnMetricsProcessedCalls()
+
+	assert.NoError(t, svr.Close())
+	wgListenAndServe.Wait()
+
+	mdd := mc.AllMetrics()
+	require.Len(t, mdd, 2)
+	assert.Equal(t, 3, mdd[0].MetricCount()+mdd[1].MetricCount())
+	_, _, metrics := internaldata.ResourceMetricsToOC(mdd[0].ResourceMetrics().At(0))
+	require.Len(t, metrics, 2)
+	assert.Equal(t, "test.metric", metrics[0].GetMetricDescriptor().GetName())
+	assert.Equal(t, "k0", metrics[0].GetMetricDescriptor().GetLabelKeys()[0].GetKey())
+	assert.Equal(t, "test.metric2", metrics[1].GetMetricDescriptor().GetName())
+}
@@ -17,6 +17,7 @@ package transport // import "github.com/open-telemetry/opentelemetry-collector-c
 import (
 	"bufio"
 	"context"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"io"
@@ -36,6 +37,10 @@ import (
 const (
 	// TCPIdleTimeoutDefault is the default timeout for idle TCP connections.
 	TCPIdleTimeoutDefault = 30 * time.Second
+
+	// maxMessageSize is the maximum size of the messages of the binary
+	// protocols, as accepted by Carbon.
+	maxMessageSize = 1 << 20
 )
 
 type tcpServer struct {
@@ -146,6 +151,11 @@ func (t *tcpServer) handleConnection(
 	conn net.Conn,
 ) {
 	defer conn.Close()
+	if mp, ok := p.(protocol.MessageParser); ok {
+		t.handleMessages(mp, nextConsumer, conn)
+		return
+	}
+
 	var span *trace.Span
 	reader := bufio.NewReader(conn)
 	for {
@@ -218,3 +228,63 @@ func (t *tcpServer) handleConnection(
 		}
 	}
 }
+
+// handleMessages handles the connections of the binary protocols, whose
+// messages are prefixed by their size as a 32 bits big endian integer.
+func (t *tcpServer) handleMessages(
+	p protocol.MessageParser,
+	nextConsumer consumer.Metrics,
+	conn net.Conn,
+) {
+	reader := bufio.NewReader(conn)
+	header := make([]byte, 4)
+	for {
+		if err := conn.SetDeadline(time.Now().Add(t.idleTimeout)); err != nil {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - conn.SetDeadLine error: %v",
+				t.ln.Addr(),
+				err)
+			return
+		}
+
+		// Idle timeouts, closed connections and truncated messages all end the
+		// connection, since the next message can't be found anymore.
+		if _, err := io.ReadFull(reader, header); err != nil {
+			t.reporter.OnDebugf("TCP Transport (%s) - error: %v", t.ln.Addr(), err)
+			return
+		}
+		size := binary.BigEndian.Uint32(header)
+		if size > maxMessageSize {
+			t.reporter.OnDebugf(
+				"TCP Transport (%s) - message of %d bytes exceeds the maximum size of %d bytes",
+				t.ln.Addr(),
+				size,
+				maxMessageSize)
+			return
+		}
+		msg := make([]byte, size)
+		if _, err := io.ReadFull(reader, msg); err != nil {
+			t.reporter.OnDebugf("TCP Transport (%s) - error: %v", t.ln.Addr(), err)
+			return
+		}
+
+		ctx := t.reporter.OnDataReceived(context.Background())
+		metrics, err := p.ParseMessage(msg)
+		if err != nil {
+			// The message may be partially translated, its valid metrics are
+			// still sent.
+			t.reporter.OnTranslationError(ctx, err)
+		}
+		if len(metrics) == 0 {
+			continue
+		}
+
+		err = nextConsumer.ConsumeMetrics(ctx, internaldata.OCToMetrics(nil, nil, metrics))
+		t.reporter.OnMetricsProcessed(ctx, len(metrics), err)
+		if err != nil {
+			// As for the plaintext protocol, close the connection to report the
+			// error back to the client.
+			return
+		}
+	}
+}
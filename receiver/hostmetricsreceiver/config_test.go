@@ -27,6 +27,7 @@ import (
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cpuscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/diskscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/filesystemscraper"
@@ -34,8 +35,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/memoryscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/networkscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pagingscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processesscraper"
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
 )
 
 func TestLoadConfig(t *testing.T) {
@@ -84,6 +87,23 @@ func TestLoadConfig(t *testing.T) {
 			})(),
 			processesscraper.TypeStr: (&processesscraper.Factory{}).CreateDefaultConfig(),
 			pagingscraper.TypeStr:    (&pagingscraper.Factory{}).CreateDefaultConfig(),
+			pressurescraper.TypeStr:  (&pressurescraper.Factory{}).CreateDefaultConfig(),
+			cgroupscraper.TypeStr: (func() internal.Config {
+				cfg := (&cgroupscraper.Factory{}).CreateDefaultConfig()
+				cfg.(*cgroupscraper.Config).Include = cgroupscraper.MatchConfig{
+					Paths:  []string{"/system.slice/.*"},
+					Config: filterset.Config{MatchType: "regexp"},
+				}
+				return cfg
+			})(),
+			systemdscraper.TypeStr: (func() internal.Config {
+				cfg := (&systemdscraper.Factory{}).CreateDefaultConfig()
+				cfg.(*systemdscraper.Config).Include = systemdscraper.MatchConfig{
+					Units:  []string{"nginx.service", "sshd.service"},
+					Config: filterset.Config{MatchType: "strict"},
+				}
+				return cfg
+			})(),
 			processscraper.TypeStr: (func() internal.Config {
 				cfg := (&processscraper.Factory{}).CreateDefaultConfig()
 				cfg.(*processscraper.Config).Include = processscraper.MatchConfig{
@@ -0,0 +1,106 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+// cgroup reads the interface files of a cgroup v2 directory.
+type cgroup struct {
+	dir string
+}
+
+// readFlatKeyed reads a file of "<key> <value>" lines, e.g. cpu.stat.
+func (c cgroup) readFlatKeyed(name string) (map[string]uint64, error) {
+	content, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]uint64{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("invalid line %q in %s", scanner.Text(), name)
+		}
+		value, err := strconv.ParseUint(fields[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value of %s in %s: %w", fields[0], name, err)
+		}
+		values[fields[0]] = value
+	}
+	return values, scanner.Err()
+}
+
+// readNestedKeyed reads a file of "<key> <subkey>=<value> ..." lines, e.g. io.stat.
+func (c cgroup) readNestedKeyed(name string) (map[string]map[string]uint64, error) {
+	content, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		return nil, err
+	}
+
+	values := map[string]map[string]uint64{}
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+		subValues := map[string]uint64{}
+		for _, field := range fields[1:] {
+			subKey, rawValue, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid field %q in %s", field, name)
+			}
+			value, err := strconv.ParseUint(rawValue, 10, 64)
+			if err != nil {
+				return nil, fmt.Errorf("invalid value of %s in %s: %w", subKey, name, err)
+			}
+			subValues[subKey] = value
+		}
+		values[fields[0]] = subValues
+	}
+	return values, scanner.Err()
+}
+
+// readSingleValue reads a file holding a single value, e.g. memory.current. The returned
+// boolean is false when the value is "max", i.e. there is no limit.
+func (c cgroup) readSingleValue(name string) (uint64, bool, error) {
+	content, err := os.ReadFile(filepath.Join(c.dir, name))
+	if err != nil {
+		return 0, false, err
+	}
+
+	raw := strings.TrimSpace(string(content))
+	if raw == "max" {
+		return 0, false, nil
+	}
+	value, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, false, fmt.Errorf("invalid value in %s: %w", name, err)
+	}
+	return value, true, nil
+}
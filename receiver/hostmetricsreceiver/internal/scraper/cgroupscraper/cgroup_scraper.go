@@ -0,0 +1,208 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper"
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+const (
+	cpuMetricsLen    = 2
+	memoryMetricsLen = 2
+	ioMetricsLen     = 2
+)
+
+// scraper for Cgroup Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+}
+
+// newCgroupScraper creates a Cgroup Scraper
+func newCgroupScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	scraper := &scraper{
+		settings: settings,
+		config:   cfg,
+	}
+
+	var err error
+
+	if len(cfg.Include.Paths) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Paths, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Paths) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Paths, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating cgroup exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	s.mb = metadata.NewMetricsBuilder(s.config.Metrics, s.settings)
+	return nil
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	var errs scrapererror.ScrapeErrors
+
+	mountPoint := s.config.MountPoint
+	if mountPoint == "" {
+		mountPoint = defaultMountPoint
+	}
+	root := filepath.Join(s.config.RootPath, mountPoint)
+
+	err := filepath.WalkDir(root, func(dir string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			// the cgroup may have been removed since its parent was listed
+			if dir != root && errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if !entry.IsDir() {
+			return nil
+		}
+
+		rel, err := filepath.Rel(root, dir)
+		if err != nil {
+			return err
+		}
+		path := "/" + filepath.ToSlash(rel)
+		if rel == "." {
+			path = "/"
+		}
+
+		// filter cgroups by path
+		if (s.includeFS != nil && !s.includeFS.Matches(path)) ||
+			(s.excludeFS != nil && s.excludeFS.Matches(path)) {
+			return nil
+		}
+
+		s.scrapeCgroup(cgroup{dir: dir}, path, &errs)
+		return nil
+	})
+	if err != nil {
+		return pmetric.NewMetrics(), fmt.Errorf("error walking the cgroup hierarchy: %w", err)
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) scrapeCgroup(cg cgroup, path string, errs *scrapererror.ScrapeErrors) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	if err := s.scrapeAndAppendCPUMetrics(now, cg); err != nil {
+		errs.AddPartial(cpuMetricsLen, fmt.Errorf("error reading cpu stats for cgroup %q: %w", path, err))
+	}
+
+	if err := s.scrapeAndAppendMemoryMetrics(now, cg); err != nil {
+		errs.AddPartial(memoryMetricsLen, fmt.Errorf("error reading memory stats for cgroup %q: %w", path, err))
+	}
+
+	if err := s.scrapeAndAppendIOMetrics(now, cg); err != nil {
+		errs.AddPartial(ioMetricsLen, fmt.Errorf("error reading io stats for cgroup %q: %w", path, err))
+	}
+
+	s.mb.EmitForResource(metadata.WithCgroupPath(path))
+}
+
+// The interface files of the controllers that aren't enabled for a cgroup don't exist,
+// the corresponding metrics are skipped.
+
+func (s *scraper) scrapeAndAppendCPUMetrics(now pcommon.Timestamp, cg cgroup) error {
+	stats, err := cg.readFlatKeyed("cpu.stat")
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+
+	if usec, ok := stats["user_usec"]; ok {
+		s.mb.RecordSystemCgroupCPUTimeDataPoint(now, microsecondsToSeconds(usec), metadata.AttributeStateUser)
+	}
+	if usec, ok := stats["system_usec"]; ok {
+		s.mb.RecordSystemCgroupCPUTimeDataPoint(now, microsecondsToSeconds(usec), metadata.AttributeStateSystem)
+	}
+	if usec, ok := stats["throttled_usec"]; ok {
+		s.mb.RecordSystemCgroupCPUThrottledTimeDataPoint(now, microsecondsToSeconds(usec))
+	}
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendMemoryMetrics(now pcommon.Timestamp, cg cgroup) error {
+	usage, _, err := cg.readSingleValue("memory.current")
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	s.mb.RecordSystemCgroupMemoryUsageDataPoint(now, int64(usage))
+
+	limit, limited, err := cg.readSingleValue("memory.max")
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+	if limited {
+		s.mb.RecordSystemCgroupMemoryLimitDataPoint(now, int64(limit))
+	}
+	return nil
+}
+
+func (s *scraper) scrapeAndAppendIOMetrics(now pcommon.Timestamp, cg cgroup) error {
+	stats, err := cg.readNestedKeyed("io.stat")
+	if err != nil {
+		return ignoreNotExist(err)
+	}
+
+	for device, stat := range stats {
+		s.mb.RecordSystemCgroupIoBytesDataPoint(now, int64(stat["rbytes"]), device, metadata.AttributeDirectionRead)
+		s.mb.RecordSystemCgroupIoBytesDataPoint(now, int64(stat["wbytes"]), device, metadata.AttributeDirectionWrite)
+		s.mb.RecordSystemCgroupIoOperationsDataPoint(now, int64(stat["rios"]), device, metadata.AttributeDirectionRead)
+		s.mb.RecordSystemCgroupIoOperationsDataPoint(now, int64(stat["wios"]), device, metadata.AttributeDirectionWrite)
+	}
+	return nil
+}
+
+func ignoreNotExist(err error) error {
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+func microsecondsToSeconds(usec uint64) float64 {
+	return float64(usec) / 1e6
+}
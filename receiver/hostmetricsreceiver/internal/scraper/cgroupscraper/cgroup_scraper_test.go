@@ -0,0 +1,191 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/cgroupscraper/internal/metadata"
+)
+
+func TestScrape(t *testing.T) {
+	md, err := scrape(t, newTestConfig("testdata"))
+	require.NoError(t, err)
+
+	resources := resourcesByPath(md)
+	require.Len(t, resources, 4)
+
+	root := resources["/"]
+	assert.Equal(t, 6.0, findDataPoint(t, root, "system.cgroup.cpu.time", map[string]string{"state": "user"}).DoubleValue())
+	assert.Equal(t, 3.0, findDataPoint(t, root, "system.cgroup.cpu.time", map[string]string{"state": "system"}).DoubleValue())
+	assert.Equal(t, int64(8192), findDataPoint(t, root, "system.cgroup.io.bytes", map[string]string{"device": "8:0", "direction": "write"}).IntValue())
+	assert.False(t, hasMetric(root, "system.cgroup.memory.usage"))
+
+	slice := resources["/system.slice"]
+	assert.Equal(t, int64(209715200), findDataPoint(t, slice, "system.cgroup.memory.usage", nil).IntValue())
+	assert.False(t, hasMetric(slice, "system.cgroup.memory.limit"), "the cgroup has no memory limit")
+	assert.Equal(t, 0.0, findDataPoint(t, slice, "system.cgroup.cpu.throttled.time", nil).DoubleValue())
+
+	service := resources["/system.slice/nginx.service"]
+	assert.Equal(t, 0.25, findDataPoint(t, service, "system.cgroup.cpu.throttled.time", nil).DoubleValue())
+	assert.Equal(t, int64(52428800), findDataPoint(t, service, "system.cgroup.memory.usage", nil).IntValue())
+	assert.Equal(t, int64(104857600), findDataPoint(t, service, "system.cgroup.memory.limit", nil).IntValue())
+	assert.Equal(t, int64(512), findDataPoint(t, service, "system.cgroup.io.bytes", map[string]string{"device": "259:0", "direction": "read"}).IntValue())
+	assert.Equal(t, int64(4), findDataPoint(t, service, "system.cgroup.io.operations", map[string]string{"device": "8:0", "direction": "write"}).IntValue())
+
+	user := resources["/user.slice"]
+	assert.Equal(t, 0.7, findDataPoint(t, user, "system.cgroup.cpu.time", map[string]string{"state": "user"}).DoubleValue())
+}
+
+func TestScrapeFilters(t *testing.T) {
+	tests := []struct {
+		name     string
+		include  MatchConfig
+		exclude  MatchConfig
+		expected []string
+	}{
+		{
+			name:     "include regexp",
+			include:  MatchConfig{Config: filterset.Config{MatchType: filterset.Regexp}, Paths: []string{`\.service$`}},
+			expected: []string{"/system.slice/nginx.service"},
+		},
+		{
+			name:     "exclude strict",
+			exclude:  MatchConfig{Config: filterset.Config{MatchType: filterset.Strict}, Paths: []string{"/", "/user.slice"}},
+			expected: []string{"/system.slice", "/system.slice/nginx.service"},
+		},
+		{
+			name:     "include and exclude",
+			include:  MatchConfig{Config: filterset.Config{MatchType: filterset.Regexp}, Paths: []string{"^/system.slice"}},
+			exclude:  MatchConfig{Config: filterset.Config{MatchType: filterset.Strict}, Paths: []string{"/system.slice"}},
+			expected: []string{"/system.slice/nginx.service"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig("testdata")
+			cfg.Include = tt.include
+			cfg.Exclude = tt.exclude
+
+			md, err := scrape(t, cfg)
+			require.NoError(t, err)
+
+			var paths []string
+			for path := range resourcesByPath(md) {
+				paths = append(paths, path)
+			}
+			assert.ElementsMatch(t, tt.expected, paths)
+		})
+	}
+}
+
+func TestScrapeInvalidFiles(t *testing.T) {
+	md, err := scrape(t, newTestConfig("testdata/invalid"))
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, `error reading cpu stats for cgroup "/"`)
+	assert.ErrorContains(t, err, `error reading memory stats for cgroup "/"`)
+
+	// the usage is reported even though the limit can't be read
+	root := resourcesByPath(md)["/"]
+	assert.Equal(t, int64(1024), findDataPoint(t, root, "system.cgroup.memory.usage", nil).IntValue())
+}
+
+func TestScrapeMissingHierarchy(t *testing.T) {
+	_, err := scrape(t, newTestConfig("testdata/missing"))
+	assert.ErrorContains(t, err, "error walking the cgroup hierarchy")
+}
+
+func TestNewCgroupScraperInvalidFilter(t *testing.T) {
+	cfg := newTestConfig("testdata")
+	cfg.Include = MatchConfig{Config: filterset.Config{MatchType: filterset.Regexp}, Paths: []string{"("}}
+	_, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, err, "error creating cgroup include filters")
+}
+
+func newTestConfig(rootPath string) *Config {
+	return &Config{
+		Metrics:       metadata.DefaultMetricsSettings(),
+		ScraperConfig: internal.ScraperConfig{RootPath: rootPath},
+		MountPoint:    defaultMountPoint,
+	}
+}
+
+func scrape(t *testing.T, cfg *Config) (pmetric.Metrics, error) {
+	scraper, err := newCgroupScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	return scraper.scrape(context.Background())
+}
+
+func resourcesByPath(md pmetric.Metrics) map[string]pmetric.MetricSlice {
+	resources := map[string]pmetric.MetricSlice{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		path, _ := rm.Resource().Attributes().Get("cgroup.path")
+		resources[path.Str()] = rm.ScopeMetrics().At(0).Metrics()
+	}
+	return resources
+}
+
+func hasMetric(metrics pmetric.MetricSlice, name string) bool {
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return true
+		}
+	}
+	return false
+}
+
+func findDataPoint(t *testing.T, metrics pmetric.MetricSlice, name string, attrs map[string]string) pmetric.NumberDataPoint {
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() != name {
+			continue
+		}
+		dps := metrics.At(i).Sum().DataPoints()
+		for j := 0; j < dps.Len(); j++ {
+			if matchAttributes(dps.At(j).Attributes(), attrs) {
+				return dps.At(j)
+			}
+		}
+	}
+	require.Failf(t, "data point not found", "%s %v", name, attrs)
+	return pmetric.NumberDataPoint{}
+}
+
+func matchAttributes(actual pcommon.Map, expected map[string]string) bool {
+	if actual.Len() != len(expected) {
+		return false
+	}
+	for k, v := range expected {
+		value, ok := actual.Get(k)
+		if !ok || value.Str() != v {
+			return false
+		}
+	}
+	return true
+}
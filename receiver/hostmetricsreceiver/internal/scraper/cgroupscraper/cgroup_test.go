@@ -0,0 +1,63 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package cgroupscraper
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadNestedKeyed(t *testing.T) {
+	cg := newTestCgroup(t, "io.stat", "8:0 rbytes=1024 wbytes=2048\n259:0 rbytes=512\n")
+	values, err := cg.readNestedKeyed("io.stat")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]map[string]uint64{
+		"8:0":   {"rbytes": 1024, "wbytes": 2048},
+		"259:0": {"rbytes": 512},
+	}, values)
+
+	cg = newTestCgroup(t, "io.stat", "8:0 rbytes\n")
+	_, err = cg.readNestedKeyed("io.stat")
+	assert.EqualError(t, err, `invalid field "rbytes" in io.stat`)
+}
+
+func TestReadFlatKeyed(t *testing.T) {
+	cg := newTestCgroup(t, "cpu.stat", "usage_usec 10 20\n")
+	_, err := cg.readFlatKeyed("cpu.stat")
+	assert.EqualError(t, err, `invalid line "usage_usec 10 20" in cpu.stat`)
+}
+
+func TestReadSingleValue(t *testing.T) {
+	cg := newTestCgroup(t, "memory.max", "max\n")
+	_, limited, err := cg.readSingleValue("memory.max")
+	require.NoError(t, err)
+	assert.False(t, limited)
+
+	cg = newTestCgroup(t, "memory.max", "1024\n")
+	value, limited, err := cg.readSingleValue("memory.max")
+	require.NoError(t, err)
+	assert.True(t, limited)
+	assert.Equal(t, uint64(1024), value)
+}
+
+func newTestCgroup(t *testing.T, name, content string) cgroup {
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0600))
+	return cgroup{dir: dir}
+}
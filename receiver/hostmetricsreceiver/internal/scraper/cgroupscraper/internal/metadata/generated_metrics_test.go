@@ -0,0 +1,224 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDefaultMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	mb := NewMetricsBuilder(DefaultMetricsSettings(), receivertest.NewNopCreateSettings(), WithStartTime(start))
+	enabledMetrics := make(map[string]bool)
+
+	enabledMetrics["system.cgroup.cpu.throttled.time"] = true
+	mb.RecordSystemCgroupCPUThrottledTimeDataPoint(ts, 1)
+
+	enabledMetrics["system.cgroup.cpu.time"] = true
+	mb.RecordSystemCgroupCPUTimeDataPoint(ts, 1, AttributeState(1))
+
+	enabledMetrics["system.cgroup.io.bytes"] = true
+	mb.RecordSystemCgroupIoBytesDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+
+	enabledMetrics["system.cgroup.io.operations"] = true
+	mb.RecordSystemCgroupIoOperationsDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+
+	enabledMetrics["system.cgroup.memory.limit"] = true
+	mb.RecordSystemCgroupMemoryLimitDataPoint(ts, 1)
+
+	enabledMetrics["system.cgroup.memory.usage"] = true
+	mb.RecordSystemCgroupMemoryUsageDataPoint(ts, 1)
+
+	metrics := mb.Emit()
+
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	sm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	assert.Equal(t, 1, sm.Len())
+	ms := sm.At(0).Metrics()
+	assert.Equal(t, len(enabledMetrics), ms.Len())
+	seenMetrics := make(map[string]bool)
+	for i := 0; i < ms.Len(); i++ {
+		assert.True(t, enabledMetrics[ms.At(i).Name()])
+		seenMetrics[ms.At(i).Name()] = true
+	}
+	assert.Equal(t, len(enabledMetrics), len(seenMetrics))
+}
+
+func TestAllMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	metricsSettings := MetricsSettings{
+		SystemCgroupCPUThrottledTime: MetricSettings{Enabled: true},
+		SystemCgroupCPUTime:          MetricSettings{Enabled: true},
+		SystemCgroupIoBytes:          MetricSettings{Enabled: true},
+		SystemCgroupIoOperations:     MetricSettings{Enabled: true},
+		SystemCgroupMemoryLimit:      MetricSettings{Enabled: true},
+		SystemCgroupMemoryUsage:      MetricSettings{Enabled: true},
+	}
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopCreateSettings()
+	settings.Logger = zap.New(observedZapCore)
+	mb := NewMetricsBuilder(metricsSettings, settings, WithStartTime(start))
+
+	assert.Equal(t, 0, observedLogs.Len())
+
+	mb.RecordSystemCgroupCPUThrottledTimeDataPoint(ts, 1)
+	mb.RecordSystemCgroupCPUTimeDataPoint(ts, 1, AttributeState(1))
+	mb.RecordSystemCgroupIoBytesDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordSystemCgroupIoOperationsDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordSystemCgroupMemoryLimitDataPoint(ts, 1)
+	mb.RecordSystemCgroupMemoryUsageDataPoint(ts, 1)
+
+	metrics := mb.Emit(WithCgroupPath("attr-val"))
+
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	rm := metrics.ResourceMetrics().At(0)
+	attrCount := 0
+	attrCount++
+	attrVal, ok := rm.Resource().Attributes().Get("cgroup.path")
+	assert.True(t, ok)
+	assert.EqualValues(t, "attr-val", attrVal.Str())
+	assert.Equal(t, attrCount, rm.Resource().Attributes().Len())
+
+	assert.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	allMetricsCount := reflect.TypeOf(MetricsSettings{}).NumField()
+	assert.Equal(t, allMetricsCount, ms.Len())
+	validatedMetrics := make(map[string]struct{})
+	for i := 0; i < ms.Len(); i++ {
+		switch ms.At(i).Name() {
+		case "system.cgroup.cpu.throttled.time":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Total time the tasks of the cgroup have been throttled by the CPU bandwidth limit.", ms.At(i).Description())
+			assert.Equal(t, "s", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+			assert.Equal(t, float64(1), dp.DoubleValue())
+			validatedMetrics["system.cgroup.cpu.throttled.time"] = struct{}{}
+		case "system.cgroup.cpu.time":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Total CPU time consumed by the tasks of the cgroup.", ms.At(i).Description())
+			assert.Equal(t, "s", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+			assert.Equal(t, float64(1), dp.DoubleValue())
+			attrVal, ok := dp.Attributes().Get("state")
+			assert.True(t, ok)
+			assert.Equal(t, "user", attrVal.Str())
+			validatedMetrics["system.cgroup.cpu.time"] = struct{}{}
+		case "system.cgroup.io.bytes":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Bytes read from and written to the block devices by the cgroup.", ms.At(i).Description())
+			assert.Equal(t, "By", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("device")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("direction")
+			assert.True(t, ok)
+			assert.Equal(t, "read", attrVal.Str())
+			validatedMetrics["system.cgroup.io.bytes"] = struct{}{}
+		case "system.cgroup.io.operations":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Read and write operations on the block devices by the cgroup.", ms.At(i).Description())
+			assert.Equal(t, "{operations}", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("device")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("direction")
+			assert.True(t, ok)
+			assert.Equal(t, "read", attrVal.Str())
+			validatedMetrics["system.cgroup.io.operations"] = struct{}{}
+		case "system.cgroup.memory.limit":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Memory usage hard limit of the cgroup. Not reported when the cgroup has no limit.", ms.At(i).Description())
+			assert.Equal(t, "By", ms.At(i).Unit())
+			assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			validatedMetrics["system.cgroup.memory.limit"] = struct{}{}
+		case "system.cgroup.memory.usage":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Memory currently used by the cgroup and its descendants.", ms.At(i).Description())
+			assert.Equal(t, "By", ms.At(i).Unit())
+			assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			validatedMetrics["system.cgroup.memory.usage"] = struct{}{}
+		}
+	}
+	assert.Equal(t, allMetricsCount, len(validatedMetrics))
+}
+
+func TestNoMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	metricsSettings := MetricsSettings{
+		SystemCgroupCPUThrottledTime: MetricSettings{Enabled: false},
+		SystemCgroupCPUTime:          MetricSettings{Enabled: false},
+		SystemCgroupIoBytes:          MetricSettings{Enabled: false},
+		SystemCgroupIoOperations:     MetricSettings{Enabled: false},
+		SystemCgroupMemoryLimit:      MetricSettings{Enabled: false},
+		SystemCgroupMemoryUsage:      MetricSettings{Enabled: false},
+	}
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopCreateSettings()
+	settings.Logger = zap.New(observedZapCore)
+	mb := NewMetricsBuilder(metricsSettings, settings, WithStartTime(start))
+
+	assert.Equal(t, 0, observedLogs.Len())
+	mb.RecordSystemCgroupCPUThrottledTimeDataPoint(ts, 1)
+	mb.RecordSystemCgroupCPUTimeDataPoint(ts, 1, AttributeState(1))
+	mb.RecordSystemCgroupIoBytesDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordSystemCgroupIoOperationsDataPoint(ts, 1, "attr-val", AttributeDirection(1))
+	mb.RecordSystemCgroupMemoryLimitDataPoint(ts, 1)
+	mb.RecordSystemCgroupMemoryUsageDataPoint(ts, 1)
+
+	metrics := mb.Emit()
+
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+}
@@ -0,0 +1,138 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDefaultMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	mb := NewMetricsBuilder(DefaultMetricsSettings(), receivertest.NewNopCreateSettings(), WithStartTime(start))
+	enabledMetrics := make(map[string]bool)
+
+	enabledMetrics["system.pressure.stall.ratio"] = true
+	mb.RecordSystemPressureStallRatioDataPoint(ts, 1, AttributeResource(1), AttributeStall(1), AttributeWindow(1))
+
+	enabledMetrics["system.pressure.stall.time"] = true
+	mb.RecordSystemPressureStallTimeDataPoint(ts, 1, AttributeResource(1), AttributeStall(1))
+
+	metrics := mb.Emit()
+
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	sm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	assert.Equal(t, 1, sm.Len())
+	ms := sm.At(0).Metrics()
+	assert.Equal(t, len(enabledMetrics), ms.Len())
+	seenMetrics := make(map[string]bool)
+	for i := 0; i < ms.Len(); i++ {
+		assert.True(t, enabledMetrics[ms.At(i).Name()])
+		seenMetrics[ms.At(i).Name()] = true
+	}
+	assert.Equal(t, len(enabledMetrics), len(seenMetrics))
+}
+
+func TestAllMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	metricsSettings := MetricsSettings{
+		SystemPressureStallRatio: MetricSettings{Enabled: true},
+		SystemPressureStallTime:  MetricSettings{Enabled: true},
+	}
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopCreateSettings()
+	settings.Logger = zap.New(observedZapCore)
+	mb := NewMetricsBuilder(metricsSettings, settings, WithStartTime(start))
+
+	assert.Equal(t, 0, observedLogs.Len())
+
+	mb.RecordSystemPressureStallRatioDataPoint(ts, 1, AttributeResource(1), AttributeStall(1), AttributeWindow(1))
+	mb.RecordSystemPressureStallTimeDataPoint(ts, 1, AttributeResource(1), AttributeStall(1))
+
+	metrics := mb.Emit()
+
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	rm := metrics.ResourceMetrics().At(0)
+	attrCount := 0
+	assert.Equal(t, attrCount, rm.Resource().Attributes().Len())
+
+	assert.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	allMetricsCount := reflect.TypeOf(MetricsSettings{}).NumField()
+	assert.Equal(t, allMetricsCount, ms.Len())
+	validatedMetrics := make(map[string]struct{})
+	for i := 0; i < ms.Len(); i++ {
+		switch ms.At(i).Name() {
+		case "system.pressure.stall.ratio":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "Share of the time the tasks have been stalled on the resource, averaged over the time window.", ms.At(i).Description())
+			assert.Equal(t, "%", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+			assert.Equal(t, float64(1), dp.DoubleValue())
+			attrVal, ok := dp.Attributes().Get("resource")
+			assert.True(t, ok)
+			assert.Equal(t, "cpu", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("stall")
+			assert.True(t, ok)
+			assert.Equal(t, "some", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("window")
+			assert.True(t, ok)
+			assert.Equal(t, "10s", attrVal.Str())
+			validatedMetrics["system.pressure.stall.ratio"] = struct{}{}
+		case "system.pressure.stall.time":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Total time the tasks have been stalled on the resource.", ms.At(i).Description())
+			assert.Equal(t, "s", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeDouble, dp.ValueType())
+			assert.Equal(t, float64(1), dp.DoubleValue())
+			attrVal, ok := dp.Attributes().Get("resource")
+			assert.True(t, ok)
+			assert.Equal(t, "cpu", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("stall")
+			assert.True(t, ok)
+			assert.Equal(t, "some", attrVal.Str())
+			validatedMetrics["system.pressure.stall.time"] = struct{}{}
+		}
+	}
+	assert.Equal(t, allMetricsCount, len(validatedMetrics))
+}
+
+func TestNoMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	metricsSettings := MetricsSettings{
+		SystemPressureStallRatio: MetricSettings{Enabled: false},
+		SystemPressureStallTime:  MetricSettings{Enabled: false},
+	}
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopCreateSettings()
+	settings.Logger = zap.New(observedZapCore)
+	mb := NewMetricsBuilder(metricsSettings, settings, WithStartTime(start))
+
+	assert.Equal(t, 0, observedLogs.Len())
+	mb.RecordSystemPressureStallRatioDataPoint(ts, 1, AttributeResource(1), AttributeStall(1), AttributeWindow(1))
+	mb.RecordSystemPressureStallTimeDataPoint(ts, 1, AttributeResource(1), AttributeStall(1))
+
+	metrics := mb.Emit()
+
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+}
@@ -0,0 +1,73 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// stall is a line of a pressure file, e.g.
+// some avg10=0.12 avg60=0.05 avg300=0.01 total=123456
+type stall struct {
+	kind   string
+	avg10  float64
+	avg60  float64
+	avg300 float64
+	total  time.Duration
+}
+
+// parsePressure parses the content of a /proc/pressure file.
+func parsePressure(r io.Reader) ([]stall, error) {
+	var stalls []stall
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 {
+			continue
+		}
+
+		st := stall{kind: fields[0]}
+		for _, field := range fields[1:] {
+			key, value, ok := strings.Cut(field, "=")
+			if !ok {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+
+			var err error
+			switch key {
+			case "avg10":
+				st.avg10, err = strconv.ParseFloat(value, 64)
+			case "avg60":
+				st.avg60, err = strconv.ParseFloat(value, 64)
+			case "avg300":
+				st.avg300, err = strconv.ParseFloat(value, 64)
+			case "total":
+				var total uint64
+				total, err = strconv.ParseUint(value, 10, 64)
+				st.total = time.Duration(total) * time.Microsecond
+			}
+			if err != nil {
+				return nil, fmt.Errorf("invalid field %q: %w", field, err)
+			}
+		}
+		stalls = append(stalls, st)
+	}
+	return stalls, scanner.Err()
+}
@@ -0,0 +1,109 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper"
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/host"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const (
+	pressureDir = "/proc/pressure"
+	// a stall time metric and three stall ratio metrics per stall kind
+	metricsLen = 4
+)
+
+var resources = []metadata.AttributeResource{
+	metadata.AttributeResourceCpu,
+	metadata.AttributeResourceMemory,
+	metadata.AttributeResourceIo,
+}
+
+// scraper for Pressure Metrics
+type scraper struct {
+	settings receiver.CreateSettings
+	config   *Config
+	mb       *metadata.MetricsBuilder
+
+	// for mocking
+	bootTime func() (uint64, error)
+}
+
+// newPressureScraper creates a Pressure Scraper
+func newPressureScraper(_ context.Context, settings receiver.CreateSettings, cfg *Config) *scraper {
+	return &scraper{
+		settings: settings,
+		config:   cfg,
+		bootTime: host.BootTime,
+	}
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	bootTime, err := s.bootTime()
+	if err != nil {
+		return err
+	}
+
+	s.mb = metadata.NewMetricsBuilder(s.config.Metrics, s.settings, metadata.WithStartTime(pcommon.Timestamp(bootTime*1e9)))
+	return nil
+}
+
+func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
+	now := pcommon.NewTimestampFromTime(time.Now())
+
+	var errs scrapererror.ScrapeErrors
+	for _, resource := range resources {
+		stalls, err := s.readPressure(resource)
+		if err != nil {
+			errs.AddPartial(metricsLen, fmt.Errorf("error reading %s pressure: %w", resource, err))
+			continue
+		}
+
+		for _, st := range stalls {
+			kind, ok := metadata.MapAttributeStall[st.kind]
+			if !ok {
+				continue
+			}
+			s.mb.RecordSystemPressureStallTimeDataPoint(now, st.total.Seconds(), resource, kind)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, st.avg10, resource, kind, metadata.AttributeWindow10s)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, st.avg60, resource, kind, metadata.AttributeWindow60s)
+			s.mb.RecordSystemPressureStallRatioDataPoint(now, st.avg300, resource, kind, metadata.AttributeWindow300s)
+		}
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) readPressure(resource metadata.AttributeResource) ([]stall, error) {
+	f, err := os.Open(filepath.Join(s.config.RootPath, pressureDir, resource.String()))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return parsePressure(f)
+}
@@ -0,0 +1,115 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/pressurescraper/internal/metadata"
+)
+
+const bootTime = 1672531200
+
+func TestScrape(t *testing.T) {
+	scraper := newTestScraper(t, "testdata")
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	require.Equal(t, 2, metrics.Len())
+
+	stallTime := findMetric(t, metrics, "system.pressure.stall.time")
+	assert.True(t, stallTime.Sum().IsMonotonic())
+	assert.Equal(t, pmetric.AggregationTemporalityCumulative, stallTime.Sum().AggregationTemporality())
+	dps := stallTime.Sum().DataPoints()
+	require.Equal(t, 6, dps.Len())
+	assert.Equal(t, 2.5, findDataPoint(t, dps, "cpu", "some", "").DoubleValue())
+	assert.Equal(t, 0.0, findDataPoint(t, dps, "cpu", "full", "").DoubleValue())
+	assert.Equal(t, 3.0, findDataPoint(t, dps, "io", "full", "").DoubleValue())
+	assert.Equal(t, pcommon.Timestamp(bootTime*1e9), dps.At(0).StartTimestamp())
+
+	stallRatio := findMetric(t, metrics, "system.pressure.stall.ratio")
+	dps = stallRatio.Gauge().DataPoints()
+	require.Equal(t, 18, dps.Len())
+	assert.Equal(t, 1.5, findDataPoint(t, dps, "cpu", "some", "10s").DoubleValue())
+	assert.Equal(t, 0.75, findDataPoint(t, dps, "cpu", "some", "60s").DoubleValue())
+	assert.Equal(t, 0.02, findDataPoint(t, dps, "memory", "full", "300s").DoubleValue())
+}
+
+func TestScrapeMissingResource(t *testing.T) {
+	scraper := newTestScraper(t, "testdata/missing")
+
+	md, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, "error reading memory pressure")
+	assert.ErrorContains(t, err, "error reading io pressure")
+
+	metrics := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	assert.Equal(t, 2, findMetric(t, metrics, "system.pressure.stall.time").Sum().DataPoints().Len())
+}
+
+func TestStartError(t *testing.T) {
+	scraper := newPressureScraper(context.Background(), receivertest.NewNopCreateSettings(), &Config{})
+	scraper.bootTime = func() (uint64, error) { return 0, errors.New("err1") }
+	assert.EqualError(t, scraper.start(context.Background(), componenttest.NewNopHost()), "err1")
+}
+
+func newTestScraper(t *testing.T, rootPath string) *scraper {
+	cfg := &Config{
+		Metrics:       metadata.DefaultMetricsSettings(),
+		ScraperConfig: internal.ScraperConfig{RootPath: rootPath},
+	}
+	scraper := newPressureScraper(context.Background(), receivertest.NewNopCreateSettings(), cfg)
+	scraper.bootTime = func() (uint64, error) { return bootTime, nil }
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	return scraper
+}
+
+func findMetric(t *testing.T, metrics pmetric.MetricSlice, name string) pmetric.Metric {
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "%s", name)
+	return pmetric.Metric{}
+}
+
+func findDataPoint(t *testing.T, dps pmetric.NumberDataPointSlice, resource, stall, window string) pmetric.NumberDataPoint {
+	for i := 0; i < dps.Len(); i++ {
+		attrs := dps.At(i).Attributes()
+		r, _ := attrs.Get("resource")
+		s, _ := attrs.Get("stall")
+		w, _ := attrs.Get("window")
+		if r.Str() == resource && s.Str() == stall && w.Str() == window {
+			return dps.At(i)
+		}
+	}
+	require.Failf(t, "data point not found", "%s %s %s", resource, stall, window)
+	return pmetric.NumberDataPoint{}
+}
@@ -0,0 +1,46 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package pressurescraper
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParsePressure(t *testing.T) {
+	stalls, err := parsePressure(strings.NewReader(
+		"some avg10=1.50 avg60=0.75 avg300=0.25 total=2500000\n" +
+			"full avg10=0.00 avg60=0.00 avg300=0.00 total=0\n"))
+	require.NoError(t, err)
+	assert.Equal(t, []stall{
+		{kind: "some", avg10: 1.5, avg60: 0.75, avg300: 0.25, total: 2500 * time.Millisecond},
+		{kind: "full"},
+	}, stalls)
+}
+
+func TestParsePressureErrors(t *testing.T) {
+	_, err := parsePressure(strings.NewReader("some avg10"))
+	assert.EqualError(t, err, `invalid field "avg10"`)
+
+	_, err = parsePressure(strings.NewReader("some avg10=high"))
+	assert.ErrorContains(t, err, `invalid field "avg10=high"`)
+
+	_, err = parsePressure(strings.NewReader("some total=-1"))
+	assert.ErrorContains(t, err, `invalid field "total=-1"`)
+}
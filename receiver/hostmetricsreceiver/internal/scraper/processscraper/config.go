@@ -41,6 +41,15 @@ type Config struct {
 	// ScrapeProcessDelay is used to indicate the minimum amount of time a process must be running
 	// before metrics are scraped for it.  The default value is 0 seconds (0s)
 	ScrapeProcessDelay time.Duration `mapstructure:"scrape_process_delay"`
+
+	// GroupBy aggregates the metrics of the processes sharing the same values of the given keys,
+	// among executable_name, command, owner and cgroup, instead of reporting the metrics of each process.
+	GroupBy []string `mapstructure:"group_by"`
+
+	// GroupStaleness is the duration after which the cpu time and disk counters of the processes that
+	// exited are forgotten when their group has no running process, the group then starting again from
+	// zero. With 0, they are forgotten as soon as the group has no running process.
+	GroupStaleness time.Duration `mapstructure:"group_staleness"`
 }
 
 type MatchConfig struct {
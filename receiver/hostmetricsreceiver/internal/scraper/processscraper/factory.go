@@ -40,7 +40,8 @@ type Factory struct {
 // CreateDefaultConfig creates the default configuration for the Scraper.
 func (f *Factory) CreateDefaultConfig() internal.Config {
 	return &Config{
-		Metrics: metadata.DefaultMetricsSettings(),
+		Metrics:        metadata.DefaultMetricsSettings(),
+		GroupStaleness: defaultGroupStaleness,
 	}
 }
 
@@ -22,6 +22,9 @@ func TestDefaultMetrics(t *testing.T) {
 
 	mb.RecordProcessContextSwitchesDataPoint(ts, 1, AttributeContextSwitchType(1))
 
+	enabledMetrics["process.count"] = true
+	mb.RecordProcessCountDataPoint(ts, 1)
+
 	enabledMetrics["process.cpu.time"] = true
 	mb.RecordProcessCPUTimeDataPoint(ts, 1, AttributeState(1))
 
@@ -72,6 +75,7 @@ func TestAllMetrics(t *testing.T) {
 	ts := pcommon.Timestamp(1_000_001_000)
 	metricsSettings := MetricsSettings{
 		ProcessContextSwitches:     MetricSettings{Enabled: true},
+		ProcessCount:               MetricSettings{Enabled: true},
 		ProcessCPUTime:             MetricSettings{Enabled: true},
 		ProcessCPUUtilization:      MetricSettings{Enabled: true},
 		ProcessDiskIo:              MetricSettings{Enabled: true},
@@ -94,6 +98,7 @@ func TestAllMetrics(t *testing.T) {
 	assert.Equal(t, 0+1+1, observedLogs.Len())
 
 	mb.RecordProcessContextSwitchesDataPoint(ts, 1, AttributeContextSwitchType(1))
+	mb.RecordProcessCountDataPoint(ts, 1)
 	mb.RecordProcessCPUTimeDataPoint(ts, 1, AttributeState(1))
 	mb.RecordProcessCPUUtilizationDataPoint(ts, 1, AttributeState(1))
 	mb.RecordProcessDiskIoDataPoint(ts, 1, AttributeDirection(1))
@@ -108,13 +113,17 @@ func TestAllMetrics(t *testing.T) {
 	mb.RecordProcessSignalsPendingDataPoint(ts, 1)
 	mb.RecordProcessThreadsDataPoint(ts, 1)
 
-	metrics := mb.Emit(WithProcessCommand("attr-val"), WithProcessCommandLine("attr-val"), WithProcessExecutableName("attr-val"), WithProcessExecutablePath("attr-val"), WithProcessOwner("attr-val"), WithProcessParentPid(1), WithProcessPid(1))
+	metrics := mb.Emit(WithProcessCgroup("attr-val"), WithProcessCommand("attr-val"), WithProcessCommandLine("attr-val"), WithProcessExecutableName("attr-val"), WithProcessExecutablePath("attr-val"), WithProcessOwner("attr-val"), WithProcessParentPid(1), WithProcessPid(1))
 
 	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
 	rm := metrics.ResourceMetrics().At(0)
 	attrCount := 0
 	attrCount++
-	attrVal, ok := rm.Resource().Attributes().Get("process.command")
+	attrVal, ok := rm.Resource().Attributes().Get("process.cgroup")
+	assert.True(t, ok)
+	assert.EqualValues(t, "attr-val", attrVal.Str())
+	attrCount++
+	attrVal, ok = rm.Resource().Attributes().Get("process.command")
 	assert.True(t, ok)
 	assert.EqualValues(t, "attr-val", attrVal.Str())
 	attrCount++
@@ -166,6 +175,19 @@ func TestAllMetrics(t *testing.T) {
 			assert.True(t, ok)
 			assert.Equal(t, "involuntary", attrVal.Str())
 			validatedMetrics["process.context_switches"] = struct{}{}
+		case "process.count":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Number of processes in the group. Only reported when `group_by` is set.", ms.At(i).Description())
+			assert.Equal(t, "{processes}", ms.At(i).Unit())
+			assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			validatedMetrics["process.count"] = struct{}{}
 		case "process.cpu.time":
 			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
 			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
@@ -356,6 +378,7 @@ func TestNoMetrics(t *testing.T) {
 	ts := pcommon.Timestamp(1_000_001_000)
 	metricsSettings := MetricsSettings{
 		ProcessContextSwitches:     MetricSettings{Enabled: false},
+		ProcessCount:               MetricSettings{Enabled: false},
 		ProcessCPUTime:             MetricSettings{Enabled: false},
 		ProcessCPUUtilization:      MetricSettings{Enabled: false},
 		ProcessDiskIo:              MetricSettings{Enabled: false},
@@ -377,6 +400,7 @@ func TestNoMetrics(t *testing.T) {
 
 	assert.Equal(t, 0, observedLogs.Len())
 	mb.RecordProcessContextSwitchesDataPoint(ts, 1, AttributeContextSwitchType(1))
+	mb.RecordProcessCountDataPoint(ts, 1)
 	mb.RecordProcessCPUTimeDataPoint(ts, 1, AttributeState(1))
 	mb.RecordProcessCPUUtilizationDataPoint(ts, 1, AttributeState(1))
 	mb.RecordProcessDiskIoDataPoint(ts, 1, AttributeDirection(1))
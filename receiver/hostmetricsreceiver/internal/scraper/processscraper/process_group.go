@@ -0,0 +1,436 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
+
+import (
+	"bufio"
+	"fmt"
+	"runtime"
+	"strings"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
+)
+
+// defaultGroupStaleness is the default duration the counters of the processes that exited are kept
+// for a group without running processes.
+const defaultGroupStaleness = 5 * time.Minute
+
+const (
+	groupByExecutableName = "executable_name"
+	groupByCommand        = "command"
+	groupByOwner          = "owner"
+	groupByCgroup         = "cgroup"
+)
+
+func validateGroupBy(groupBy []string) error {
+	for _, key := range groupBy {
+		switch key {
+		case groupByExecutableName, groupByCommand, groupByOwner:
+		case groupByCgroup:
+			if runtime.GOOS != "linux" {
+				return fmt.Errorf("group_by key %q is only available on Linux", key)
+			}
+		default:
+			return fmt.Errorf("invalid group_by key %q, must be one of %s, %s, %s or %s",
+				key, groupByExecutableName, groupByCommand, groupByOwner, groupByCgroup)
+		}
+	}
+	return nil
+}
+
+// groupKey identifies a group of processes, only the fields of the group_by keys are set.
+type groupKey struct {
+	executableName string
+	command        string
+	owner          string
+	cgroup         string
+}
+
+func (k groupKey) resourceOptions(groupBy []string) []metadata.ResourceMetricsOption {
+	opts := make([]metadata.ResourceMetricsOption, 0, len(groupBy))
+	for _, key := range groupBy {
+		switch key {
+		case groupByExecutableName:
+			opts = append(opts, metadata.WithProcessExecutableName(k.executableName))
+		case groupByCommand:
+			opts = append(opts, metadata.WithProcessCommand(k.command))
+		case groupByOwner:
+			opts = append(opts, metadata.WithProcessOwner(k.owner))
+		case groupByCgroup:
+			opts = append(opts, metadata.WithProcessCgroup(k.cgroup))
+		}
+	}
+	return opts
+}
+
+// processCounters are the cumulative counters of a process that are summed up per group.
+type processCounters struct {
+	cpuUser    float64
+	cpuSystem  float64
+	cpuWait    float64
+	readBytes  int64
+	writeBytes int64
+	readOps    int64
+	writeOps   int64
+}
+
+func (c processCounters) add(o processCounters) processCounters {
+	return processCounters{
+		cpuUser:    c.cpuUser + o.cpuUser,
+		cpuSystem:  c.cpuSystem + o.cpuSystem,
+		cpuWait:    c.cpuWait + o.cpuWait,
+		readBytes:  c.readBytes + o.readBytes,
+		writeBytes: c.writeBytes + o.writeBytes,
+		readOps:    c.readOps + o.readOps,
+		writeOps:   c.writeOps + o.writeOps,
+	}
+}
+
+func (c processCounters) sub(o processCounters) processCounters {
+	return processCounters{
+		cpuUser:    c.cpuUser - o.cpuUser,
+		cpuSystem:  c.cpuSystem - o.cpuSystem,
+		cpuWait:    c.cpuWait - o.cpuWait,
+		readBytes:  c.readBytes - o.readBytes,
+		writeBytes: c.writeBytes - o.writeBytes,
+		readOps:    c.readOps - o.readOps,
+		writeOps:   c.writeOps - o.writeOps,
+	}
+}
+
+// processID identifies a process, the create time distinguishes the processes reusing a pid.
+type processID struct {
+	pid        int32
+	createTime int64
+}
+
+// trackedProcess is a process contributing to the counters of a group, from the baseline
+// counters it had when it joined the group to the last counters read. A process missing from a
+// scrape is kept for the staleness period, so that its contribution isn't counted twice if it is
+// seen again, e.g. after a transient error reading it.
+type trackedProcess struct {
+	group    groupKey
+	baseline processCounters
+	last     processCounters
+	lastSeen time.Time
+	// whether the contribution of the process was moved to the retired counters of its group
+	exited bool
+}
+
+func (p *trackedProcess) contribution() processCounters {
+	return p.last.sub(p.baseline)
+}
+
+// processGroups keeps the state needed for the cumulative counters of the groups to never go
+// backwards when their processes exit.
+type processGroups struct {
+	processes map[processID]*trackedProcess
+	// the contributions of the processes that left the groups
+	retired map[groupKey]processCounters
+	// the last time the groups with retired counters had a running process
+	lastSeen map[groupKey]time.Time
+	// the start time of the cumulative counters of the groups, reset when a group is forgotten
+	starts    map[groupKey]pcommon.Timestamp
+	staleness time.Duration
+}
+
+func newProcessGroups(staleness time.Duration) *processGroups {
+	return &processGroups{
+		processes: map[processID]*trackedProcess{},
+		retired:   map[groupKey]processCounters{},
+		lastSeen:  map[groupKey]time.Time{},
+		starts:    map[groupKey]pcommon.Timestamp{},
+		staleness: staleness,
+	}
+}
+
+// track records the last counters of a process.
+func (g *processGroups) track(id processID, group groupKey, counters processCounters) {
+	p, ok := g.processes[id]
+	if !ok {
+		g.processes[id] = &trackedProcess{group: group, last: counters}
+		return
+	}
+	if p.exited {
+		// the process is seen again, its contribution is back out of the retired counters
+		g.retired[p.group] = g.retired[p.group].sub(p.contribution())
+		p.exited = false
+	}
+	if p.group != group {
+		// the process moved, e.g. to another cgroup: its past contribution stays with its previous group
+		g.retire(p)
+		p.group = group
+		p.baseline = p.last
+	}
+	p.last = counters
+}
+
+// update moves the contributions of the processes that weren't seen to the retired counters,
+// forgets the processes and the retired counters of the groups that weren't seen for the staleness
+// period, and sets the start time of the groups created.
+func (g *processGroups) update(seen map[processID]bool, running map[groupKey]*groupStats, now time.Time) {
+	for id, p := range g.processes {
+		if seen[id] {
+			p.lastSeen = now
+			continue
+		}
+		if !p.exited {
+			g.retire(p)
+			p.exited = true
+		}
+		if now.Sub(p.lastSeen) >= g.staleness {
+			delete(g.processes, id)
+		}
+	}
+
+	for group := range g.retired {
+		if _, ok := running[group]; ok {
+			g.lastSeen[group] = now
+			continue
+		}
+		lastSeen, ok := g.lastSeen[group]
+		if !ok {
+			lastSeen = now
+			g.lastSeen[group] = now
+		}
+		if now.Sub(lastSeen) >= g.staleness {
+			delete(g.retired, group)
+			delete(g.lastSeen, group)
+		}
+	}
+
+	for group := range g.starts {
+		_, isRunning := running[group]
+		_, hasRetired := g.retired[group]
+		if !isRunning && !hasRetired {
+			delete(g.starts, group)
+		}
+	}
+	// the counters of a group created start with the oldest of its processes
+	created := map[groupKey]bool{}
+	for group := range running {
+		if _, ok := g.starts[group]; !ok {
+			created[group] = true
+		}
+	}
+	for id, p := range g.processes {
+		if p.exited || !created[p.group] {
+			continue
+		}
+		start := pcommon.Timestamp(id.createTime * 1e6)
+		if current, ok := g.starts[p.group]; !ok || start < current {
+			g.starts[p.group] = start
+		}
+	}
+}
+
+func (g *processGroups) retire(p *trackedProcess) {
+	g.retired[p.group] = g.retired[p.group].add(p.contribution())
+}
+
+// counters returns the cumulative counters of the groups with running processes.
+func (g *processGroups) counters() map[groupKey]processCounters {
+	counters := make(map[groupKey]processCounters, len(g.retired))
+	for _, p := range g.processes {
+		if !p.exited {
+			counters[p.group] = counters[p.group].add(p.contribution())
+		}
+	}
+	for group, c := range counters {
+		counters[group] = c.add(g.retired[group])
+	}
+	return counters
+}
+
+// groupStats are the stats of the processes of a group currently running.
+type groupStats struct {
+	count      int64
+	rss        int64
+	vms        int64
+	threads    int64
+	hasThreads bool
+}
+
+func (s *scraper) groupKey(md *processMetadata) (groupKey, error) {
+	var key groupKey
+	for _, groupBy := range s.config.GroupBy {
+		switch groupBy {
+		case groupByExecutableName:
+			key.executableName = md.executable.name
+		case groupByCommand:
+			if md.command != nil {
+				key.command = md.command.command
+			}
+		case groupByOwner:
+			key.owner = md.username
+		case groupByCgroup:
+			cgroup, err := s.getProcessCgroup(md.pid)
+			if err != nil {
+				return key, err
+			}
+			key.cgroup = cgroup
+		}
+	}
+	return key, nil
+}
+
+// scrapeAndAppendGroupMetrics records the metrics of the groups of processes. The cpu time, disk
+// io and disk operations of the processes that exited keep counting in their groups, so that the
+// cumulative metrics of the groups never go backwards.
+func (s *scraper) scrapeAndAppendGroupMetrics(data []*processMetadata, errs *scrapererror.ScrapeErrors) {
+	stats := map[groupKey]*groupStats{}
+	var keys []groupKey
+	seen := make(map[processID]bool, len(data))
+
+	for _, md := range data {
+		key, err := s.groupKey(md)
+		if err != nil {
+			errs.AddPartial(metricsLen, fmt.Errorf("error reading cgroup for process %q (pid %v): %w", md.executable.name, md.pid, err))
+			continue
+		}
+
+		gs, ok := stats[key]
+		if !ok {
+			gs = &groupStats{}
+			stats[key] = gs
+			keys = append(keys, key)
+		}
+		gs.count++
+
+		id := processID{pid: md.pid, createTime: md.createTime}
+		seen[id] = true
+
+		counters, err := s.readProcessCounters(md.handle)
+		if err != nil {
+			errs.AddPartial(cpuMetricsLen+diskMetricsLen, fmt.Errorf("error reading cpu times or disk usage for process %q (pid %v): %w", md.executable.name, md.pid, err))
+			if p, tracked := s.groups.processes[id]; tracked {
+				// keep the last counters read
+				counters = p.last
+			}
+		}
+		s.groups.track(id, key, counters)
+
+		if err = s.readGroupMemoryAndThreads(gs, md.handle); err != nil {
+			errs.AddPartial(memoryMetricsLen+threadMetricsLen, fmt.Errorf("error reading memory info or threads for process %q (pid %v): %w", md.executable.name, md.pid, err))
+		}
+	}
+
+	scrapeTime := time.Now()
+	s.groups.update(seen, stats, scrapeTime)
+	groupCounters := s.groups.counters()
+
+	now := pcommon.NewTimestampFromTime(scrapeTime)
+	for _, key := range keys {
+		gs := stats[key]
+		counters := groupCounters[key]
+
+		s.mb.RecordProcessCountDataPoint(now, gs.count)
+		if s.config.Metrics.ProcessCPUTime.Enabled {
+			s.recordCPUTimeMetric(now, &cpu.TimesStat{User: counters.cpuUser, System: counters.cpuSystem, Iowait: counters.cpuWait})
+		}
+		s.mb.RecordProcessMemoryPhysicalUsageDataPoint(now, gs.rss)
+		s.mb.RecordProcessMemoryVirtualUsageDataPoint(now, gs.vms)
+		s.mb.RecordProcessMemoryUsageDataPoint(now, gs.rss)
+		s.mb.RecordProcessMemoryVirtualDataPoint(now, gs.vms)
+		s.mb.RecordProcessDiskIoDataPoint(now, counters.readBytes, metadata.AttributeDirectionRead)
+		s.mb.RecordProcessDiskIoDataPoint(now, counters.writeBytes, metadata.AttributeDirectionWrite)
+		s.mb.RecordProcessDiskOperationsDataPoint(now, counters.readOps, metadata.AttributeDirectionRead)
+		s.mb.RecordProcessDiskOperationsDataPoint(now, counters.writeOps, metadata.AttributeDirectionWrite)
+		if gs.hasThreads {
+			s.mb.RecordProcessThreadsDataPoint(now, gs.threads)
+		}
+
+		options := append(key.resourceOptions(s.config.GroupBy), metadata.WithStartTimeOverride(s.groups.starts[key]))
+		s.mb.EmitForResource(options...)
+	}
+}
+
+func (s *scraper) readProcessCounters(handle processHandle) (processCounters, error) {
+	var counters processCounters
+
+	if s.config.Metrics.ProcessCPUTime.Enabled {
+		times, err := handle.Times()
+		if err != nil {
+			return counters, err
+		}
+		counters.cpuUser, counters.cpuSystem, counters.cpuWait = times.User, times.System, times.Iowait
+	}
+
+	if s.config.Metrics.ProcessDiskIo.Enabled || s.config.Metrics.ProcessDiskOperations.Enabled {
+		io, err := handle.IOCounters()
+		if err != nil {
+			return counters, err
+		}
+		counters.readBytes, counters.writeBytes = int64(io.ReadBytes), int64(io.WriteBytes)
+		counters.readOps, counters.writeOps = int64(io.ReadCount), int64(io.WriteCount)
+	}
+
+	return counters, nil
+}
+
+func (s *scraper) readGroupMemoryAndThreads(gs *groupStats, handle processHandle) error {
+	if s.config.Metrics.ProcessMemoryPhysicalUsage.Enabled || s.config.Metrics.ProcessMemoryVirtualUsage.Enabled ||
+		s.config.Metrics.ProcessMemoryUsage.Enabled || s.config.Metrics.ProcessMemoryVirtual.Enabled {
+		mem, err := handle.MemoryInfo()
+		if err != nil {
+			return err
+		}
+		gs.rss += int64(mem.RSS)
+		gs.vms += int64(mem.VMS)
+	}
+
+	if s.config.Metrics.ProcessThreads.Enabled {
+		threads, err := handle.NumThreads()
+		if err != nil {
+			return err
+		}
+		gs.threads += int64(threads)
+		gs.hasThreads = true
+	}
+
+	return nil
+}
+
+// parseCgroup returns the cgroup of a process from the content of proc/[pid]/cgroup: the cgroup v2
+// path when the unified hierarchy is used, the path in the systemd hierarchy otherwise.
+func parseCgroup(content string) string {
+	var first, systemd string
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		// hierarchy-ID:controller-list:cgroup-path
+		fields := strings.SplitN(scanner.Text(), ":", 3)
+		if len(fields) != 3 {
+			continue
+		}
+		if fields[0] == "0" && fields[1] == "" {
+			return fields[2]
+		}
+		if fields[1] == "name=systemd" {
+			systemd = fields[2]
+		}
+		if first == "" {
+			first = fields[2]
+		}
+	}
+	if systemd != "" {
+		return systemd
+	}
+	return first
+}
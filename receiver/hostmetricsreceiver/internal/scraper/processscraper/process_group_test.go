@@ -0,0 +1,417 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package processscraper
+
+import (
+	"context"
+	"errors"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/shirou/gopsutil/v3/cpu"
+	"github.com/shirou/gopsutil/v3/process"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper/internal/metadata"
+)
+
+type groupedProcess struct {
+	pid        int32
+	createTime int64
+	name       string
+	owner      string
+	cpuUser    float64
+	rss        uint64
+	ioRead     uint64
+	ioWrites   uint64
+}
+
+type processHandlesWithPidsMock struct {
+	pids    []int32
+	handles []*processHandleMock
+}
+
+func (p *processHandlesWithPidsMock) Pid(index int) int32 {
+	return p.pids[index]
+}
+
+func (p *processHandlesWithPidsMock) At(index int) processHandle {
+	return p.handles[index]
+}
+
+func (p *processHandlesWithPidsMock) Len() int {
+	return len(p.handles)
+}
+
+func newGroupedProcessesMock(processes ...groupedProcess) *processHandlesWithPidsMock {
+	handles := &processHandlesWithPidsMock{}
+	for _, proc := range processes {
+		createTime := proc.createTime
+		if createTime == 0 {
+			createTime = 1000
+		}
+		handleMock := &processHandleMock{}
+		handleMock.On("Name").Return(proc.name, nil)
+		handleMock.On("Exe").Return("/usr/bin/"+proc.name, nil)
+		handleMock.On("Username").Return(proc.owner, nil)
+		handleMock.On("Cmdline").Return(proc.name, nil)
+		handleMock.On("CmdlineSlice").Return([]string{proc.name}, nil)
+		handleMock.On("CreateTime").Return(createTime, nil)
+		handleMock.On("Parent").Return(&process.Process{Pid: 1}, nil)
+		handleMock.On("Times").Return(&cpu.TimesStat{User: proc.cpuUser}, nil)
+		handleMock.On("MemoryInfo").Return(&process.MemoryInfoStat{RSS: proc.rss}, nil)
+		handleMock.On("IOCounters").Return(&process.IOCountersStat{ReadBytes: proc.ioRead, WriteCount: proc.ioWrites}, nil)
+		handleMock.On("NumThreads").Return(int32(1), nil)
+		handles.pids = append(handles.pids, proc.pid)
+		handles.handles = append(handles.handles, handleMock)
+	}
+	return handles
+}
+
+func newGroupByScraper(t *testing.T, groupBy ...string) *scraper {
+	return newGroupByScraperWithStaleness(t, defaultGroupStaleness, groupBy...)
+}
+
+func newGroupByScraperWithStaleness(t *testing.T, staleness time.Duration, groupBy ...string) *scraper {
+	metricsSettings := metadata.DefaultMetricsSettings()
+	metricsSettings.ProcessThreads.Enabled = true
+	metricsSettings.ProcessDiskOperations.Enabled = true
+	scraper, err := newProcessScraper(receivertest.NewNopCreateSettings(), &Config{Metrics: metricsSettings, GroupBy: groupBy, GroupStaleness: staleness})
+	require.NoError(t, err)
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	return scraper
+}
+
+func scrapeGroups(t *testing.T, scraper *scraper, processes ...groupedProcess) map[string]pmetric.ResourceMetrics {
+	scraper.getProcessHandles = func() (processHandles, error) {
+		return newGroupedProcessesMock(processes...), nil
+	}
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	groups := map[string]pmetric.ResourceMetrics{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		_, hasPid := rm.Resource().Attributes().Get("process.pid")
+		assert.False(t, hasPid)
+		var key string
+		rm.Resource().Attributes().Range(func(k string, v pcommon.Value) bool {
+			key += k + "=" + v.AsString() + ";"
+			return true
+		})
+		groups[key] = rm
+	}
+	return groups
+}
+
+func groupMetric(t *testing.T, rm pmetric.ResourceMetrics, name string) pmetric.Metric {
+	metrics := rm.ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "metric %q not found", name)
+	return pmetric.Metric{}
+}
+
+func groupCPUUserTime(t *testing.T, rm pmetric.ResourceMetrics) float64 {
+	dps := groupMetric(t, rm, "process.cpu.time").Sum().DataPoints()
+	for i := 0; i < dps.Len(); i++ {
+		if state, _ := dps.At(i).Attributes().Get("state"); state.Str() == "user" {
+			return dps.At(i).DoubleValue()
+		}
+	}
+	require.Fail(t, "user cpu time not found")
+	return 0
+}
+
+func groupProcessCount(t *testing.T, rm pmetric.ResourceMetrics) int64 {
+	return groupMetric(t, rm, "process.count").Sum().DataPoints().At(0).IntValue()
+}
+
+func TestScrapeMetrics_GroupBy(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper := newGroupByScraper(t, groupByExecutableName, groupByOwner)
+	groups := scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, name: "nginx", owner: "www", cpuUser: 5, rss: 100, ioRead: 10, ioWrites: 1},
+		groupedProcess{pid: 11, name: "nginx", owner: "www", cpuUser: 3, rss: 50, ioRead: 20, ioWrites: 2},
+		groupedProcess{pid: 12, name: "nginx", owner: "root", cpuUser: 1, rss: 10},
+		groupedProcess{pid: 13, name: "redis", owner: "www", cpuUser: 2, rss: 200},
+	)
+	require.Len(t, groups, 3)
+
+	nginx := groups["process.executable.name=nginx;process.owner=www;"]
+	assert.Equal(t, int64(2), groupProcessCount(t, nginx))
+	assert.Equal(t, int64(150), groupMetric(t, nginx, "process.memory.physical_usage").Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(2), groupMetric(t, nginx, "process.threads").Sum().DataPoints().At(0).IntValue())
+
+	diskIO := groupMetric(t, nginx, "process.disk.io").Sum().DataPoints()
+	require.Equal(t, 2, diskIO.Len())
+	assert.Equal(t, int64(30), diskIO.At(0).IntValue())
+	diskOperations := groupMetric(t, nginx, "process.disk.operations").Sum().DataPoints()
+	require.Equal(t, 2, diskOperations.Len())
+	assert.Equal(t, int64(3), diskOperations.At(1).IntValue())
+
+	if runtime.GOOS == "linux" || runtime.GOOS == "windows" {
+		assert.Equal(t, float64(8), groupCPUUserTime(t, nginx))
+	}
+
+	assert.Equal(t, int64(1), groupProcessCount(t, groups["process.executable.name=nginx;process.owner=root;"]))
+	assert.Equal(t, int64(1), groupProcessCount(t, groups["process.executable.name=redis;process.owner=www;"]))
+}
+
+func TestScrapeMetrics_GroupByExitedProcesses(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper := newGroupByScraper(t, groupByExecutableName)
+	const key = "process.executable.name=worker;"
+
+	groups := scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, name: "worker", cpuUser: 5, ioRead: 100},
+		groupedProcess{pid: 11, name: "worker", cpuUser: 3, ioRead: 50},
+	)
+	assert.Equal(t, int64(2), groupProcessCount(t, groups[key]))
+	assert.Equal(t, int64(150), groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+
+	// pid 11 exited, its counters keep counting in the group
+	groups = scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, name: "worker", cpuUser: 6, ioRead: 120},
+	)
+	assert.Equal(t, int64(1), groupProcessCount(t, groups[key]))
+	assert.Equal(t, int64(170), groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+
+	// a new process joins the group
+	groups = scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, name: "worker", cpuUser: 6, ioRead: 120},
+		groupedProcess{pid: 12, name: "worker", cpuUser: 1, ioRead: 5},
+	)
+	assert.Equal(t, int64(2), groupProcessCount(t, groups[key]))
+	assert.Equal(t, int64(175), groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+
+	// no process left, the group is not reported
+	groups = scrapeGroups(t, scraper)
+	assert.Empty(t, groups)
+
+	// the group comes back with its previous counters
+	groups = scrapeGroups(t, scraper,
+		groupedProcess{pid: 13, name: "worker", cpuUser: 1, ioRead: 10},
+	)
+	assert.Equal(t, int64(185), groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+	if runtime.GOOS == "linux" || runtime.GOOS == "windows" {
+		assert.Equal(t, float64(11), groupCPUUserTime(t, groups[key]))
+	}
+}
+
+func TestScrapeMetrics_GroupByResetWithoutStaleness(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper := newGroupByScraperWithStaleness(t, 0, groupByExecutableName)
+	const key = "process.executable.name=cron-job;"
+
+	groups := scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, name: "cron-job", ioRead: 100},
+	)
+	assert.Equal(t, int64(100), groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+
+	// the group disappears and its counters are forgotten
+	groups = scrapeGroups(t, scraper)
+	assert.Empty(t, groups)
+	assert.Empty(t, scraper.groups.retired)
+	assert.Empty(t, scraper.groups.lastSeen)
+
+	groups = scrapeGroups(t, scraper,
+		groupedProcess{pid: 11, name: "cron-job", ioRead: 10},
+	)
+	assert.Equal(t, int64(10), groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+}
+
+func TestScrapeMetrics_GroupByStartTime(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+
+	scraper := newGroupByScraperWithStaleness(t, 0, groupByExecutableName)
+	const key = "process.executable.name=cron-job;"
+	startTime := func(groups map[string]pmetric.ResourceMetrics) pcommon.Timestamp {
+		return groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).StartTimestamp()
+	}
+
+	groups := scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, createTime: 2000, name: "cron-job", ioRead: 100},
+		groupedProcess{pid: 11, createTime: 1500, name: "cron-job", ioRead: 50},
+	)
+	assert.Equal(t, pcommon.Timestamp(1500*1e6), startTime(groups))
+
+	// a process joining the group doesn't change its start time
+	groups = scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, createTime: 2000, name: "cron-job", ioRead: 100},
+		groupedProcess{pid: 11, createTime: 1500, name: "cron-job", ioRead: 50},
+		groupedProcess{pid: 12, createTime: 1000, name: "cron-job", ioRead: 10},
+	)
+	assert.Equal(t, pcommon.Timestamp(1500*1e6), startTime(groups))
+
+	// the group is forgotten after the staleness period
+	groups = scrapeGroups(t, scraper)
+	assert.Empty(t, groups)
+	assert.Empty(t, scraper.groups.starts)
+
+	// the group comes back with counters reset and a new start time
+	groups = scrapeGroups(t, scraper,
+		groupedProcess{pid: 13, createTime: 5000, name: "cron-job", ioRead: 10},
+	)
+	assert.Equal(t, int64(10), groupMetric(t, groups[key], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, pcommon.Timestamp(5000*1e6), startTime(groups))
+}
+
+func TestProcessGroupsMissedScrape(t *testing.T) {
+	groups := newProcessGroups(time.Minute)
+	group := groupKey{executableName: "worker"}
+	running := map[groupKey]*groupStats{group: {count: 1}}
+	start := time.Unix(1000, 0)
+
+	groups.track(processID{pid: 1, createTime: 10}, group, processCounters{readBytes: 10})
+	groups.update(map[processID]bool{{pid: 1, createTime: 10}: true}, running, start)
+
+	// the process is missing from a scrape, its contribution stays in the group
+	groups.update(map[processID]bool{}, map[groupKey]*groupStats{}, start.Add(10*time.Second))
+	assert.Equal(t, map[groupKey]processCounters{group: {readBytes: 10}}, groups.retired)
+
+	// the process is seen again and isn't counted twice
+	groups.track(processID{pid: 1, createTime: 10}, group, processCounters{readBytes: 15})
+	groups.update(map[processID]bool{{pid: 1, createTime: 10}: true}, running, start.Add(20*time.Second))
+	assert.Equal(t, map[groupKey]processCounters{group: {readBytes: 15}}, groups.counters())
+	assert.Equal(t, map[groupKey]pcommon.Timestamp{group: pcommon.Timestamp(10 * 1e6)}, groups.starts)
+
+	// a process reusing the pid is another process
+	groups.track(processID{pid: 1, createTime: 30}, group, processCounters{readBytes: 5})
+	groups.update(map[processID]bool{{pid: 1, createTime: 30}: true}, running, start.Add(30*time.Second))
+	assert.Equal(t, map[groupKey]processCounters{group: {readBytes: 20}}, groups.counters())
+}
+
+func TestProcessGroupsStaleness(t *testing.T) {
+	groups := newProcessGroups(time.Minute)
+	live, gone := groupKey{executableName: "live"}, groupKey{executableName: "gone"}
+	start := time.Unix(1000, 0)
+
+	groups.track(processID{pid: 1}, live, processCounters{readBytes: 10})
+	groups.track(processID{pid: 2}, live, processCounters{readBytes: 20})
+	groups.track(processID{pid: 3}, gone, processCounters{readBytes: 30})
+	groups.update(map[processID]bool{{pid: 1}: true, {pid: 2}: true, {pid: 3}: true},
+		map[groupKey]*groupStats{live: {count: 2}, gone: {count: 1}}, start)
+	assert.Equal(t, map[groupKey]processCounters{
+		live: {readBytes: 30},
+		gone: {readBytes: 30},
+	}, groups.counters())
+
+	// pid 2 and 3 exit, the retired counters of both groups are kept
+	running := map[groupKey]*groupStats{live: {count: 1}}
+	groups.update(map[processID]bool{{pid: 1}: true}, running, start.Add(30*time.Second))
+	assert.Equal(t, map[groupKey]processCounters{live: {readBytes: 20}, gone: {readBytes: 30}}, groups.retired)
+	assert.Equal(t, map[groupKey]processCounters{live: {readBytes: 30}}, groups.counters())
+
+	// the group without running process is forgotten after the staleness period
+	groups.update(map[processID]bool{{pid: 1}: true}, running, start.Add(90*time.Second))
+	assert.Equal(t, map[groupKey]processCounters{live: {readBytes: 20}}, groups.retired)
+	assert.Equal(t, map[groupKey]time.Time{live: start.Add(90 * time.Second)}, groups.lastSeen)
+}
+
+func TestScrapeMetrics_GroupByCgroup(t *testing.T) {
+	skipTestOnUnsupportedOS(t)
+	if runtime.GOOS != "linux" {
+		t.Skip("cgroups are only available on Linux")
+	}
+
+	scraper := newGroupByScraper(t, groupByCgroup)
+	cgroups := map[int32]string{10: "/system.slice/a.service", 11: "/system.slice/a.service"}
+	scraper.getProcessCgroup = func(pid int32) (string, error) {
+		if cgroup, ok := cgroups[pid]; ok {
+			return cgroup, nil
+		}
+		return "", errors.New("no such process")
+	}
+	keyA := "process.cgroup=/system.slice/a.service;"
+	keyB := "process.cgroup=/system.slice/b.service;"
+
+	groups := scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, name: "a", ioRead: 100},
+		groupedProcess{pid: 11, name: "a", ioRead: 10},
+	)
+	require.Len(t, groups, 1)
+	assert.Equal(t, int64(110), groupMetric(t, groups[keyA], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+
+	// pid 11 moved to another cgroup, only what it did from now on counts in its new cgroup
+	cgroups[11] = "/system.slice/b.service"
+	groups = scrapeGroups(t, scraper,
+		groupedProcess{pid: 10, name: "a", ioRead: 100},
+		groupedProcess{pid: 11, name: "a", ioRead: 15},
+	)
+	require.Len(t, groups, 2)
+	assert.Equal(t, int64(110), groupMetric(t, groups[keyA], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(5), groupMetric(t, groups[keyB], "process.disk.io").Sum().DataPoints().At(0).IntValue())
+}
+
+func TestNewProcessScraper_InvalidGroupBy(t *testing.T) {
+	_, err := newProcessScraper(receivertest.NewNopCreateSettings(), &Config{GroupBy: []string{"pid"}})
+	assert.EqualError(t, err, `invalid group_by key "pid", must be one of executable_name, command, owner or cgroup`)
+}
+
+func TestNewProcessScraper_NegativeGroupStaleness(t *testing.T) {
+	_, err := newProcessScraper(receivertest.NewNopCreateSettings(), &Config{GroupBy: []string{groupByOwner}, GroupStaleness: -time.Second})
+	assert.EqualError(t, err, "group_staleness must not be negative, got -1s")
+}
+
+func TestParseCgroup(t *testing.T) {
+	testCases := []struct {
+		name     string
+		content  string
+		expected string
+	}{
+		{
+			name:     "unified hierarchy",
+			content:  "0::/system.slice/docker.service\n",
+			expected: "/system.slice/docker.service",
+		},
+		{
+			name:     "hybrid hierarchy",
+			content:  "12:cpu,cpuacct:/user.slice\n1:name=systemd:/user.slice/user-1000.slice/session-2.scope\n0::/user.slice/user-1000.slice/session-2.scope\n",
+			expected: "/user.slice/user-1000.slice/session-2.scope",
+		},
+		{
+			name:     "legacy hierarchy",
+			content:  "12:cpu,cpuacct:/user.slice\n1:name=systemd:/user.slice/user-1000.slice/session-2.scope\n",
+			expected: "/user.slice/user-1000.slice/session-2.scope",
+		},
+		{
+			name:     "no systemd hierarchy",
+			content:  "12:cpu,cpuacct:/user.slice\n11:memory:/\n",
+			expected: "/user.slice",
+		},
+		{
+			name:    "empty",
+			content: "",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, parseCgroup(tc.content))
+		})
+	}
+}
@@ -55,9 +55,11 @@ type scraper struct {
 	excludeFS          filterset.FilterSet
 	scrapeProcessDelay time.Duration
 	ucal               *ucal.CPUUtilizationCalculator
+	groups             *processGroups
 	// for mocking
 	getProcessCreateTime func(p processHandle) (int64, error)
 	getProcessHandles    func() (processHandles, error)
+	getProcessCgroup     func(pid int32) (string, error)
 }
 
 // newProcessScraper creates a Process Scraper
@@ -67,6 +69,7 @@ func newProcessScraper(settings receiver.CreateSettings, cfg *Config) (*scraper,
 		config:               cfg,
 		getProcessCreateTime: processHandle.CreateTime,
 		getProcessHandles:    getProcessHandlesInternal,
+		getProcessCgroup:     getProcessCgroup,
 		scrapeProcessDelay:   cfg.ScrapeProcessDelay,
 		ucal:                 &ucal.CPUUtilizationCalculator{},
 	}
@@ -87,6 +90,16 @@ func newProcessScraper(settings receiver.CreateSettings, cfg *Config) (*scraper,
 		}
 	}
 
+	if len(cfg.GroupBy) > 0 {
+		if err = validateGroupBy(cfg.GroupBy); err != nil {
+			return nil, err
+		}
+		if cfg.GroupStaleness < 0 {
+			return nil, fmt.Errorf("group_staleness must not be negative, got %v", cfg.GroupStaleness)
+		}
+		scraper.groups = newProcessGroups(cfg.GroupStaleness)
+	}
+
 	return scraper, nil
 }
 
@@ -108,6 +121,11 @@ func (s *scraper) scrape(_ context.Context) (pmetric.Metrics, error) {
 		errs.AddPartial(partialErr.Failed, partialErr)
 	}
 
+	if s.groups != nil {
+		s.scrapeAndAppendGroupMetrics(data, &errs)
+		return s.mb.Emit(), errs.Combine()
+	}
+
 	for _, md := range data {
 		now := pcommon.NewTimestampFromTime(time.Now())
 
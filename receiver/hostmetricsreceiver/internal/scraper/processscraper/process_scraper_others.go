@@ -18,6 +18,7 @@
 package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 
 import (
+	"errors"
 	"github.com/shirou/gopsutil/v3/cpu"
 	"go.opentelemetry.io/collector/pdata/pcommon"
 
@@ -35,3 +36,7 @@ func getProcessExecutable(processHandle) (*executableMetadata, error) {
 func getProcessCommand(processHandle) (*commandMetadata, error) {
 	return nil, nil
 }
+
+func getProcessCgroup(pid int32) (string, error) {
+	return "", errors.New("process cgroups are only available on Linux")
+}
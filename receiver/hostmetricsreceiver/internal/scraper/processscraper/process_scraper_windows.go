@@ -18,6 +18,7 @@
 package processscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/processscraper"
 
 import (
+	"errors"
 	"path/filepath"
 	"regexp"
 
@@ -67,3 +68,7 @@ func getProcessCommand(proc processHandle) (*commandMetadata, error) {
 	command := &commandMetadata{command: cmd, commandLine: cmdline}
 	return command, nil
 }
+
+func getProcessCgroup(pid int32) (string, error) {
+	return "", errors.New("process cgroups are only available on Linux")
+}
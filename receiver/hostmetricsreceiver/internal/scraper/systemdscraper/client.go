@@ -0,0 +1,59 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import (
+	"context"
+	"fmt"
+)
+
+// unitStatus is the state of a unit loaded by systemd.
+type unitStatus struct {
+	name        string
+	loadState   string
+	activeState string
+	subState    string
+}
+
+// serviceProperties are the properties of a service unit.
+type serviceProperties struct {
+	restarts int64
+	mainPID  int64
+}
+
+// systemdClient reads the state of the units from systemd.
+type systemdClient interface {
+	// listUnits returns the units currently loaded by systemd.
+	listUnits(ctx context.Context) ([]unitStatus, error)
+	// serviceProperties returns the properties of a service unit.
+	serviceProperties(ctx context.Context, name string) (serviceProperties, error)
+	close() error
+}
+
+// toInt64 converts a numeric property value to an int64.
+func toInt64(property string, value interface{}) (int64, error) {
+	switch v := value.(type) {
+	case uint32:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint64:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("unexpected type %T of property %s", value, property)
+	}
+}
@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/coreos/go-systemd/v22/dbus"
+)
+
+// dbusClient reads the state of the units from the systemd D-Bus API.
+type dbusClient struct {
+	conn *dbus.Conn
+}
+
+var _ systemdClient = (*dbusClient)(nil)
+
+func newDBusClient() (systemdClient, error) {
+	// the connection is closed once its context is done, it must outlive the start of the scraper
+	conn, err := dbus.NewWithContext(context.Background())
+	if err != nil {
+		return nil, err
+	}
+	return &dbusClient{conn: conn}, nil
+}
+
+func (c *dbusClient) listUnits(ctx context.Context) ([]unitStatus, error) {
+	units, err := c.conn.ListUnitsContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	statuses := make([]unitStatus, 0, len(units))
+	for _, unit := range units {
+		statuses = append(statuses, unitStatus{
+			name:        unit.Name,
+			loadState:   unit.LoadState,
+			activeState: unit.ActiveState,
+			subState:    unit.SubState,
+		})
+	}
+	return statuses, nil
+}
+
+func (c *dbusClient) serviceProperties(ctx context.Context, name string) (serviceProperties, error) {
+	properties, err := c.conn.GetUnitTypePropertiesContext(ctx, name, "Service")
+	if err != nil {
+		return serviceProperties{}, err
+	}
+
+	var props serviceProperties
+	if props.restarts, err = toInt64("NRestarts", properties["NRestarts"]); err != nil {
+		return serviceProperties{}, fmt.Errorf("invalid properties of %s: %w", name, err)
+	}
+	if props.mainPID, err = toInt64("MainPID", properties["MainPID"]); err != nil {
+		return serviceProperties{}, fmt.Errorf("invalid properties of %s: %w", name, err)
+	}
+	return props, nil
+}
+
+func (c *dbusClient) close() error {
+	c.conn.Close()
+	return nil
+}
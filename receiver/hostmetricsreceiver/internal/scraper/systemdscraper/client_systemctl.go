@@ -0,0 +1,126 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+const systemctl = "systemctl"
+
+// commandRunner runs a command and returns its standard output.
+type commandRunner func(ctx context.Context, name string, args ...string) ([]byte, error)
+
+func runCommand(ctx context.Context, name string, args ...string) ([]byte, error) {
+	return exec.CommandContext(ctx, name, args...).Output()
+}
+
+// systemctlClient reads the state of the units by parsing the output of `systemctl show`.
+type systemctlClient struct {
+	run commandRunner
+	// the properties of the services returned by the last listUnits call
+	services map[string]serviceProperties
+}
+
+var _ systemdClient = (*systemctlClient)(nil)
+
+func newSystemctlClient() (systemdClient, error) {
+	if _, err := exec.LookPath(systemctl); err != nil {
+		return nil, err
+	}
+	return &systemctlClient{run: runCommand}, nil
+}
+
+func (c *systemctlClient) listUnits(ctx context.Context) ([]unitStatus, error) {
+	// a single call returns the state of all the units along with the properties of the services
+	out, err := c.run(ctx, systemctl, "show", "--no-pager",
+		"--property=Id,LoadState,ActiveState,SubState,NRestarts,MainPID", "*")
+	if err != nil {
+		return nil, fmt.Errorf("failed to run systemctl show: %w", err)
+	}
+
+	units := []unitStatus{}
+	c.services = map[string]serviceProperties{}
+	for _, block := range parseShowOutput(out) {
+		unit := unitStatus{
+			name:        block["Id"],
+			loadState:   block["LoadState"],
+			activeState: block["ActiveState"],
+			subState:    block["SubState"],
+		}
+		if unit.name == "" {
+			continue
+		}
+		units = append(units, unit)
+
+		rawRestarts, hasRestarts := block["NRestarts"]
+		rawMainPID, hasMainPID := block["MainPID"]
+		if !hasRestarts || !hasMainPID {
+			continue
+		}
+		var props serviceProperties
+		if props.restarts, err = strconv.ParseInt(rawRestarts, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid NRestarts of %s: %w", unit.name, err)
+		}
+		if props.mainPID, err = strconv.ParseInt(rawMainPID, 10, 64); err != nil {
+			return nil, fmt.Errorf("invalid MainPID of %s: %w", unit.name, err)
+		}
+		c.services[unit.name] = props
+	}
+	return units, nil
+}
+
+func (c *systemctlClient) serviceProperties(_ context.Context, name string) (serviceProperties, error) {
+	props, ok := c.services[name]
+	if !ok {
+		return serviceProperties{}, fmt.Errorf("no properties reported for %s", name)
+	}
+	return props, nil
+}
+
+func (c *systemctlClient) close() error {
+	return nil
+}
+
+// parseShowOutput parses the output of `systemctl show`, made of blocks of key=value
+// lines separated by empty lines, one block per unit.
+func parseShowOutput(out []byte) []map[string]string {
+	var blocks []map[string]string
+	block := map[string]string{}
+	scanner := bufio.NewScanner(bytes.NewReader(out))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, block)
+				block = map[string]string{}
+			}
+			continue
+		}
+		if key, value, ok := strings.Cut(line, "="); ok {
+			block[key] = value
+		}
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
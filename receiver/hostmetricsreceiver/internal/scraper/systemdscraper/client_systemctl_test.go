@@ -0,0 +1,77 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemctlClient(t *testing.T) {
+	out, err := os.ReadFile(filepath.Join("testdata", "systemctl-show.txt"))
+	require.NoError(t, err)
+
+	var args []string
+	client := &systemctlClient{run: func(_ context.Context, name string, arg ...string) ([]byte, error) {
+		args = append([]string{name}, arg...)
+		return out, nil
+	}}
+
+	units, err := client.listUnits(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []string{"systemctl", "show", "--no-pager", "--property=Id,LoadState,ActiveState,SubState,NRestarts,MainPID", "*"}, args)
+	assert.Equal(t, []unitStatus{
+		{name: "nginx.service", loadState: "loaded", activeState: "active", subState: "running"},
+		{name: "backup.service", loadState: "loaded", activeState: "failed", subState: "failed"},
+		{name: "backup.timer", loadState: "loaded", activeState: "active", subState: "waiting"},
+		{name: "missing.service", loadState: "not-found", activeState: "inactive", subState: "dead"},
+	}, units)
+
+	props, err := client.serviceProperties(context.Background(), "nginx.service")
+	require.NoError(t, err)
+	assert.Equal(t, serviceProperties{restarts: 3, mainPID: 1234}, props)
+
+	_, err = client.serviceProperties(context.Background(), "backup.timer")
+	assert.EqualError(t, err, "no properties reported for backup.timer")
+}
+
+func TestSystemctlClientErrors(t *testing.T) {
+	client := &systemctlClient{run: func(context.Context, string, ...string) ([]byte, error) {
+		return nil, errors.New("exit status 1")
+	}}
+	_, err := client.listUnits(context.Background())
+	assert.EqualError(t, err, "failed to run systemctl show: exit status 1")
+
+	client = &systemctlClient{run: func(context.Context, string, ...string) ([]byte, error) {
+		return []byte("Id=nginx.service\nNRestarts=many\nMainPID=1\n"), nil
+	}}
+	_, err = client.listUnits(context.Background())
+	assert.ErrorContains(t, err, "invalid NRestarts of nginx.service")
+}
+
+func TestToInt64(t *testing.T) {
+	value, err := toInt64("MainPID", uint32(42))
+	require.NoError(t, err)
+	assert.Equal(t, int64(42), value)
+
+	_, err = toInt64("MainPID", "42")
+	assert.EqualError(t, err, "unexpected type string of property MainPID")
+}
@@ -0,0 +1,456 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/confmap"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	conventions "go.opentelemetry.io/collector/semconv/v1.9.0"
+)
+
+// MetricSettings provides common settings for a particular metric.
+type MetricSettings struct {
+	Enabled bool `mapstructure:"enabled"`
+
+	enabledProvidedByUser bool
+}
+
+// IsEnabledProvidedByUser returns true if `enabled` option is explicitly set in user settings to any value.
+func (ms *MetricSettings) IsEnabledProvidedByUser() bool {
+	return ms.enabledProvidedByUser
+}
+
+func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
+	if parser == nil {
+		return nil
+	}
+	err := parser.Unmarshal(ms, confmap.WithErrorUnused())
+	if err != nil {
+		return err
+	}
+	ms.enabledProvidedByUser = parser.IsSet("enabled")
+	return nil
+}
+
+// MetricsSettings provides settings for hostmetricsreceiver/systemd metrics.
+type MetricsSettings struct {
+	SystemdServiceMainPid  MetricSettings `mapstructure:"systemd.service.main_pid"`
+	SystemdServiceRestarts MetricSettings `mapstructure:"systemd.service.restarts"`
+	SystemdUnitActiveState MetricSettings `mapstructure:"systemd.unit.active_state"`
+	SystemdUnitSubState    MetricSettings `mapstructure:"systemd.unit.sub_state"`
+}
+
+func DefaultMetricsSettings() MetricsSettings {
+	return MetricsSettings{
+		SystemdServiceMainPid: MetricSettings{
+			Enabled: true,
+		},
+		SystemdServiceRestarts: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitActiveState: MetricSettings{
+			Enabled: true,
+		},
+		SystemdUnitSubState: MetricSettings{
+			Enabled: true,
+		},
+	}
+}
+
+// AttributeActiveState specifies the a value active_state attribute.
+type AttributeActiveState int
+
+const (
+	_ AttributeActiveState = iota
+	AttributeActiveStateActive
+	AttributeActiveStateReloading
+	AttributeActiveStateInactive
+	AttributeActiveStateFailed
+	AttributeActiveStateActivating
+	AttributeActiveStateDeactivating
+)
+
+// String returns the string representation of the AttributeActiveState.
+func (av AttributeActiveState) String() string {
+	switch av {
+	case AttributeActiveStateActive:
+		return "active"
+	case AttributeActiveStateReloading:
+		return "reloading"
+	case AttributeActiveStateInactive:
+		return "inactive"
+	case AttributeActiveStateFailed:
+		return "failed"
+	case AttributeActiveStateActivating:
+		return "activating"
+	case AttributeActiveStateDeactivating:
+		return "deactivating"
+	}
+	return ""
+}
+
+// MapAttributeActiveState is a helper map of string to AttributeActiveState attribute value.
+var MapAttributeActiveState = map[string]AttributeActiveState{
+	"active":       AttributeActiveStateActive,
+	"reloading":    AttributeActiveStateReloading,
+	"inactive":     AttributeActiveStateInactive,
+	"failed":       AttributeActiveStateFailed,
+	"activating":   AttributeActiveStateActivating,
+	"deactivating": AttributeActiveStateDeactivating,
+}
+
+type metricSystemdServiceMainPid struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.service.main_pid metric with initial data.
+func (m *metricSystemdServiceMainPid) init() {
+	m.data.SetName("systemd.service.main_pid")
+	m.data.SetDescription("PID of the main process of the service, 0 when the service isn't running.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+}
+
+func (m *metricSystemdServiceMainPid) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdServiceMainPid) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdServiceMainPid) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdServiceMainPid(settings MetricSettings) metricSystemdServiceMainPid {
+	m := metricSystemdServiceMainPid{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdServiceRestarts struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.service.restarts metric with initial data.
+func (m *metricSystemdServiceRestarts) init() {
+	m.data.SetName("systemd.service.restarts")
+	m.data.SetDescription("Number of times the service has been automatically restarted.")
+	m.data.SetUnit("{restarts}")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(true)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+}
+
+func (m *metricSystemdServiceRestarts) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdServiceRestarts) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdServiceRestarts) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdServiceRestarts(settings MetricSettings) metricSystemdServiceRestarts {
+	m := metricSystemdServiceRestarts{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitActiveState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.active_state metric with initial data.
+func (m *metricSystemdUnitActiveState) init() {
+	m.data.SetName("systemd.unit.active_state")
+	m.data.SetDescription("Active state of the unit. The data point of the current state has a value of 1, the others a value of 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitActiveState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, activeStateAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", activeStateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitActiveState) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitActiveState) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitActiveState(settings MetricSettings) metricSystemdUnitActiveState {
+	m := metricSystemdUnitActiveState{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricSystemdUnitSubState struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills systemd.unit.sub_state metric with initial data.
+func (m *metricSystemdUnitSubState) init() {
+	m.data.SetName("systemd.unit.sub_state")
+	m.data.SetDescription("Sub-state of the unit. A single data point with a value of 1 is reported for the current sub-state.")
+	m.data.SetUnit("1")
+	m.data.SetEmptySum()
+	m.data.Sum().SetIsMonotonic(false)
+	m.data.Sum().SetAggregationTemporality(pmetric.AggregationTemporalityCumulative)
+	m.data.Sum().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricSystemdUnitSubState) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, subStateAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Sum().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("state", subStateAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricSystemdUnitSubState) updateCapacity() {
+	if m.data.Sum().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Sum().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricSystemdUnitSubState) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Sum().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricSystemdUnitSubState(settings MetricSettings) metricSystemdUnitSubState {
+	m := metricSystemdUnitSubState{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+// MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
+// required to produce metric representation defined in metadata and user settings.
+type MetricsBuilder struct {
+	startTime                    pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity              int                 // maximum observed number of metrics per resource.
+	resourceCapacity             int                 // maximum observed number of resource attributes.
+	metricsBuffer                pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                    component.BuildInfo // contains version information
+	metricSystemdServiceMainPid  metricSystemdServiceMainPid
+	metricSystemdServiceRestarts metricSystemdServiceRestarts
+	metricSystemdUnitActiveState metricSystemdUnitActiveState
+	metricSystemdUnitSubState    metricSystemdUnitSubState
+}
+
+// metricBuilderOption applies changes to default metrics builder.
+type metricBuilderOption func(*MetricsBuilder)
+
+// WithStartTime sets startTime on the metrics builder.
+func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
+	return func(mb *MetricsBuilder) {
+		mb.startTime = startTime
+	}
+}
+
+func NewMetricsBuilder(ms MetricsSettings, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
+	mb := &MetricsBuilder{
+		startTime:                    pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                pmetric.NewMetrics(),
+		buildInfo:                    settings.BuildInfo,
+		metricSystemdServiceMainPid:  newMetricSystemdServiceMainPid(ms.SystemdServiceMainPid),
+		metricSystemdServiceRestarts: newMetricSystemdServiceRestarts(ms.SystemdServiceRestarts),
+		metricSystemdUnitActiveState: newMetricSystemdUnitActiveState(ms.SystemdUnitActiveState),
+		metricSystemdUnitSubState:    newMetricSystemdUnitSubState(ms.SystemdUnitSubState),
+	}
+	for _, op := range options {
+		op(mb)
+	}
+	return mb
+}
+
+// updateCapacity updates max length of metrics and resource attributes that will be used for the slice capacity.
+func (mb *MetricsBuilder) updateCapacity(rm pmetric.ResourceMetrics) {
+	if mb.metricsCapacity < rm.ScopeMetrics().At(0).Metrics().Len() {
+		mb.metricsCapacity = rm.ScopeMetrics().At(0).Metrics().Len()
+	}
+	if mb.resourceCapacity < rm.Resource().Attributes().Len() {
+		mb.resourceCapacity = rm.Resource().Attributes().Len()
+	}
+}
+
+// ResourceMetricsOption applies changes to provided resource metrics.
+type ResourceMetricsOption func(pmetric.ResourceMetrics)
+
+// WithSystemdUnitName sets provided value as "systemd.unit.name" attribute for current resource.
+func WithSystemdUnitName(val string) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		rm.Resource().Attributes().PutStr("systemd.unit.name", val)
+	}
+}
+
+// WithStartTimeOverride overrides start time for all the resource metrics data points.
+// This option should be only used if different start time has to be set on metrics coming from different resources.
+func WithStartTimeOverride(start pcommon.Timestamp) ResourceMetricsOption {
+	return func(rm pmetric.ResourceMetrics) {
+		var dps pmetric.NumberDataPointSlice
+		metrics := rm.ScopeMetrics().At(0).Metrics()
+		for i := 0; i < metrics.Len(); i++ {
+			switch metrics.At(i).Type() {
+			case pmetric.MetricTypeGauge:
+				dps = metrics.At(i).Gauge().DataPoints()
+			case pmetric.MetricTypeSum:
+				dps = metrics.At(i).Sum().DataPoints()
+			}
+			for j := 0; j < dps.Len(); j++ {
+				dps.At(j).SetStartTimestamp(start)
+			}
+		}
+	}
+}
+
+// EmitForResource saves all the generated metrics under a new resource and updates the internal state to be ready for
+// recording another set of data points as part of another resource. This function can be helpful when one scraper
+// needs to emit metrics from several resources. Otherwise calling this function is not required,
+// just `Emit` function can be called instead.
+// Resource attributes should be provided as ResourceMetricsOption arguments.
+func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
+	rm := pmetric.NewResourceMetrics()
+	rm.SetSchemaUrl(conventions.SchemaURL)
+	rm.Resource().Attributes().EnsureCapacity(mb.resourceCapacity)
+	ils := rm.ScopeMetrics().AppendEmpty()
+	ils.Scope().SetName("otelcol/hostmetricsreceiver/systemd")
+	ils.Scope().SetVersion(mb.buildInfo.Version)
+	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricSystemdServiceMainPid.emit(ils.Metrics())
+	mb.metricSystemdServiceRestarts.emit(ils.Metrics())
+	mb.metricSystemdUnitActiveState.emit(ils.Metrics())
+	mb.metricSystemdUnitSubState.emit(ils.Metrics())
+	for _, op := range rmo {
+		op(rm)
+	}
+	if ils.Metrics().Len() > 0 {
+		mb.updateCapacity(rm)
+		rm.MoveTo(mb.metricsBuffer.ResourceMetrics().AppendEmpty())
+	}
+}
+
+// Emit returns all the metrics accumulated by the metrics builder and updates the internal state to be ready for
+// recording another set of metrics. This function will be responsible for applying all the transformations required to
+// produce metric representation defined in metadata and user settings, e.g. delta or cumulative.
+func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
+	mb.EmitForResource(rmo...)
+	metrics := pmetric.NewMetrics()
+	mb.metricsBuffer.MoveTo(metrics)
+	return metrics
+}
+
+// RecordSystemdServiceMainPidDataPoint adds a data point to systemd.service.main_pid metric.
+func (mb *MetricsBuilder) RecordSystemdServiceMainPidDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdServiceMainPid.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdServiceRestartsDataPoint adds a data point to systemd.service.restarts metric.
+func (mb *MetricsBuilder) RecordSystemdServiceRestartsDataPoint(ts pcommon.Timestamp, val int64) {
+	mb.metricSystemdServiceRestarts.recordDataPoint(mb.startTime, ts, val)
+}
+
+// RecordSystemdUnitActiveStateDataPoint adds a data point to systemd.unit.active_state metric.
+func (mb *MetricsBuilder) RecordSystemdUnitActiveStateDataPoint(ts pcommon.Timestamp, val int64, activeStateAttributeValue AttributeActiveState) {
+	mb.metricSystemdUnitActiveState.recordDataPoint(mb.startTime, ts, val, activeStateAttributeValue.String())
+}
+
+// RecordSystemdUnitSubStateDataPoint adds a data point to systemd.unit.sub_state metric.
+func (mb *MetricsBuilder) RecordSystemdUnitSubStateDataPoint(ts pcommon.Timestamp, val int64, subStateAttributeValue string) {
+	mb.metricSystemdUnitSubState.recordDataPoint(mb.startTime, ts, val, subStateAttributeValue)
+}
+
+// Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
+// and metrics builder should update its startTime and reset it's internal state accordingly.
+func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
+	mb.startTime = pcommon.NewTimestampFromTime(time.Now())
+	for _, op := range options {
+		op(mb)
+	}
+}
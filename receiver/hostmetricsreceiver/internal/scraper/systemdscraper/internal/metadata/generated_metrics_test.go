@@ -0,0 +1,173 @@
+// Code generated by mdatagen. DO NOT EDIT.
+
+package metadata
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDefaultMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	mb := NewMetricsBuilder(DefaultMetricsSettings(), receivertest.NewNopCreateSettings(), WithStartTime(start))
+	enabledMetrics := make(map[string]bool)
+
+	enabledMetrics["systemd.service.main_pid"] = true
+	mb.RecordSystemdServiceMainPidDataPoint(ts, 1)
+
+	enabledMetrics["systemd.service.restarts"] = true
+	mb.RecordSystemdServiceRestartsDataPoint(ts, 1)
+
+	enabledMetrics["systemd.unit.active_state"] = true
+	mb.RecordSystemdUnitActiveStateDataPoint(ts, 1, AttributeActiveState(1))
+
+	enabledMetrics["systemd.unit.sub_state"] = true
+	mb.RecordSystemdUnitSubStateDataPoint(ts, 1, "attr-val")
+
+	metrics := mb.Emit()
+
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	sm := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	assert.Equal(t, 1, sm.Len())
+	ms := sm.At(0).Metrics()
+	assert.Equal(t, len(enabledMetrics), ms.Len())
+	seenMetrics := make(map[string]bool)
+	for i := 0; i < ms.Len(); i++ {
+		assert.True(t, enabledMetrics[ms.At(i).Name()])
+		seenMetrics[ms.At(i).Name()] = true
+	}
+	assert.Equal(t, len(enabledMetrics), len(seenMetrics))
+}
+
+func TestAllMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	metricsSettings := MetricsSettings{
+		SystemdServiceMainPid:  MetricSettings{Enabled: true},
+		SystemdServiceRestarts: MetricSettings{Enabled: true},
+		SystemdUnitActiveState: MetricSettings{Enabled: true},
+		SystemdUnitSubState:    MetricSettings{Enabled: true},
+	}
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopCreateSettings()
+	settings.Logger = zap.New(observedZapCore)
+	mb := NewMetricsBuilder(metricsSettings, settings, WithStartTime(start))
+
+	assert.Equal(t, 0, observedLogs.Len())
+
+	mb.RecordSystemdServiceMainPidDataPoint(ts, 1)
+	mb.RecordSystemdServiceRestartsDataPoint(ts, 1)
+	mb.RecordSystemdUnitActiveStateDataPoint(ts, 1, AttributeActiveState(1))
+	mb.RecordSystemdUnitSubStateDataPoint(ts, 1, "attr-val")
+
+	metrics := mb.Emit(WithSystemdUnitName("attr-val"))
+
+	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
+	rm := metrics.ResourceMetrics().At(0)
+	attrCount := 0
+	attrCount++
+	attrVal, ok := rm.Resource().Attributes().Get("systemd.unit.name")
+	assert.True(t, ok)
+	assert.EqualValues(t, "attr-val", attrVal.Str())
+	assert.Equal(t, attrCount, rm.Resource().Attributes().Len())
+
+	assert.Equal(t, 1, rm.ScopeMetrics().Len())
+	ms := rm.ScopeMetrics().At(0).Metrics()
+	allMetricsCount := reflect.TypeOf(MetricsSettings{}).NumField()
+	assert.Equal(t, allMetricsCount, ms.Len())
+	validatedMetrics := make(map[string]struct{})
+	for i := 0; i < ms.Len(); i++ {
+		switch ms.At(i).Name() {
+		case "systemd.service.main_pid":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "PID of the main process of the service, 0 when the service isn't running.", ms.At(i).Description())
+			assert.Equal(t, "1", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			validatedMetrics["systemd.service.main_pid"] = struct{}{}
+		case "systemd.service.restarts":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Number of times the service has been automatically restarted.", ms.At(i).Description())
+			assert.Equal(t, "{restarts}", ms.At(i).Unit())
+			assert.Equal(t, true, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			validatedMetrics["systemd.service.restarts"] = struct{}{}
+		case "systemd.unit.active_state":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Active state of the unit. The data point of the current state has a value of 1, the others a value of 0.", ms.At(i).Description())
+			assert.Equal(t, "1", ms.At(i).Unit())
+			assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("state")
+			assert.True(t, ok)
+			assert.Equal(t, "active", attrVal.Str())
+			validatedMetrics["systemd.unit.active_state"] = struct{}{}
+		case "systemd.unit.sub_state":
+			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
+			assert.Equal(t, "Sub-state of the unit. A single data point with a value of 1 is reported for the current sub-state.", ms.At(i).Description())
+			assert.Equal(t, "1", ms.At(i).Unit())
+			assert.Equal(t, false, ms.At(i).Sum().IsMonotonic())
+			assert.Equal(t, pmetric.AggregationTemporalityCumulative, ms.At(i).Sum().AggregationTemporality())
+			dp := ms.At(i).Sum().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("state")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["systemd.unit.sub_state"] = struct{}{}
+		}
+	}
+	assert.Equal(t, allMetricsCount, len(validatedMetrics))
+}
+
+func TestNoMetrics(t *testing.T) {
+	start := pcommon.Timestamp(1_000_000_000)
+	ts := pcommon.Timestamp(1_000_001_000)
+	metricsSettings := MetricsSettings{
+		SystemdServiceMainPid:  MetricSettings{Enabled: false},
+		SystemdServiceRestarts: MetricSettings{Enabled: false},
+		SystemdUnitActiveState: MetricSettings{Enabled: false},
+		SystemdUnitSubState:    MetricSettings{Enabled: false},
+	}
+	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
+	settings := receivertest.NewNopCreateSettings()
+	settings.Logger = zap.New(observedZapCore)
+	mb := NewMetricsBuilder(metricsSettings, settings, WithStartTime(start))
+
+	assert.Equal(t, 0, observedLogs.Len())
+	mb.RecordSystemdServiceMainPidDataPoint(ts, 1)
+	mb.RecordSystemdServiceRestartsDataPoint(ts, 1)
+	mb.RecordSystemdUnitActiveStateDataPoint(ts, 1, AttributeActiveState(1))
+	mb.RecordSystemdUnitSubStateDataPoint(ts, 1, "attr-val")
+
+	metrics := mb.Emit()
+
+	assert.Equal(t, 0, metrics.ResourceMetrics().Len())
+}
@@ -0,0 +1,163 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper"
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper/internal/metadata"
+)
+
+const (
+	serviceMetricsLen = 2
+
+	loadStateNotFound = "not-found"
+)
+
+var activeStates = []metadata.AttributeActiveState{
+	metadata.AttributeActiveStateActive,
+	metadata.AttributeActiveStateReloading,
+	metadata.AttributeActiveStateInactive,
+	metadata.AttributeActiveStateFailed,
+	metadata.AttributeActiveStateActivating,
+	metadata.AttributeActiveStateDeactivating,
+}
+
+// scraper for Systemd Metrics
+type scraper struct {
+	settings  receiver.CreateSettings
+	config    *Config
+	mb        *metadata.MetricsBuilder
+	includeFS filterset.FilterSet
+	excludeFS filterset.FilterSet
+	client    systemdClient
+
+	// for mocking the D-Bus and systemctl clients
+	newDBusClient      func() (systemdClient, error)
+	newSystemctlClient func() (systemdClient, error)
+}
+
+// newSystemdScraper creates a Systemd Scraper
+func newSystemdScraper(settings receiver.CreateSettings, cfg *Config) (*scraper, error) {
+	scraper := &scraper{
+		settings:           settings,
+		config:             cfg,
+		newDBusClient:      newDBusClient,
+		newSystemctlClient: newSystemctlClient,
+	}
+
+	var err error
+
+	if len(cfg.Include.Units) > 0 {
+		scraper.includeFS, err = filterset.CreateFilterSet(cfg.Include.Units, &cfg.Include.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating unit include filters: %w", err)
+		}
+	}
+
+	if len(cfg.Exclude.Units) > 0 {
+		scraper.excludeFS, err = filterset.CreateFilterSet(cfg.Exclude.Units, &cfg.Exclude.Config)
+		if err != nil {
+			return nil, fmt.Errorf("error creating unit exclude filters: %w", err)
+		}
+	}
+
+	return scraper, nil
+}
+
+func (s *scraper) start(context.Context, component.Host) error {
+	client, err := s.newDBusClient()
+	if err != nil {
+		s.settings.Logger.Warn("Failed to connect to systemd over D-Bus, falling back to systemctl", zap.Error(err))
+		client, err = s.newSystemctlClient()
+		if err != nil {
+			return fmt.Errorf("failed to connect to systemd over D-Bus, and systemctl is unavailable: %w", err)
+		}
+	}
+	s.client = client
+
+	s.mb = metadata.NewMetricsBuilder(s.config.Metrics, s.settings)
+	return nil
+}
+
+func (s *scraper) shutdown(context.Context) error {
+	if s.client == nil {
+		return nil
+	}
+	return s.client.close()
+}
+
+func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	units, err := s.client.listUnits(ctx)
+	if err != nil {
+		return pmetric.NewMetrics(), fmt.Errorf("error listing the systemd units: %w", err)
+	}
+
+	var errs scrapererror.ScrapeErrors
+	for _, unit := range units {
+		if unit.loadState == loadStateNotFound {
+			continue
+		}
+
+		// filter units by name
+		if (s.includeFS != nil && !s.includeFS.Matches(unit.name)) ||
+			(s.excludeFS != nil && s.excludeFS.Matches(unit.name)) {
+			continue
+		}
+
+		now := pcommon.NewTimestampFromTime(time.Now())
+
+		for _, state := range activeStates {
+			var value int64
+			if state.String() == unit.activeState {
+				value = 1
+			}
+			s.mb.RecordSystemdUnitActiveStateDataPoint(now, value, state)
+		}
+		s.mb.RecordSystemdUnitSubStateDataPoint(now, 1, unit.subState)
+
+		if strings.HasSuffix(unit.name, ".service") {
+			if err = s.scrapeAndAppendServiceMetrics(ctx, now, unit.name); err != nil {
+				errs.AddPartial(serviceMetricsLen, fmt.Errorf("error reading properties of service %q: %w", unit.name, err))
+			}
+		}
+
+		s.mb.EmitForResource(metadata.WithSystemdUnitName(unit.name))
+	}
+
+	return s.mb.Emit(), errs.Combine()
+}
+
+func (s *scraper) scrapeAndAppendServiceMetrics(ctx context.Context, now pcommon.Timestamp, name string) error {
+	props, err := s.client.serviceProperties(ctx, name)
+	if err != nil {
+		return err
+	}
+
+	s.mb.RecordSystemdServiceRestartsDataPoint(now, props.restarts)
+	s.mb.RecordSystemdServiceMainPidDataPoint(now, props.mainPID)
+	return nil
+}
@@ -0,0 +1,190 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package systemdscraper
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/filter/filterset"
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/hostmetricsreceiver/internal/scraper/systemdscraper/internal/metadata"
+)
+
+type fakeClient struct {
+	units      []unitStatus
+	services   map[string]serviceProperties
+	listErr    error
+	closed     bool
+	propsCalls []string
+}
+
+func (c *fakeClient) listUnits(context.Context) ([]unitStatus, error) {
+	return c.units, c.listErr
+}
+
+func (c *fakeClient) serviceProperties(_ context.Context, name string) (serviceProperties, error) {
+	c.propsCalls = append(c.propsCalls, name)
+	props, ok := c.services[name]
+	if !ok {
+		return serviceProperties{}, errors.New("unit not found")
+	}
+	return props, nil
+}
+
+func (c *fakeClient) close() error {
+	c.closed = true
+	return nil
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{
+		units: []unitStatus{
+			{name: "nginx.service", loadState: "loaded", activeState: "active", subState: "running"},
+			{name: "backup.service", loadState: "loaded", activeState: "failed", subState: "failed"},
+			{name: "backup.timer", loadState: "loaded", activeState: "active", subState: "waiting"},
+			{name: "missing.service", loadState: "not-found", activeState: "inactive", subState: "dead"},
+		},
+		services: map[string]serviceProperties{
+			"nginx.service":  {restarts: 3, mainPID: 1234},
+			"backup.service": {restarts: 0, mainPID: 0},
+		},
+	}
+}
+
+func TestScrape(t *testing.T) {
+	client := newFakeClient()
+	scraper := newTestScraper(t, &Config{Metrics: metadata.DefaultMetricsSettings()}, client)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	units := unitsByName(md)
+	require.Len(t, units, 3, "the units not found must be skipped")
+
+	nginx := units["nginx.service"]
+	activeStates := findMetric(t, nginx, "systemd.unit.active_state").Sum().DataPoints()
+	require.Equal(t, 6, activeStates.Len())
+	for i := 0; i < activeStates.Len(); i++ {
+		state, _ := activeStates.At(i).Attributes().Get("state")
+		expected := int64(0)
+		if state.Str() == "active" {
+			expected = 1
+		}
+		assert.Equal(t, expected, activeStates.At(i).IntValue(), state.Str())
+	}
+	subState := findMetric(t, nginx, "systemd.unit.sub_state").Sum().DataPoints().At(0)
+	state, _ := subState.Attributes().Get("state")
+	assert.Equal(t, "running", state.Str())
+	assert.Equal(t, int64(3), findMetric(t, nginx, "systemd.service.restarts").Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, int64(1234), findMetric(t, nginx, "systemd.service.main_pid").Gauge().DataPoints().At(0).IntValue())
+
+	timer := units["backup.timer"]
+	assert.Equal(t, 2, timer.Len(), "only the services have restarts and a main PID")
+	assert.Equal(t, []string{"nginx.service", "backup.service"}, client.propsCalls)
+
+	require.NoError(t, scraper.shutdown(context.Background()))
+	assert.True(t, client.closed)
+}
+
+func TestScrapeFilters(t *testing.T) {
+	cfg := &Config{
+		Metrics: metadata.DefaultMetricsSettings(),
+		Include: MatchConfig{Config: filterset.Config{MatchType: filterset.Regexp}, Units: []string{`\.service$`}},
+		Exclude: MatchConfig{Config: filterset.Config{MatchType: filterset.Strict}, Units: []string{"backup.service"}},
+	}
+	client := newFakeClient()
+	scraper := newTestScraper(t, cfg, client)
+
+	md, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	units := unitsByName(md)
+	assert.Len(t, units, 1)
+	assert.Contains(t, units, "nginx.service")
+	assert.Equal(t, []string{"nginx.service"}, client.propsCalls, "the properties of the filtered out services must not be read")
+}
+
+func TestScrapeErrors(t *testing.T) {
+	client := newFakeClient()
+	delete(client.services, "backup.service")
+	scraper := newTestScraper(t, &Config{Metrics: metadata.DefaultMetricsSettings()}, client)
+
+	md, err := scraper.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.EqualError(t, err, `error reading properties of service "backup.service": unit not found`)
+	assert.Len(t, unitsByName(md), 3)
+
+	client.listErr = errors.New("connection closed")
+	_, err = scraper.scrape(context.Background())
+	assert.EqualError(t, err, "error listing the systemd units: connection closed")
+}
+
+func TestStartFallsBackToSystemctl(t *testing.T) {
+	scraper, err := newSystemdScraper(receivertest.NewNopCreateSettings(), &Config{Metrics: metadata.DefaultMetricsSettings()})
+	require.NoError(t, err)
+
+	systemctl := &systemctlClient{}
+	scraper.newDBusClient = func() (systemdClient, error) { return nil, errors.New("no such file or directory") }
+	scraper.newSystemctlClient = func() (systemdClient, error) { return systemctl, nil }
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	assert.Same(t, systemctl, scraper.client)
+
+	scraper.newSystemctlClient = func() (systemdClient, error) { return nil, errors.New("executable file not found in $PATH") }
+	assert.EqualError(t, scraper.start(context.Background(), componenttest.NewNopHost()),
+		"failed to connect to systemd over D-Bus, and systemctl is unavailable: executable file not found in $PATH")
+}
+
+func TestNewSystemdScraperInvalidFilter(t *testing.T) {
+	cfg := &Config{Exclude: MatchConfig{Config: filterset.Config{MatchType: filterset.Regexp}, Units: []string{"("}}}
+	_, err := newSystemdScraper(receivertest.NewNopCreateSettings(), cfg)
+	assert.ErrorContains(t, err, "error creating unit exclude filters")
+}
+
+func newTestScraper(t *testing.T, cfg *Config, client systemdClient) *scraper {
+	scraper, err := newSystemdScraper(receivertest.NewNopCreateSettings(), cfg)
+	require.NoError(t, err)
+	scraper.newDBusClient = func() (systemdClient, error) { return client, nil }
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+	return scraper
+}
+
+func unitsByName(md pmetric.Metrics) map[string]pmetric.MetricSlice {
+	units := map[string]pmetric.MetricSlice{}
+	for i := 0; i < md.ResourceMetrics().Len(); i++ {
+		rm := md.ResourceMetrics().At(i)
+		name, _ := rm.Resource().Attributes().Get("systemd.unit.name")
+		units[name.Str()] = rm.ScopeMetrics().At(0).Metrics()
+	}
+	return units
+}
+
+func findMetric(t *testing.T, metrics pmetric.MetricSlice, name string) pmetric.Metric {
+	for i := 0; i < metrics.Len(); i++ {
+		if metrics.At(i).Name() == name {
+			return metrics.At(i)
+		}
+	}
+	require.Failf(t, "metric not found", "%s", name)
+	return pmetric.Metric{}
+}
@@ -18,6 +18,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"regexp"
 
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
@@ -28,10 +29,15 @@ import (
 
 // Predefined error responses for configuration validation failures
 var (
-	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errInvalidEndpoint       = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>:<port>`)
+	errMissingEndpoint       = errors.New(`"endpoint" must be specified for each target`)
+	errInvalidMaxConcurrency = errors.New(`"max_concurrency" must be positive`)
 )
 
-const defaultEndpoint = "http://localhost:80"
+const (
+	defaultEndpoint       = "http://localhost:80"
+	defaultMaxConcurrency = 10
+)
 
 // Config defines the configuration for the various elements of the receiver agent.
 type Config struct {
@@ -39,6 +45,23 @@ type Config struct {
 	confighttp.HTTPClientSettings           `mapstructure:",squash"`
 	Metrics                                 metadata.MetricsSettings `mapstructure:"metrics"`
 	Method                                  string                   `mapstructure:"method"`
+	// Targets to check. If empty, the endpoint and method above are checked.
+	Targets []TargetConfig `mapstructure:"targets"`
+	// MaxConcurrency bounds the number of checks performed at the same time.
+	MaxConcurrency int `mapstructure:"max_concurrency"`
+}
+
+// TargetConfig defines a single endpoint to check and the assertions made on its response.
+type TargetConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+	// Method defaults to the method of the receiver.
+	Method  string            `mapstructure:"method"`
+	Headers map[string]string `mapstructure:"headers"`
+	Body    string            `mapstructure:"body"`
+	// ExpectedStatusCodes lists the status codes considered successful. No assertion is made if empty.
+	ExpectedStatusCodes []int `mapstructure:"expected_status_codes"`
+	// ExpectedBodyRegex is a regular expression the response body must match. No assertion is made if empty.
+	ExpectedBodyRegex string `mapstructure:"expected_body_regex"`
 }
 
 // Validate validates the configuration by checking for missing or invalid fields
@@ -51,5 +74,37 @@ func (cfg *Config) Validate() error {
 		err = multierr.Append(err, wrappedErr)
 	}
 
+	if cfg.MaxConcurrency <= 0 {
+		err = multierr.Append(err, errInvalidMaxConcurrency)
+	}
+
+	for i, target := range cfg.Targets {
+		err = multierr.Append(err, target.validate(i))
+	}
+
+	return err
+}
+
+func (t *TargetConfig) validate(index int) error {
+	var err error
+
+	if t.Endpoint == "" {
+		err = multierr.Append(err, fmt.Errorf("targets[%d]: %w", index, errMissingEndpoint))
+	} else if _, parseErr := url.Parse(t.Endpoint); parseErr != nil {
+		err = multierr.Append(err, fmt.Errorf("targets[%d]: %s: %w", index, errInvalidEndpoint.Error(), parseErr))
+	}
+
+	for _, code := range t.ExpectedStatusCodes {
+		if code < 100 || code > 599 {
+			err = multierr.Append(err, fmt.Errorf("targets[%d]: invalid expected status code %d", index, code))
+		}
+	}
+
+	if t.ExpectedBodyRegex != "" {
+		if _, reErr := regexp.Compile(t.ExpectedBodyRegex); reErr != nil {
+			err = multierr.Append(err, fmt.Errorf("targets[%d]: invalid expected_body_regex: %w", index, reErr))
+		}
+	}
+
 	return err
 }
@@ -36,17 +36,38 @@ func TestValidate(t *testing.T) {
 				HTTPClientSettings: confighttp.HTTPClientSettings{
 					Endpoint: "invalid://endpoint:  12efg",
 				},
+				MaxConcurrency: defaultMaxConcurrency,
 			},
 			expectedErr: multierr.Combine(
 				fmt.Errorf("%s: %w", errInvalidEndpoint, errors.New(`parse "invalid://endpoint:  12efg": invalid port ":  12efg" after host`)),
 			),
 		},
+		{
+			desc: "invalid targets",
+			cfg: &Config{
+				HTTPClientSettings: confighttp.HTTPClientSettings{
+					Endpoint: defaultEndpoint,
+				},
+				MaxConcurrency: 0,
+				Targets: []TargetConfig{
+					{Endpoint: defaultEndpoint, ExpectedStatusCodes: []int{200, 42}},
+					{ExpectedBodyRegex: "("},
+				},
+			},
+			expectedErr: multierr.Combine(
+				errInvalidMaxConcurrency,
+				errors.New("targets[0]: invalid expected status code 42"),
+				fmt.Errorf("targets[1]: %w", errMissingEndpoint),
+				errors.New("targets[1]: invalid expected_body_regex: error parsing regexp: missing closing ): `(`"),
+			),
+		},
 		{
 			desc: "valid config",
 			cfg: &Config{
 				HTTPClientSettings: confighttp.HTTPClientSettings{
 					Endpoint: defaultEndpoint,
 				},
+				MaxConcurrency: defaultMaxConcurrency,
 			},
 			expectedErr: nil,
 		},
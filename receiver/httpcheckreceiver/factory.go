@@ -52,8 +52,9 @@ func createDefaultConfig() component.Config {
 			Endpoint: defaultEndpoint,
 			Timeout:  10 * time.Second,
 		},
-		Metrics: metadata.DefaultMetricsSettings(),
-		Method:  "GET",
+		Metrics:        metadata.DefaultMetricsSettings(),
+		Method:         "GET",
+		MaxConcurrency: defaultMaxConcurrency,
 	}
 }
 
@@ -54,8 +54,9 @@ func TestNewFactory(t *testing.T) {
 						Endpoint: defaultEndpoint,
 						Timeout:  10 * time.Second,
 					},
-					Metrics: metadata.DefaultMetricsSettings(),
-					Method:  "GET",
+					Metrics:        metadata.DefaultMetricsSettings(),
+					Method:         "GET",
+					MaxConcurrency: defaultMaxConcurrency,
 				}
 
 				require.Equal(t, expectedCfg, factory.CreateDefaultConfig())
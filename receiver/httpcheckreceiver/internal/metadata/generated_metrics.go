@@ -38,23 +38,151 @@ func (ms *MetricSettings) Unmarshal(parser *confmap.Conf) error {
 
 // MetricsSettings provides settings for httpcheckreceiver metrics.
 type MetricsSettings struct {
-	HttpcheckDuration MetricSettings `mapstructure:"httpcheck.duration"`
-	HttpcheckError    MetricSettings `mapstructure:"httpcheck.error"`
-	HttpcheckStatus   MetricSettings `mapstructure:"httpcheck.status"`
+	HttpcheckAssertion     MetricSettings `mapstructure:"httpcheck.assertion"`
+	HttpcheckDuration      MetricSettings `mapstructure:"httpcheck.duration"`
+	HttpcheckError         MetricSettings `mapstructure:"httpcheck.error"`
+	HttpcheckPhaseDuration MetricSettings `mapstructure:"httpcheck.phase.duration"`
+	HttpcheckResponseSize  MetricSettings `mapstructure:"httpcheck.response.size"`
+	HttpcheckStatus        MetricSettings `mapstructure:"httpcheck.status"`
+	HttpcheckTLSCertExpiry MetricSettings `mapstructure:"httpcheck.tls.cert.expiry"`
 }
 
 func DefaultMetricsSettings() MetricsSettings {
 	return MetricsSettings{
+		HttpcheckAssertion: MetricSettings{
+			Enabled: true,
+		},
 		HttpcheckDuration: MetricSettings{
 			Enabled: true,
 		},
 		HttpcheckError: MetricSettings{
 			Enabled: true,
 		},
+		HttpcheckPhaseDuration: MetricSettings{
+			Enabled: false,
+		},
+		HttpcheckResponseSize: MetricSettings{
+			Enabled: false,
+		},
 		HttpcheckStatus: MetricSettings{
 			Enabled: true,
 		},
+		HttpcheckTLSCertExpiry: MetricSettings{
+			Enabled: false,
+		},
+	}
+}
+
+// AttributeAssertionType specifies the a value assertion.type attribute.
+type AttributeAssertionType int
+
+const (
+	_ AttributeAssertionType = iota
+	AttributeAssertionTypeStatusCode
+	AttributeAssertionTypeBody
+)
+
+// String returns the string representation of the AttributeAssertionType.
+func (av AttributeAssertionType) String() string {
+	switch av {
+	case AttributeAssertionTypeStatusCode:
+		return "status_code"
+	case AttributeAssertionTypeBody:
+		return "body"
+	}
+	return ""
+}
+
+// MapAttributeAssertionType is a helper map of string to AttributeAssertionType attribute value.
+var MapAttributeAssertionType = map[string]AttributeAssertionType{
+	"status_code": AttributeAssertionTypeStatusCode,
+	"body":        AttributeAssertionTypeBody,
+}
+
+// AttributeHTTPPhase specifies the a value http.phase attribute.
+type AttributeHTTPPhase int
+
+const (
+	_ AttributeHTTPPhase = iota
+	AttributeHTTPPhaseDns
+	AttributeHTTPPhaseConnect
+	AttributeHTTPPhaseTls
+	AttributeHTTPPhaseTtfb
+)
+
+// String returns the string representation of the AttributeHTTPPhase.
+func (av AttributeHTTPPhase) String() string {
+	switch av {
+	case AttributeHTTPPhaseDns:
+		return "dns"
+	case AttributeHTTPPhaseConnect:
+		return "connect"
+	case AttributeHTTPPhaseTls:
+		return "tls"
+	case AttributeHTTPPhaseTtfb:
+		return "ttfb"
+	}
+	return ""
+}
+
+// MapAttributeHTTPPhase is a helper map of string to AttributeHTTPPhase attribute value.
+var MapAttributeHTTPPhase = map[string]AttributeHTTPPhase{
+	"dns":     AttributeHTTPPhaseDns,
+	"connect": AttributeHTTPPhaseConnect,
+	"tls":     AttributeHTTPPhaseTls,
+	"ttfb":    AttributeHTTPPhaseTtfb,
+}
+
+type metricHttpcheckAssertion struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.assertion metric with initial data.
+func (m *metricHttpcheckAssertion) init() {
+	m.data.SetName("httpcheck.assertion")
+	m.data.SetDescription("1 if the response satisfied the assertion configured for the target, otherwise 0.")
+	m.data.SetUnit("1")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckAssertion) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, assertionTypeAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("assertion.type", assertionTypeAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckAssertion) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckAssertion) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckAssertion(settings MetricSettings) metricHttpcheckAssertion {
+	m := metricHttpcheckAssertion{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
 	}
+	return m
 }
 
 type metricHttpcheckDuration struct {
@@ -162,6 +290,109 @@ func newMetricHttpcheckError(settings MetricSettings) metricHttpcheckError {
 	return m
 }
 
+type metricHttpcheckPhaseDuration struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.phase.duration metric with initial data.
+func (m *metricHttpcheckPhaseDuration) init() {
+	m.data.SetName("httpcheck.phase.duration")
+	m.data.SetDescription("Measures the duration of a phase of the HTTP check. Phases not performed by the check, such as the DNS lookup for an IP address or the connection setup for a reused connection, are not recorded.")
+	m.data.SetUnit("ms")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckPhaseDuration) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+	dp.Attributes().PutStr("http.phase", httpPhaseAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckPhaseDuration) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckPhaseDuration) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckPhaseDuration(settings MetricSettings) metricHttpcheckPhaseDuration {
+	m := metricHttpcheckPhaseDuration{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
+type metricHttpcheckResponseSize struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.response.size metric with initial data.
+func (m *metricHttpcheckResponseSize) init() {
+	m.data.SetName("httpcheck.response.size")
+	m.data.SetDescription("Size of the response body.")
+	m.data.SetUnit("By")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckResponseSize) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckResponseSize) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckResponseSize) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckResponseSize(settings MetricSettings) metricHttpcheckResponseSize {
+	m := metricHttpcheckResponseSize{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 type metricHttpcheckStatus struct {
 	data     pmetric.Metric // data buffer for generated metric.
 	settings MetricSettings // metric settings provided by user.
@@ -218,17 +449,72 @@ func newMetricHttpcheckStatus(settings MetricSettings) metricHttpcheckStatus {
 	return m
 }
 
+type metricHttpcheckTLSCertExpiry struct {
+	data     pmetric.Metric // data buffer for generated metric.
+	settings MetricSettings // metric settings provided by user.
+	capacity int            // max observed number of data points added to the metric.
+}
+
+// init fills httpcheck.tls.cert.expiry metric with initial data.
+func (m *metricHttpcheckTLSCertExpiry) init() {
+	m.data.SetName("httpcheck.tls.cert.expiry")
+	m.data.SetDescription("Time at which the TLS certificate presented by the endpoint expires, in seconds since the Unix epoch.")
+	m.data.SetUnit("s")
+	m.data.SetEmptyGauge()
+	m.data.Gauge().DataPoints().EnsureCapacity(m.capacity)
+}
+
+func (m *metricHttpcheckTLSCertExpiry) recordDataPoint(start pcommon.Timestamp, ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	if !m.settings.Enabled {
+		return
+	}
+	dp := m.data.Gauge().DataPoints().AppendEmpty()
+	dp.SetStartTimestamp(start)
+	dp.SetTimestamp(ts)
+	dp.SetIntValue(val)
+	dp.Attributes().PutStr("http.url", httpURLAttributeValue)
+}
+
+// updateCapacity saves max length of data point slices that will be used for the slice capacity.
+func (m *metricHttpcheckTLSCertExpiry) updateCapacity() {
+	if m.data.Gauge().DataPoints().Len() > m.capacity {
+		m.capacity = m.data.Gauge().DataPoints().Len()
+	}
+}
+
+// emit appends recorded metric data to a metrics slice and prepares it for recording another set of data points.
+func (m *metricHttpcheckTLSCertExpiry) emit(metrics pmetric.MetricSlice) {
+	if m.settings.Enabled && m.data.Gauge().DataPoints().Len() > 0 {
+		m.updateCapacity()
+		m.data.MoveTo(metrics.AppendEmpty())
+		m.init()
+	}
+}
+
+func newMetricHttpcheckTLSCertExpiry(settings MetricSettings) metricHttpcheckTLSCertExpiry {
+	m := metricHttpcheckTLSCertExpiry{settings: settings}
+	if settings.Enabled {
+		m.data = pmetric.NewMetric()
+		m.init()
+	}
+	return m
+}
+
 // MetricsBuilder provides an interface for scrapers to report metrics while taking care of all the transformations
 // required to produce metric representation defined in metadata and user settings.
 type MetricsBuilder struct {
-	startTime               pcommon.Timestamp   // start time that will be applied to all recorded data points.
-	metricsCapacity         int                 // maximum observed number of metrics per resource.
-	resourceCapacity        int                 // maximum observed number of resource attributes.
-	metricsBuffer           pmetric.Metrics     // accumulates metrics data before emitting.
-	buildInfo               component.BuildInfo // contains version information
-	metricHttpcheckDuration metricHttpcheckDuration
-	metricHttpcheckError    metricHttpcheckError
-	metricHttpcheckStatus   metricHttpcheckStatus
+	startTime                    pcommon.Timestamp   // start time that will be applied to all recorded data points.
+	metricsCapacity              int                 // maximum observed number of metrics per resource.
+	resourceCapacity             int                 // maximum observed number of resource attributes.
+	metricsBuffer                pmetric.Metrics     // accumulates metrics data before emitting.
+	buildInfo                    component.BuildInfo // contains version information
+	metricHttpcheckAssertion     metricHttpcheckAssertion
+	metricHttpcheckDuration      metricHttpcheckDuration
+	metricHttpcheckError         metricHttpcheckError
+	metricHttpcheckPhaseDuration metricHttpcheckPhaseDuration
+	metricHttpcheckResponseSize  metricHttpcheckResponseSize
+	metricHttpcheckStatus        metricHttpcheckStatus
+	metricHttpcheckTLSCertExpiry metricHttpcheckTLSCertExpiry
 }
 
 // metricBuilderOption applies changes to default metrics builder.
@@ -243,12 +529,16 @@ func WithStartTime(startTime pcommon.Timestamp) metricBuilderOption {
 
 func NewMetricsBuilder(ms MetricsSettings, settings receiver.CreateSettings, options ...metricBuilderOption) *MetricsBuilder {
 	mb := &MetricsBuilder{
-		startTime:               pcommon.NewTimestampFromTime(time.Now()),
-		metricsBuffer:           pmetric.NewMetrics(),
-		buildInfo:               settings.BuildInfo,
-		metricHttpcheckDuration: newMetricHttpcheckDuration(ms.HttpcheckDuration),
-		metricHttpcheckError:    newMetricHttpcheckError(ms.HttpcheckError),
-		metricHttpcheckStatus:   newMetricHttpcheckStatus(ms.HttpcheckStatus),
+		startTime:                    pcommon.NewTimestampFromTime(time.Now()),
+		metricsBuffer:                pmetric.NewMetrics(),
+		buildInfo:                    settings.BuildInfo,
+		metricHttpcheckAssertion:     newMetricHttpcheckAssertion(ms.HttpcheckAssertion),
+		metricHttpcheckDuration:      newMetricHttpcheckDuration(ms.HttpcheckDuration),
+		metricHttpcheckError:         newMetricHttpcheckError(ms.HttpcheckError),
+		metricHttpcheckPhaseDuration: newMetricHttpcheckPhaseDuration(ms.HttpcheckPhaseDuration),
+		metricHttpcheckResponseSize:  newMetricHttpcheckResponseSize(ms.HttpcheckResponseSize),
+		metricHttpcheckStatus:        newMetricHttpcheckStatus(ms.HttpcheckStatus),
+		metricHttpcheckTLSCertExpiry: newMetricHttpcheckTLSCertExpiry(ms.HttpcheckTLSCertExpiry),
 	}
 	for _, op := range options {
 		op(mb)
@@ -301,9 +591,13 @@ func (mb *MetricsBuilder) EmitForResource(rmo ...ResourceMetricsOption) {
 	ils.Scope().SetName("otelcol/httpcheckreceiver")
 	ils.Scope().SetVersion(mb.buildInfo.Version)
 	ils.Metrics().EnsureCapacity(mb.metricsCapacity)
+	mb.metricHttpcheckAssertion.emit(ils.Metrics())
 	mb.metricHttpcheckDuration.emit(ils.Metrics())
 	mb.metricHttpcheckError.emit(ils.Metrics())
+	mb.metricHttpcheckPhaseDuration.emit(ils.Metrics())
+	mb.metricHttpcheckResponseSize.emit(ils.Metrics())
 	mb.metricHttpcheckStatus.emit(ils.Metrics())
+	mb.metricHttpcheckTLSCertExpiry.emit(ils.Metrics())
 	for _, op := range rmo {
 		op(rm)
 	}
@@ -323,6 +617,11 @@ func (mb *MetricsBuilder) Emit(rmo ...ResourceMetricsOption) pmetric.Metrics {
 	return metrics
 }
 
+// RecordHttpcheckAssertionDataPoint adds a data point to httpcheck.assertion metric.
+func (mb *MetricsBuilder) RecordHttpcheckAssertionDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, assertionTypeAttributeValue AttributeAssertionType) {
+	mb.metricHttpcheckAssertion.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, assertionTypeAttributeValue.String())
+}
+
 // RecordHttpcheckDurationDataPoint adds a data point to httpcheck.duration metric.
 func (mb *MetricsBuilder) RecordHttpcheckDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
 	mb.metricHttpcheckDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
@@ -333,11 +632,26 @@ func (mb *MetricsBuilder) RecordHttpcheckErrorDataPoint(ts pcommon.Timestamp, va
 	mb.metricHttpcheckError.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, errorMessageAttributeValue)
 }
 
+// RecordHttpcheckPhaseDurationDataPoint adds a data point to httpcheck.phase.duration metric.
+func (mb *MetricsBuilder) RecordHttpcheckPhaseDurationDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpPhaseAttributeValue AttributeHTTPPhase) {
+	mb.metricHttpcheckPhaseDuration.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpPhaseAttributeValue.String())
+}
+
+// RecordHttpcheckResponseSizeDataPoint adds a data point to httpcheck.response.size metric.
+func (mb *MetricsBuilder) RecordHttpcheckResponseSizeDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckResponseSize.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
 // RecordHttpcheckStatusDataPoint adds a data point to httpcheck.status metric.
 func (mb *MetricsBuilder) RecordHttpcheckStatusDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string, httpStatusCodeAttributeValue int64, httpMethodAttributeValue string, httpStatusClassAttributeValue string) {
 	mb.metricHttpcheckStatus.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue, httpStatusCodeAttributeValue, httpMethodAttributeValue, httpStatusClassAttributeValue)
 }
 
+// RecordHttpcheckTLSCertExpiryDataPoint adds a data point to httpcheck.tls.cert.expiry metric.
+func (mb *MetricsBuilder) RecordHttpcheckTLSCertExpiryDataPoint(ts pcommon.Timestamp, val int64, httpURLAttributeValue string) {
+	mb.metricHttpcheckTLSCertExpiry.recordDataPoint(mb.startTime, ts, val, httpURLAttributeValue)
+}
+
 // Reset resets metrics builder to its initial state. It should be used when external metrics source is restarted,
 // and metrics builder should update its startTime and reset it's internal state accordingly.
 func (mb *MetricsBuilder) Reset(options ...metricBuilderOption) {
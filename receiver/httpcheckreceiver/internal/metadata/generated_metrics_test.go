@@ -20,15 +20,24 @@ func TestDefaultMetrics(t *testing.T) {
 	mb := NewMetricsBuilder(DefaultMetricsSettings(), receivertest.NewNopCreateSettings(), WithStartTime(start))
 	enabledMetrics := make(map[string]bool)
 
+	enabledMetrics["httpcheck.assertion"] = true
+	mb.RecordHttpcheckAssertionDataPoint(ts, 1, "attr-val", AttributeAssertionType(1))
+
 	enabledMetrics["httpcheck.duration"] = true
 	mb.RecordHttpcheckDurationDataPoint(ts, 1, "attr-val")
 
 	enabledMetrics["httpcheck.error"] = true
 	mb.RecordHttpcheckErrorDataPoint(ts, 1, "attr-val", "attr-val")
 
+	mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "attr-val", AttributeHTTPPhase(1))
+
+	mb.RecordHttpcheckResponseSizeDataPoint(ts, 1, "attr-val")
+
 	enabledMetrics["httpcheck.status"] = true
 	mb.RecordHttpcheckStatusDataPoint(ts, 1, "attr-val", 1, "attr-val", "attr-val")
 
+	mb.RecordHttpcheckTLSCertExpiryDataPoint(ts, 1, "attr-val")
+
 	metrics := mb.Emit()
 
 	assert.Equal(t, 1, metrics.ResourceMetrics().Len())
@@ -48,9 +57,13 @@ func TestAllMetrics(t *testing.T) {
 	start := pcommon.Timestamp(1_000_000_000)
 	ts := pcommon.Timestamp(1_000_001_000)
 	metricsSettings := MetricsSettings{
-		HttpcheckDuration: MetricSettings{Enabled: true},
-		HttpcheckError:    MetricSettings{Enabled: true},
-		HttpcheckStatus:   MetricSettings{Enabled: true},
+		HttpcheckAssertion:     MetricSettings{Enabled: true},
+		HttpcheckDuration:      MetricSettings{Enabled: true},
+		HttpcheckError:         MetricSettings{Enabled: true},
+		HttpcheckPhaseDuration: MetricSettings{Enabled: true},
+		HttpcheckResponseSize:  MetricSettings{Enabled: true},
+		HttpcheckStatus:        MetricSettings{Enabled: true},
+		HttpcheckTLSCertExpiry: MetricSettings{Enabled: true},
 	}
 	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
 	settings := receivertest.NewNopCreateSettings()
@@ -59,9 +72,13 @@ func TestAllMetrics(t *testing.T) {
 
 	assert.Equal(t, 0, observedLogs.Len())
 
+	mb.RecordHttpcheckAssertionDataPoint(ts, 1, "attr-val", AttributeAssertionType(1))
 	mb.RecordHttpcheckDurationDataPoint(ts, 1, "attr-val")
 	mb.RecordHttpcheckErrorDataPoint(ts, 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "attr-val", AttributeHTTPPhase(1))
+	mb.RecordHttpcheckResponseSizeDataPoint(ts, 1, "attr-val")
 	mb.RecordHttpcheckStatusDataPoint(ts, 1, "attr-val", 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckTLSCertExpiryDataPoint(ts, 1, "attr-val")
 
 	metrics := mb.Emit()
 
@@ -77,6 +94,23 @@ func TestAllMetrics(t *testing.T) {
 	validatedMetrics := make(map[string]struct{})
 	for i := 0; i < ms.Len(); i++ {
 		switch ms.At(i).Name() {
+		case "httpcheck.assertion":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "1 if the response satisfied the assertion configured for the target, otherwise 0.", ms.At(i).Description())
+			assert.Equal(t, "1", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("http.url")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("assertion.type")
+			assert.True(t, ok)
+			assert.Equal(t, "status_code", attrVal.Str())
+			validatedMetrics["httpcheck.assertion"] = struct{}{}
 		case "httpcheck.duration":
 			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
 			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
@@ -110,6 +144,37 @@ func TestAllMetrics(t *testing.T) {
 			assert.True(t, ok)
 			assert.EqualValues(t, "attr-val", attrVal.Str())
 			validatedMetrics["httpcheck.error"] = struct{}{}
+		case "httpcheck.phase.duration":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "Measures the duration of a phase of the HTTP check. Phases not performed by the check, such as the DNS lookup for an IP address or the connection setup for a reused connection, are not recorded.", ms.At(i).Description())
+			assert.Equal(t, "ms", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("http.url")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			attrVal, ok = dp.Attributes().Get("http.phase")
+			assert.True(t, ok)
+			assert.Equal(t, "dns", attrVal.Str())
+			validatedMetrics["httpcheck.phase.duration"] = struct{}{}
+		case "httpcheck.response.size":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "Size of the response body.", ms.At(i).Description())
+			assert.Equal(t, "By", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("http.url")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["httpcheck.response.size"] = struct{}{}
 		case "httpcheck.status":
 			assert.Equal(t, pmetric.MetricTypeSum, ms.At(i).Type())
 			assert.Equal(t, 1, ms.At(i).Sum().DataPoints().Len())
@@ -135,6 +200,20 @@ func TestAllMetrics(t *testing.T) {
 			assert.True(t, ok)
 			assert.EqualValues(t, "attr-val", attrVal.Str())
 			validatedMetrics["httpcheck.status"] = struct{}{}
+		case "httpcheck.tls.cert.expiry":
+			assert.Equal(t, pmetric.MetricTypeGauge, ms.At(i).Type())
+			assert.Equal(t, 1, ms.At(i).Gauge().DataPoints().Len())
+			assert.Equal(t, "Time at which the TLS certificate presented by the endpoint expires, in seconds since the Unix epoch.", ms.At(i).Description())
+			assert.Equal(t, "s", ms.At(i).Unit())
+			dp := ms.At(i).Gauge().DataPoints().At(0)
+			assert.Equal(t, start, dp.StartTimestamp())
+			assert.Equal(t, ts, dp.Timestamp())
+			assert.Equal(t, pmetric.NumberDataPointValueTypeInt, dp.ValueType())
+			assert.Equal(t, int64(1), dp.IntValue())
+			attrVal, ok := dp.Attributes().Get("http.url")
+			assert.True(t, ok)
+			assert.EqualValues(t, "attr-val", attrVal.Str())
+			validatedMetrics["httpcheck.tls.cert.expiry"] = struct{}{}
 		}
 	}
 	assert.Equal(t, allMetricsCount, len(validatedMetrics))
@@ -144,9 +223,13 @@ func TestNoMetrics(t *testing.T) {
 	start := pcommon.Timestamp(1_000_000_000)
 	ts := pcommon.Timestamp(1_000_001_000)
 	metricsSettings := MetricsSettings{
-		HttpcheckDuration: MetricSettings{Enabled: false},
-		HttpcheckError:    MetricSettings{Enabled: false},
-		HttpcheckStatus:   MetricSettings{Enabled: false},
+		HttpcheckAssertion:     MetricSettings{Enabled: false},
+		HttpcheckDuration:      MetricSettings{Enabled: false},
+		HttpcheckError:         MetricSettings{Enabled: false},
+		HttpcheckPhaseDuration: MetricSettings{Enabled: false},
+		HttpcheckResponseSize:  MetricSettings{Enabled: false},
+		HttpcheckStatus:        MetricSettings{Enabled: false},
+		HttpcheckTLSCertExpiry: MetricSettings{Enabled: false},
 	}
 	observedZapCore, observedLogs := observer.New(zap.WarnLevel)
 	settings := receivertest.NewNopCreateSettings()
@@ -154,9 +237,13 @@ func TestNoMetrics(t *testing.T) {
 	mb := NewMetricsBuilder(metricsSettings, settings, WithStartTime(start))
 
 	assert.Equal(t, 0, observedLogs.Len())
+	mb.RecordHttpcheckAssertionDataPoint(ts, 1, "attr-val", AttributeAssertionType(1))
 	mb.RecordHttpcheckDurationDataPoint(ts, 1, "attr-val")
 	mb.RecordHttpcheckErrorDataPoint(ts, 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckPhaseDurationDataPoint(ts, 1, "attr-val", AttributeHTTPPhase(1))
+	mb.RecordHttpcheckResponseSizeDataPoint(ts, 1, "attr-val")
 	mb.RecordHttpcheckStatusDataPoint(ts, 1, "attr-val", 1, "attr-val", "attr-val")
+	mb.RecordHttpcheckTLSCertExpiryDataPoint(ts, 1, "attr-val")
 
 	metrics := mb.Emit()
 
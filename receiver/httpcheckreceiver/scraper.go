@@ -16,8 +16,14 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"crypto/tls"
 	"errors"
+	"io"
 	"net/http"
+	"net/http/httptrace"
+	"regexp"
+	"strings"
+	"sync"
 	"time"
 
 	"go.opentelemetry.io/collector/component"
@@ -28,6 +34,10 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/httpcheckreceiver/internal/metadata"
 )
 
+// maxResponseBodySize is the number of bytes of the response bodies kept to be matched against
+// the expected body regex, the rest of a larger body is only counted in its size.
+const maxResponseBodySize = 1 << 20
+
 var (
 	errClientNotInit    = errors.New("client not initialized")
 	httpResponseClasses = map[string]int{"1xx": 1, "2xx": 2, "3xx": 3, "4xx": 4, "5xx": 5}
@@ -37,49 +47,244 @@ type httpcheckScraper struct {
 	client   *http.Client
 	cfg      *Config
 	settings component.TelemetrySettings
-	mb       *metadata.MetricsBuilder
+	targets  []target
+
+	// mu guards mb, which is shared by the concurrent checks.
+	mu sync.Mutex
+	mb *metadata.MetricsBuilder
+}
+
+// target is a TargetConfig ready to be checked.
+type target struct {
+	TargetConfig
+	bodyRegex *regexp.Regexp
 }
 
 // start starts the scraper by creating a new HTTP Client on the scraper
 func (h *httpcheckScraper) start(ctx context.Context, host component.Host) (err error) {
+	targetConfigs := h.cfg.Targets
+	if len(targetConfigs) == 0 {
+		targetConfigs = []TargetConfig{{Endpoint: h.cfg.Endpoint}}
+	}
+
+	h.targets = make([]target, 0, len(targetConfigs))
+	for _, tc := range targetConfigs {
+		t := target{TargetConfig: tc}
+		if t.Method == "" {
+			t.Method = h.cfg.Method
+		}
+		if t.ExpectedBodyRegex != "" {
+			if t.bodyRegex, err = regexp.Compile(t.ExpectedBodyRegex); err != nil {
+				return err
+			}
+		}
+		h.targets = append(h.targets, t)
+	}
+
 	h.client, err = h.cfg.ToClient(host, h.settings)
 	return
 }
 
-// scrape connects to the endpoint and produces metrics based on the response
+// scrape checks all targets, at most MaxConcurrency at a time, and produces metrics based on the responses
 func (h *httpcheckScraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
 	if h.client == nil {
 		return pmetric.NewMetrics(), errClientNotInit
 	}
 
+	maxConcurrency := h.cfg.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = defaultMaxConcurrency
+	}
+	sem := make(chan struct{}, maxConcurrency)
+
+	var wg sync.WaitGroup
+	for i := range h.targets {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(t *target) {
+			defer func() {
+				<-sem
+				wg.Done()
+			}()
+			h.check(ctx, t)
+		}(&h.targets[i])
+	}
+	wg.Wait()
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.mb.Emit(), nil
+}
+
+// check performs the request to a single target and records its metrics
+func (h *httpcheckScraper) check(ctx context.Context, t *target) {
 	now := pcommon.NewTimestampFromTime(time.Now())
 
-	req, err := http.NewRequestWithContext(ctx, h.cfg.Method, h.cfg.Endpoint, http.NoBody)
+	var body io.Reader = http.NoBody
+	if t.Body != "" {
+		body = strings.NewReader(t.Body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, t.Method, t.Endpoint, body)
 	if err != nil {
-		return pmetric.Metrics{}, err
+		h.mu.Lock()
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), t.Endpoint, err.Error())
+		h.mu.Unlock()
+		return
 	}
+	for k, v := range t.Headers {
+		if strings.EqualFold(k, "host") {
+			req.Host = v
+		} else {
+			req.Header.Set(k, v)
+		}
+	}
+
+	tracer := &phaseTracer{}
+	req = req.WithContext(httptrace.WithClientTrace(req.Context(), tracer.clientTrace()))
 
 	start := time.Now()
 	resp, err := h.client.Do(req)
-	h.mb.RecordHttpcheckDurationDataPoint(now, time.Since(start).Milliseconds(), h.cfg.Endpoint)
 
-	statusCode := 0
-	if err != nil {
-		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), h.cfg.Endpoint, err.Error())
-	} else {
+	var (
+		statusCode   = 0
+		size         int64
+		readErr      error
+		responseBody []byte
+	)
+	if err == nil {
+		if t.bodyRegex != nil {
+			responseBody, readErr = io.ReadAll(io.LimitReader(resp.Body, maxResponseBodySize))
+			size = int64(len(responseBody))
+		}
+		if readErr == nil {
+			var discarded int64
+			discarded, readErr = io.Copy(io.Discard, resp.Body)
+			size += discarded
+		}
+		resp.Body.Close()
 		statusCode = resp.StatusCode
 	}
+	duration := time.Since(start)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.mb.RecordHttpcheckDurationDataPoint(now, duration.Milliseconds(), t.Endpoint)
+	tracer.record(h.mb, now, t.Endpoint, start)
+
+	switch {
+	case err != nil:
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), t.Endpoint, err.Error())
+	case readErr != nil:
+		h.mb.RecordHttpcheckErrorDataPoint(now, int64(1), t.Endpoint, readErr.Error())
+	default:
+		h.mb.RecordHttpcheckResponseSizeDataPoint(now, size, t.Endpoint)
+	}
+
+	if err == nil {
+		if expiry, ok := certificateExpiry(resp.TLS); ok {
+			h.mb.RecordHttpcheckTLSCertExpiryDataPoint(now, expiry.Unix(), t.Endpoint)
+		}
+	}
 
 	for class, intVal := range httpResponseClasses {
 		if statusCode/100 == intVal {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(1), t.Endpoint, int64(statusCode), req.Method, class)
 		} else {
-			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), h.cfg.Endpoint, int64(statusCode), req.Method, class)
+			h.mb.RecordHttpcheckStatusDataPoint(now, int64(0), t.Endpoint, int64(statusCode), req.Method, class)
 		}
 
 	}
 
-	return h.mb.Emit(), nil
+	if len(t.ExpectedStatusCodes) > 0 {
+		h.mb.RecordHttpcheckAssertionDataPoint(now, boolToInt(containsStatusCode(t.ExpectedStatusCodes, statusCode)), t.Endpoint, metadata.AttributeAssertionTypeStatusCode)
+	}
+	if t.bodyRegex != nil {
+		matched := err == nil && readErr == nil && t.bodyRegex.Match(responseBody)
+		h.mb.RecordHttpcheckAssertionDataPoint(now, boolToInt(matched), t.Endpoint, metadata.AttributeAssertionTypeBody)
+	}
+}
+
+// certificateExpiry returns the expiry time of the leaf certificate of the connection, if any.
+func certificateExpiry(state *tls.ConnectionState) (time.Time, bool) {
+	if state == nil || len(state.PeerCertificates) == 0 {
+		return time.Time{}, false
+	}
+	return state.PeerCertificates[0].NotAfter, true
+}
+
+func containsStatusCode(codes []int, code int) bool {
+	for _, c := range codes {
+		if c == code {
+			return true
+		}
+	}
+	return false
+}
+
+func boolToInt(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// phaseTracer collects the timings of the phases of a request through httptrace.
+type phaseTracer struct {
+	mu                        sync.Mutex
+	dnsStart, dnsDone         time.Time
+	connectStart, connectDone time.Time
+	tlsStart, tlsDone         time.Time
+	firstByte                 time.Time
+}
+
+func (p *phaseTracer) clientTrace() *httptrace.ClientTrace {
+	set := func(field *time.Time) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		*field = time.Now()
+	}
+	setOnce := func(field *time.Time) {
+		p.mu.Lock()
+		defer p.mu.Unlock()
+		if field.IsZero() {
+			*field = time.Now()
+		}
+	}
+	return &httptrace.ClientTrace{
+		DNSStart: func(httptrace.DNSStartInfo) { set(&p.dnsStart) },
+		DNSDone:  func(httptrace.DNSDoneInfo) { set(&p.dnsDone) },
+		// Connections to several addresses may be attempted, the phase
+		// spans from the first attempt to the last one completing.
+		ConnectStart:         func(string, string) { setOnce(&p.connectStart) },
+		ConnectDone:          func(string, string, error) { set(&p.connectDone) },
+		TLSHandshakeStart:    func() { set(&p.tlsStart) },
+		TLSHandshakeDone:     func(tls.ConnectionState, error) { set(&p.tlsDone) },
+		GotFirstResponseByte: func() { set(&p.firstByte) },
+	}
+}
+
+// record records the duration of the phases that happened during the request.
+// Time to first byte is measured from the start of the request.
+func (p *phaseTracer) record(mb *metadata.MetricsBuilder, now pcommon.Timestamp, endpoint string, start time.Time) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for _, phase := range []struct {
+		start, end time.Time
+		attr       metadata.AttributeHTTPPhase
+	}{
+		{p.dnsStart, p.dnsDone, metadata.AttributeHTTPPhaseDns},
+		{p.connectStart, p.connectDone, metadata.AttributeHTTPPhaseConnect},
+		{p.tlsStart, p.tlsDone, metadata.AttributeHTTPPhaseTls},
+		{start, p.firstByte, metadata.AttributeHTTPPhaseTtfb},
+	} {
+		if phase.start.IsZero() || phase.end.IsZero() {
+			continue
+		}
+		mb.RecordHttpcheckPhaseDurationDataPoint(now, phase.end.Sub(phase.start).Milliseconds(), endpoint, phase.attr)
+	}
 }
 
 func newScraper(conf *Config, settings receiver.CreateSettings) *httpcheckScraper {
@@ -16,11 +16,16 @@ package httpcheckreceiver // import "github.com/open-telemetry/opentelemetry-col
 
 import (
 	"context"
+	"io"
 	"net/http"
 	"net/http/httptest"
 	"path/filepath"
+	"strings"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/config/confighttp"
@@ -175,6 +180,137 @@ func TestScaperScrape(t *testing.T) {
 	}
 }
 
+func TestScraperScrapeTargets(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		inFlight int
+		maxSeen  int
+	)
+	handler := http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxSeen {
+			maxSeen = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(20 * time.Millisecond)
+
+		if req.Header.Get("X-Check") != "true" {
+			rw.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		body, err := io.ReadAll(req.Body)
+		assert.NoError(t, err)
+		rw.WriteHeader(http.StatusCreated)
+		_, err = rw.Write([]byte("status: " + string(body)))
+		assert.NoError(t, err)
+	})
+	ms := httptest.NewServer(handler)
+	defer ms.Close()
+	tlsServer := httptest.NewTLSServer(handler)
+	defer tlsServer.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.MaxConcurrency = 2
+	enableTargetMetrics(cfg)
+	cfg.TLSSetting.InsecureSkipVerify = true
+	headers := map[string]string{"X-Check": "true"}
+	cfg.Targets = []TargetConfig{
+		{Endpoint: ms.URL + "/ok", Method: "POST", Headers: headers, Body: "ok", ExpectedStatusCodes: []int{201}, ExpectedBodyRegex: "^status: ok$"},
+		{Endpoint: ms.URL + "/unexpected-body", Method: "POST", Headers: headers, Body: "degraded", ExpectedBodyRegex: "^status: ok$"},
+		{Endpoint: ms.URL + "/missing-header", ExpectedStatusCodes: []int{200, 201}},
+		{Endpoint: tlsServer.URL, Headers: headers},
+	}
+
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+	require.LessOrEqual(t, maxSeen, 2)
+
+	type key struct {
+		metric, url, attr string
+	}
+	values := map[key]int64{}
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		m := metrics.At(i)
+		var dps pmetric.NumberDataPointSlice
+		switch m.Type() {
+		case pmetric.MetricTypeGauge:
+			dps = m.Gauge().DataPoints()
+		case pmetric.MetricTypeSum:
+			dps = m.Sum().DataPoints()
+		}
+		for j := 0; j < dps.Len(); j++ {
+			dp := dps.At(j)
+			url, _ := dp.Attributes().Get("http.url")
+			k := key{metric: m.Name(), url: url.Str()}
+			for _, name := range []string{"assertion.type", "http.phase", "http.status_class"} {
+				if v, ok := dp.Attributes().Get(name); ok {
+					k.attr = v.Str()
+				}
+			}
+			values[k] = dp.IntValue()
+		}
+	}
+
+	assert.Equal(t, int64(1), values[key{"httpcheck.assertion", ms.URL + "/ok", "status_code"}])
+	assert.Equal(t, int64(1), values[key{"httpcheck.assertion", ms.URL + "/ok", "body"}])
+	assert.Equal(t, int64(len("status: ok")), values[key{"httpcheck.response.size", ms.URL + "/ok", ""}])
+
+	assert.Equal(t, int64(0), values[key{"httpcheck.assertion", ms.URL + "/unexpected-body", "body"}])
+	assert.NotContains(t, values, key{"httpcheck.assertion", ms.URL + "/unexpected-body", "status_code"})
+
+	assert.Equal(t, int64(0), values[key{"httpcheck.assertion", ms.URL + "/missing-header", "status_code"}])
+	assert.Equal(t, int64(1), values[key{"httpcheck.status", ms.URL + "/missing-header", "4xx"}])
+
+	assert.Equal(t, tlsServer.Certificate().NotAfter.Unix(), values[key{"httpcheck.tls.cert.expiry", tlsServer.URL, ""}])
+	assert.Contains(t, values, key{"httpcheck.phase.duration", tlsServer.URL, "tls"})
+	assert.Contains(t, values, key{"httpcheck.phase.duration", tlsServer.URL, "ttfb"})
+	assert.NotContains(t, values, key{"httpcheck.tls.cert.expiry", ms.URL + "/ok", ""})
+}
+
+func enableTargetMetrics(cfg *Config) {
+	cfg.Metrics.HttpcheckPhaseDuration.Enabled = true
+	cfg.Metrics.HttpcheckResponseSize.Enabled = true
+	cfg.Metrics.HttpcheckTLSCertExpiry.Enabled = true
+}
+
+func TestScraperLimitsResponseBody(t *testing.T) {
+	ms := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, err := rw.Write([]byte(strings.Repeat("x", maxResponseBodySize+1024) + "tail"))
+		assert.NoError(t, err)
+	}))
+	defer ms.Close()
+
+	cfg := createDefaultConfig().(*Config)
+	enableTargetMetrics(cfg)
+	cfg.Targets = []TargetConfig{{Endpoint: ms.URL, ExpectedBodyRegex: "tail"}}
+	scraper := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, scraper.start(context.Background(), componenttest.NewNopHost()))
+
+	actualMetrics, err := scraper.scrape(context.Background())
+	require.NoError(t, err)
+
+	values := map[string]int64{}
+	metrics := actualMetrics.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics()
+	for i := 0; i < metrics.Len(); i++ {
+		if m := metrics.At(i); m.Type() == pmetric.MetricTypeGauge {
+			values[m.Name()] = m.Gauge().DataPoints().At(0).IntValue()
+		}
+	}
+	assert.Equal(t, int64(maxResponseBodySize+1024+len("tail")), values["httpcheck.response.size"])
+	// the end of the body isn't matched
+	assert.Equal(t, int64(0), values["httpcheck.assertion"])
+}
+
 func TestNilClient(t *testing.T) {
 	scraper := newScraper(createDefaultConfig().(*Config), receivertest.NewNopCreateSettings())
 	actualMetrics, err := scraper.scrape(context.Background())
@@ -15,10 +15,93 @@
 package influxdbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/influxdbreceiver"
 
 import (
+	"errors"
+	"time"
+
 	"go.opentelemetry.io/collector/config/confighttp"
 )
 
 // Config defines configuration for the InfluxDB receiver.
 type Config struct {
 	confighttp.HTTPServerSettings `mapstructure:",squash"`
+
+	// UDP enables a UDP listener receiving newline-delimited line protocol,
+	// as sent by the Telegraf socket_writer output.
+	UDP *SocketConfig `mapstructure:"udp"`
+
+	// TCP enables a TCP listener receiving newline-delimited line protocol,
+	// as sent by the Telegraf socket_writer output.
+	TCP *TCPConfig `mapstructure:"tcp"`
+
+	// ResourceAttributes defines the resource attributes set from the query
+	// parameters of the HTTP write requests.
+	ResourceAttributes ResourceAttributesConfig `mapstructure:"resource_attributes"`
+}
+
+// SocketConfig defines a socket listener.
+type SocketConfig struct {
+	// Endpoint is the address the listener binds to.
+	Endpoint string `mapstructure:"endpoint"`
+}
+
+// TCPConfig defines the TCP listener.
+type TCPConfig struct {
+	SocketConfig `mapstructure:",squash"`
+
+	// MaxLineSize is the maximum size of a line, the connections sending longer
+	// lines are closed. It defaults to 64 KiB.
+	MaxLineSize int `mapstructure:"max_line_size"`
+
+	// IdleTimeout is the maximum duration a connection waits for new data
+	// before being closed. It defaults to 5 minutes.
+	IdleTimeout time.Duration `mapstructure:"idle_timeout"`
+}
+
+// ResourceAttributesConfig defines the names of the resource attributes set
+// from the query parameters of the write requests. The attributes aren't set
+// when their name is empty.
+type ResourceAttributesConfig struct {
+	// Org is the attribute set from the org parameter of /api/v2/write.
+	Org string `mapstructure:"org"`
+	// Bucket is the attribute set from the bucket parameter of /api/v2/write.
+	Bucket string `mapstructure:"bucket"`
+	// DB is the attribute set from the db parameter of /write.
+	DB string `mapstructure:"db"`
+	// RP is the attribute set from the rp parameter of /write.
+	RP string `mapstructure:"rp"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if cfg.UDP != nil && cfg.UDP.Endpoint == "" {
+		return errors.New(`"udp" requires an "endpoint"`)
+	}
+	if cfg.TCP != nil {
+		if cfg.TCP.Endpoint == "" {
+			return errors.New(`"tcp" requires an "endpoint"`)
+		}
+		if cfg.TCP.MaxLineSize < 0 {
+			return errors.New(`"tcp" "max_line_size" must not be negative`)
+		}
+		if cfg.TCP.IdleTimeout < 0 {
+			return errors.New(`"tcp" "idle_timeout" must not be negative`)
+		}
+	}
+	return nil
+}
+
+// queryParameters returns the attribute names by query parameter.
+func (c ResourceAttributesConfig) queryParameters() map[string]string {
+	params := make(map[string]string)
+	for param, attr := range map[string]string{
+		"org":    c.Org,
+		"bucket": c.Bucket,
+		"db":     c.DB,
+		"rp":     c.RP,
+	} {
+		if attr != "" {
+			params[param] = attr
+		}
+	}
+	return params
 }
@@ -18,7 +18,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"sync"
 	"time"
 
@@ -29,6 +31,8 @@ import (
 	"go.opentelemetry.io/collector/config/confighttp"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/consumer/consumererror"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.uber.org/multierr"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/sanitize"
 )
@@ -36,10 +40,18 @@ import (
 type metricsReceiver struct {
 	nextConsumer       consumer.Metrics
 	httpServerSettings *confighttp.HTTPServerSettings
+	udpSettings        *SocketConfig
+	tcpSettings        *TCPConfig
 	converter          *influx2otel.LineProtocolToOtelMetrics
+	queryAttributes    map[string]string
 
-	server *http.Server
-	wg     sync.WaitGroup
+	server      *http.Server
+	udpConn     net.PacketConn
+	tcpListener net.Listener
+	tcpConns    map[net.Conn]struct{}
+	tcpClosing  bool
+	tcpConnsMu  sync.Mutex
+	wg          sync.WaitGroup
 
 	logger common.Logger
 
@@ -55,7 +67,10 @@ func newMetricsReceiver(config *Config, settings component.TelemetrySettings, ne
 	receiver := &metricsReceiver{
 		nextConsumer:       nextConsumer,
 		httpServerSettings: &config.HTTPServerSettings,
+		udpSettings:        config.UDP,
+		tcpSettings:        config.TCP,
 		converter:          converter,
+		queryAttributes:    config.ResourceAttributes.queryParameters(),
 		logger:             influxLogger,
 		settings:           settings,
 	}
@@ -84,15 +99,34 @@ func (r *metricsReceiver) Start(_ context.Context, host component.Host) error {
 		}
 	}()
 
+	if r.udpSettings != nil {
+		if err = r.startUDP(); err != nil {
+			return err
+		}
+	}
+	if r.tcpSettings != nil {
+		if err = r.startTCP(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
 func (r *metricsReceiver) Shutdown(ctx context.Context) error {
-	if err := r.server.Close(); err != nil {
-		return err
+	var errs error
+	if r.server != nil {
+		errs = multierr.Append(errs, r.server.Close())
+	}
+	if r.udpConn != nil {
+		errs = multierr.Append(errs, r.udpConn.Close())
+	}
+	if r.tcpListener != nil {
+		errs = multierr.Append(errs, r.tcpListener.Close())
+		r.closeTCPConns()
 	}
 	r.wg.Wait()
-	return nil
+	return errs
 }
 
 const defaultPrecision = lineprotocol.Nanosecond
@@ -120,16 +154,35 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 	}
 
 	batch := r.converter.NewBatch()
-	lpDecoder := lineprotocol.NewDecoder(req.Body)
+	if err := r.parseLines(batch, lineprotocol.NewDecoder(req.Body), precision); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		_, _ = fmt.Fprint(w, err.Error())
+		return
+	}
+
+	metrics := batch.GetMetrics()
+	setResourceAttributes(metrics, r.queryAttributes, req.URL.Query())
+	if err := r.nextConsumer.ConsumeMetrics(req.Context(), metrics); err != nil {
+		if consumererror.IsPermanent(err) {
+			w.WriteHeader(http.StatusBadRequest)
+		} else {
+			w.WriteHeader(http.StatusInternalServerError)
+		}
+		r.logger.Debug("failed to pass metrics to next consumer: %s", err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
 
+// parseLines adds the points of the line protocol read by the decoder to the batch.
+func (r *metricsReceiver) parseLines(batch *influx2otel.MetricsBatch, lpDecoder *lineprotocol.Decoder, precision lineprotocol.Precision) error {
 	var k, vTag []byte
 	var vField lineprotocol.Value
 	for line := 0; lpDecoder.Next(); line++ {
 		measurement, err := lpDecoder.Measurement()
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse measurement on line %d", line)
-			return
+			return fmt.Errorf("failed to parse measurement on line %d", line)
 		}
 
 		tags := make(map[string]string)
@@ -137,9 +190,7 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 			tags[string(k)] = string(vTag)
 		}
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse tag on line %d", line)
-			return
+			return fmt.Errorf("failed to parse tag on line %d", line)
 		}
 
 		fields := make(map[string]interface{})
@@ -147,41 +198,38 @@ func (r *metricsReceiver) handleWrite(w http.ResponseWriter, req *http.Request)
 			fields[string(k)] = vField.Interface()
 		}
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse field on line %d", line)
-			return
+			return fmt.Errorf("failed to parse field on line %d", line)
 		}
 
 		ts, err := lpDecoder.Time(precision, time.Time{})
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse timestamp on line %d", line)
-			return
+			return fmt.Errorf("failed to parse timestamp on line %d", line)
 		}
 
 		if err = lpDecoder.Err(); err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to parse line: %s", err.Error())
-			return
+			return fmt.Errorf("failed to parse line: %s", err.Error())
 		}
 
 		err = batch.AddPoint(string(measurement), tags, fields, ts, common.InfluxMetricValueTypeUntyped)
 		if err != nil {
-			w.WriteHeader(http.StatusBadRequest)
-			_, _ = fmt.Fprintf(w, "failed to append to the batch")
-			return
+			return errors.New("failed to append to the batch")
 		}
 	}
 
-	if err := r.nextConsumer.ConsumeMetrics(req.Context(), batch.GetMetrics()); err != nil {
-		if consumererror.IsPermanent(err) {
-			w.WriteHeader(http.StatusBadRequest)
-		} else {
-			w.WriteHeader(http.StatusInternalServerError)
+	return nil
+}
+
+// setResourceAttributes sets the resource attributes mapped from the query
+// parameters of a write request.
+func setResourceAttributes(metrics pmetric.Metrics, queryAttributes map[string]string, query url.Values) {
+	for param, attr := range queryAttributes {
+		value := query.Get(param)
+		if value == "" {
+			continue
+		}
+		rms := metrics.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			rms.At(i).Resource().Attributes().PutStr(attr, value)
 		}
-		r.logger.Debug("failed to pass metrics to next consumer: %s", err)
-		return
 	}
-
-	w.WriteHeader(http.StatusNoContent)
 }
@@ -0,0 +1,249 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbreceiver
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/common/testutil"
+)
+
+const testLines = "cpu_temp,host=a gauge=87.332 1609459200000000000\nhttp_requests_total,method=post counter=1027 1609459200000000000\n"
+
+func startTestReceiver(t *testing.T, cfg *Config) *consumertest.MetricsSink {
+	require.NoError(t, cfg.Validate())
+	sink := new(consumertest.MetricsSink)
+	r, err := NewFactory().CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, r.Start(context.Background(), componenttest.NewNopHost()))
+	t.Cleanup(func() {
+		assert.NoError(t, r.Shutdown(context.Background()))
+	})
+	return sink
+}
+
+func newTestConfig(t *testing.T) *Config {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Endpoint = testutil.GetAvailableLocalAddress(t)
+	return cfg
+}
+
+func TestWriteResourceAttributes(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.ResourceAttributes = ResourceAttributesConfig{
+		Org:    "influxdb.org",
+		Bucket: "influxdb.bucket",
+		DB:     "influxdb.db",
+	}
+	sink := startTestReceiver(t, cfg)
+
+	tests := []struct {
+		path  string
+		attrs map[string]interface{}
+	}{
+		{
+			path: "/api/v2/write?org=team-a&bucket=telegraf&precision=ns",
+			attrs: map[string]interface{}{
+				"influxdb.org":    "team-a",
+				"influxdb.bucket": "telegraf",
+			},
+		},
+		{
+			path: "/write?db=team-b&rp=autogen",
+			attrs: map[string]interface{}{
+				"influxdb.db": "team-b",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			sink.Reset()
+			resp, err := http.Post(fmt.Sprintf("http://%s%s", cfg.Endpoint, tt.path), "text/plain", bytes.NewBufferString(testLines))
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+			require.Len(t, sink.AllMetrics(), 1)
+			md := sink.AllMetrics()[0]
+			assert.Equal(t, 2, md.MetricCount())
+			for i := 0; i < md.ResourceMetrics().Len(); i++ {
+				assert.Equal(t, tt.attrs, md.ResourceMetrics().At(i).Resource().Attributes().AsRaw())
+			}
+		})
+	}
+}
+
+func TestWriteGzip(t *testing.T) {
+	cfg := newTestConfig(t)
+	sink := startTestReceiver(t, cfg)
+
+	var body bytes.Buffer
+	gw := gzip.NewWriter(&body)
+	_, err := gw.Write([]byte(testLines))
+	require.NoError(t, err)
+	require.NoError(t, gw.Close())
+
+	req, err := http.NewRequest(http.MethodPost, fmt.Sprintf("http://%s/api/v2/write", cfg.Endpoint), &body)
+	require.NoError(t, err)
+	req.Header.Set("Content-Encoding", "gzip")
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+
+	require.Len(t, sink.AllMetrics(), 1)
+	assert.Equal(t, 2, sink.AllMetrics()[0].MetricCount())
+}
+
+func TestWriteInvalidLine(t *testing.T) {
+	cfg := newTestConfig(t)
+	sink := startTestReceiver(t, cfg)
+
+	resp, err := http.Post(fmt.Sprintf("http://%s/api/v2/write", cfg.Endpoint), "text/plain", bytes.NewBufferString("cpu_temp,host=a gauge=87.332 nope\n"))
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	assert.Empty(t, sink.AllMetrics())
+}
+
+func metricCount(sink *consumertest.MetricsSink) int {
+	var count int
+	for _, md := range sink.AllMetrics() {
+		count += md.MetricCount()
+	}
+	return count
+}
+
+func TestSocketListeners(t *testing.T) {
+	for _, network := range []string{"udp", "tcp"} {
+		t.Run(network, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			socket := SocketConfig{Endpoint: testutil.GetAvailableLocalNetworkAddress(t, network)}
+			if network == "udp" {
+				cfg.UDP = &socket
+			} else {
+				cfg.TCP = &TCPConfig{SocketConfig: socket}
+			}
+			sink := startTestReceiver(t, cfg)
+
+			conn, err := net.Dial(network, socket.Endpoint)
+			require.NoError(t, err)
+			// the invalid line is skipped
+			_, err = conn.Write([]byte(testLines + "invalid line\n"))
+			require.NoError(t, err)
+			_, err = conn.Write([]byte("mem,host=a used=42i 1609459200000000000\n"))
+			require.NoError(t, err)
+			require.NoError(t, conn.Close())
+
+			assert.Eventually(t, func() bool {
+				return metricCount(sink) == 3
+			}, 5*time.Second, 10*time.Millisecond)
+
+			var names []string
+			for _, md := range sink.AllMetrics() {
+				rms := md.ResourceMetrics()
+				for i := 0; i < rms.Len(); i++ {
+					sms := rms.At(i).ScopeMetrics()
+					for j := 0; j < sms.Len(); j++ {
+						ms := sms.At(j).Metrics()
+						for k := 0; k < ms.Len(); k++ {
+							names = append(names, ms.At(k).Name())
+						}
+					}
+				}
+			}
+			assert.ElementsMatch(t, []string{"cpu_temp", "http_requests_total", "mem_used"}, names)
+		})
+	}
+}
+
+func TestTCPListenerClosesConnections(t *testing.T) {
+	tests := []struct {
+		name  string
+		tcp   TCPConfig
+		write string
+	}{
+		{
+			name:  "line_too_long",
+			tcp:   TCPConfig{MaxLineSize: 64},
+			write: "mem,host=a used=42i 1609459200000000000\n" + strings.Repeat("x", 100) + "\n",
+		},
+		{
+			name: "idle",
+			tcp:  TCPConfig{IdleTimeout: 50 * time.Millisecond},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := newTestConfig(t)
+			cfg.TCP = &tt.tcp
+			cfg.TCP.Endpoint = testutil.GetAvailableLocalNetworkAddress(t, "tcp")
+			sink := startTestReceiver(t, cfg)
+
+			conn, err := net.Dial("tcp", cfg.TCP.Endpoint)
+			require.NoError(t, err)
+			defer conn.Close()
+			if tt.write != "" {
+				_, err = conn.Write([]byte(tt.write))
+				require.NoError(t, err)
+			}
+
+			// the receiver closes the connection, which is reset when it still holds unread data
+			require.NoError(t, conn.SetReadDeadline(time.Now().Add(5*time.Second)))
+			_, err = conn.Read(make([]byte, 1))
+			require.Error(t, err)
+			var netErr net.Error
+			assert.False(t, errors.As(err, &netErr) && netErr.Timeout(), "the connection should be closed before the deadline")
+
+			if tt.write != "" {
+				// the lines received before the long line are still consumed
+				assert.Equal(t, 1, metricCount(sink))
+			}
+		})
+	}
+}
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.UDP = &SocketConfig{}
+	assert.EqualError(t, cfg.Validate(), `"udp" requires an "endpoint"`)
+
+	cfg.UDP = nil
+	cfg.TCP = &TCPConfig{}
+	assert.EqualError(t, cfg.Validate(), `"tcp" requires an "endpoint"`)
+
+	cfg.TCP = &TCPConfig{SocketConfig: SocketConfig{Endpoint: "localhost:8094"}, MaxLineSize: -1}
+	assert.EqualError(t, cfg.Validate(), `"tcp" "max_line_size" must not be negative`)
+
+	cfg.TCP = &TCPConfig{SocketConfig: SocketConfig{Endpoint: "localhost:8094"}, IdleTimeout: -time.Second}
+	assert.EqualError(t, cfg.Validate(), `"tcp" "idle_timeout" must not be negative`)
+}
@@ -0,0 +1,217 @@
+// Copyright 2021, OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package influxdbreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/influxdbreceiver"
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/influxdata/line-protocol/v2/lineprotocol"
+)
+
+const (
+	// maxDatagramSize is the maximum size of the UDP datagrams.
+	maxDatagramSize = 64 * 1024
+
+	defaultMaxLineSize = 64 * 1024
+	defaultIdleTimeout = 5 * time.Minute
+
+	// acceptRetryDelay is the delay before accepting new TCP connections after a failure.
+	acceptRetryDelay = 100 * time.Millisecond
+)
+
+func (r *metricsReceiver) startUDP() error {
+	conn, err := net.ListenPacket("udp", r.udpSettings.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.udpSettings.Endpoint, err)
+	}
+	r.udpConn = conn
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		buf := make([]byte, maxDatagramSize)
+		for {
+			n, _, err := conn.ReadFrom(buf)
+			if n > 0 {
+				r.consumeLines(bytes.Split(buf[:n], []byte{'\n'}))
+			}
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			if err != nil {
+				r.logger.Debug("failed to read UDP datagram", "error", err)
+			}
+		}
+	}()
+	return nil
+}
+
+func (r *metricsReceiver) startTCP() error {
+	ln, err := net.Listen("tcp", r.tcpSettings.Endpoint)
+	if err != nil {
+		return fmt.Errorf("failed to bind to address %s: %w", r.tcpSettings.Endpoint, err)
+	}
+	r.tcpListener = ln
+	r.tcpConns = make(map[net.Conn]struct{})
+
+	r.wg.Add(1)
+	go func() {
+		defer r.wg.Done()
+		for {
+			conn, err := ln.Accept()
+			if errors.Is(err, net.ErrClosed) {
+				return
+			}
+			if err != nil {
+				// keep accepting connections, the error can be temporary, e.g. too many
+				// open files, the sleep prevents a hot loop if it persists
+				r.logger.Debug("failed to accept TCP connection", "error", err)
+				time.Sleep(acceptRetryDelay)
+				continue
+			}
+
+			if !r.trackTCPConn(conn) {
+				_ = conn.Close()
+				continue
+			}
+
+			r.wg.Add(1)
+			go func() {
+				defer r.wg.Done()
+				r.handleTCPConn(conn)
+
+				r.tcpConnsMu.Lock()
+				delete(r.tcpConns, conn)
+				r.tcpConnsMu.Unlock()
+			}()
+		}
+	}()
+	return nil
+}
+
+// trackTCPConn registers a connection to be closed on shutdown, it returns
+// false when the receiver is already shutting down.
+func (r *metricsReceiver) trackTCPConn(conn net.Conn) bool {
+	r.tcpConnsMu.Lock()
+	defer r.tcpConnsMu.Unlock()
+	if r.tcpClosing {
+		return false
+	}
+	r.tcpConns[conn] = struct{}{}
+	return true
+}
+
+func (r *metricsReceiver) closeTCPConns() {
+	r.tcpConnsMu.Lock()
+	defer r.tcpConnsMu.Unlock()
+	r.tcpClosing = true
+	for conn := range r.tcpConns {
+		_ = conn.Close()
+	}
+}
+
+// handleTCPConn reads the lines of a connection, the lines available at once
+// being sent together.
+func (r *metricsReceiver) handleTCPConn(conn net.Conn) {
+	defer conn.Close()
+
+	maxLineSize := r.tcpSettings.MaxLineSize
+	if maxLineSize == 0 {
+		maxLineSize = defaultMaxLineSize
+	}
+	idleTimeout := r.tcpSettings.IdleTimeout
+	if idleTimeout == 0 {
+		idleTimeout = defaultIdleTimeout
+	}
+
+	var lines [][]byte
+	flush := func() {
+		if len(lines) > 0 {
+			r.consumeLines(lines)
+			lines = nil
+		}
+	}
+
+	scanner := bufio.NewScanner(&idleReader{
+		conn:        conn,
+		idleTimeout: idleTimeout,
+		// the scanner only reads from the connection once it has returned
+		// all the lines already received
+		beforeRead: flush,
+	})
+	// the capacity of the initial buffer must not exceed the maximum line size,
+	// otherwise it is the one limiting the size of the lines
+	initialSize := 4096
+	if maxLineSize < initialSize {
+		initialSize = maxLineSize
+	}
+	scanner.Buffer(make([]byte, 0, initialSize), maxLineSize)
+	for scanner.Scan() {
+		// the scanner reuses its buffer
+		lines = append(lines, append([]byte(nil), scanner.Bytes()...))
+	}
+	flush()
+
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		r.logger.Debug("failed to read TCP connection", "error", err)
+	}
+}
+
+// idleReader reads from a connection, failing when no data is received
+// within the idle timeout.
+type idleReader struct {
+	conn        net.Conn
+	idleTimeout time.Duration
+	beforeRead  func()
+}
+
+func (ir *idleReader) Read(p []byte) (int, error) {
+	ir.beforeRead()
+	if err := ir.conn.SetReadDeadline(time.Now().Add(ir.idleTimeout)); err != nil {
+		return 0, err
+	}
+	return ir.conn.Read(p)
+}
+
+// consumeLines converts and sends the points of the lines received by the
+// socket listeners. Unlike HTTP requests, the invalid lines can't be reported
+// to the sender, they are logged and skipped.
+func (r *metricsReceiver) consumeLines(lines [][]byte) {
+	batch := r.converter.NewBatch()
+	var points int
+	for _, line := range lines {
+		if len(bytes.TrimSpace(line)) == 0 {
+			continue
+		}
+		if err := r.parseLines(batch, lineprotocol.NewDecoderWithBytes(line), defaultPrecision); err != nil {
+			r.logger.Debug("failed to parse line", "error", err)
+			continue
+		}
+		points++
+	}
+	if points == 0 {
+		return
+	}
+
+	if err := r.nextConsumer.ConsumeMetrics(context.Background(), batch.GetMetrics()); err != nil {
+		r.logger.Debug("failed to pass metrics to next consumer", "error", err)
+	}
+}
@@ -0,0 +1,90 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttppullreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlphttppullreceiver"
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
+)
+
+const (
+	encodingProto = "proto"
+	encodingJSON  = "json"
+)
+
+var (
+	errNoTargets       = errors.New(`at least one target must be specified in "targets"`)
+	errMissingEndpoint = errors.New(`"endpoint" must be specified`)
+	errInvalidEndpoint = errors.New(`"endpoint" must be in the form of <scheme>://<hostname>[:<port>]/<path>`)
+)
+
+// Config defines the configuration of the OTLP/HTTP pull receiver.
+type Config struct {
+	scraperhelper.ScraperControllerSettings `mapstructure:",squash"`
+
+	// Targets are the HTTP endpoints the OTLP metrics documents are pulled from.
+	Targets []TargetConfig `mapstructure:"targets"`
+}
+
+// TargetConfig defines an HTTP endpoint serving an OTLP metrics document.
+type TargetConfig struct {
+	confighttp.HTTPClientSettings `mapstructure:",squash"`
+
+	// Encoding is the encoding requested to the target, proto or json. When empty, both
+	// are accepted, protobuf being preferred.
+	Encoding string `mapstructure:"encoding"`
+
+	// ResourceAttributes are added to the resources of the metrics pulled from the target,
+	// overriding the attributes with the same keys.
+	ResourceAttributes map[string]string `mapstructure:"resource_attributes"`
+}
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	if len(cfg.Targets) == 0 {
+		return errNoTargets
+	}
+
+	var err error
+	for i, target := range cfg.Targets {
+		err = multierr.Append(err, target.validate(i))
+	}
+	return err
+}
+
+func (t *TargetConfig) validate(index int) error {
+	var err error
+
+	if t.Endpoint == "" {
+		err = multierr.Append(err, fmt.Errorf("targets[%d]: %w", index, errMissingEndpoint))
+	} else if u, parseErr := url.Parse(t.Endpoint); parseErr != nil {
+		err = multierr.Append(err, fmt.Errorf("targets[%d]: %s: %w", index, errInvalidEndpoint.Error(), parseErr))
+	} else if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		err = multierr.Append(err, fmt.Errorf("targets[%d]: %w", index, errInvalidEndpoint))
+	}
+
+	switch t.Encoding {
+	case "", encodingProto, encodingJSON:
+	default:
+		err = multierr.Append(err, fmt.Errorf("targets[%d]: invalid encoding %q, must be %s or %s", index, t.Encoding, encodingProto, encodingJSON))
+	}
+
+	return err
+}
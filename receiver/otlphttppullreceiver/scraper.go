@@ -0,0 +1,196 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttppullreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlphttppullreceiver"
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"sync"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+const (
+	contentTypeProtobuf    = "application/x-protobuf"
+	contentTypeAltProtobuf = "application/protobuf"
+	contentTypeJSON        = "application/json"
+)
+
+var (
+	protoUnmarshaler = &pmetric.ProtoUnmarshaler{}
+	jsonUnmarshaler  = &pmetric.JSONUnmarshaler{}
+)
+
+type target struct {
+	cfg    TargetConfig
+	client *http.Client
+	accept string
+}
+
+type scraper struct {
+	cfg      *Config
+	settings component.TelemetrySettings
+	targets  []*target
+}
+
+func newScraper(cfg *Config, settings receiver.CreateSettings) *scraper {
+	return &scraper{
+		cfg:      cfg,
+		settings: settings.TelemetrySettings,
+	}
+}
+
+func (s *scraper) start(_ context.Context, host component.Host) error {
+	s.targets = make([]*target, 0, len(s.cfg.Targets))
+	for _, cfg := range s.cfg.Targets {
+		client, err := cfg.ToClient(host, s.settings)
+		if err != nil {
+			return fmt.Errorf("failed to create the HTTP client of %s: %w", cfg.Endpoint, err)
+		}
+		s.targets = append(s.targets, &target{
+			cfg:    cfg,
+			client: client,
+			accept: acceptHeader(cfg.Encoding),
+		})
+	}
+	return nil
+}
+
+// scrape pulls the metrics of all the targets concurrently. The failure of a target is
+// reported as a partial error, so that the metrics of the other targets are still sent.
+func (s *scraper) scrape(ctx context.Context) (pmetric.Metrics, error) {
+	// bound the requests of the targets without timeout to the collection interval
+	ctx, cancel := context.WithTimeout(ctx, s.cfg.CollectionInterval)
+	defer cancel()
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		errs scrapererror.ScrapeErrors
+	)
+	metrics := pmetric.NewMetrics()
+	for _, t := range s.targets {
+		wg.Add(1)
+		go func(t *target) {
+			defer wg.Done()
+
+			md, err := t.pull(ctx)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				// the number of metrics of the target isn't known
+				errs.AddPartial(0, fmt.Errorf("failed to pull metrics from %s: %w", t.cfg.Endpoint, err))
+				return
+			}
+			md.ResourceMetrics().MoveAndAppendTo(metrics.ResourceMetrics())
+		}(t)
+	}
+	wg.Wait()
+
+	return metrics, errs.Combine()
+}
+
+func (t *target) pull(ctx context.Context) (pmetric.Metrics, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, t.cfg.Endpoint, nil)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	req.Header.Set("Accept", t.accept)
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	defer func() {
+		_, _ = io.Copy(io.Discard, resp.Body)
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return pmetric.Metrics{}, fmt.Errorf("HTTP %d %q", resp.StatusCode, http.StatusText(resp.StatusCode))
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return pmetric.Metrics{}, fmt.Errorf("failed to read the response: %w", err)
+	}
+
+	unmarshaler, err := t.unmarshaler(resp.Header.Get("Content-Type"))
+	if err != nil {
+		return pmetric.Metrics{}, err
+	}
+	md, err := unmarshaler.UnmarshalMetrics(body)
+	if err != nil {
+		return pmetric.Metrics{}, fmt.Errorf("failed to unmarshal the metrics: %w", err)
+	}
+
+	t.addResourceAttributes(md)
+	return md, nil
+}
+
+// unmarshaler returns the unmarshaler of the content type of a response, falling back to
+// the configured encoding when the target doesn't send a content type.
+func (t *target) unmarshaler(contentType string) (pmetric.Unmarshaler, error) {
+	if contentType == "" {
+		if t.cfg.Encoding == encodingJSON {
+			return jsonUnmarshaler, nil
+		}
+		return protoUnmarshaler, nil
+	}
+
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid content type %q: %w", contentType, err)
+	}
+	switch mediaType {
+	case contentTypeProtobuf, contentTypeAltProtobuf:
+		return protoUnmarshaler, nil
+	case contentTypeJSON:
+		return jsonUnmarshaler, nil
+	default:
+		return nil, fmt.Errorf("unsupported content type %q", mediaType)
+	}
+}
+
+func (t *target) addResourceAttributes(md pmetric.Metrics) {
+	if len(t.cfg.ResourceAttributes) == 0 {
+		return
+	}
+	rms := md.ResourceMetrics()
+	for i := 0; i < rms.Len(); i++ {
+		attrs := rms.At(i).Resource().Attributes()
+		for k, v := range t.cfg.ResourceAttributes {
+			attrs.PutStr(k, v)
+		}
+	}
+}
+
+func acceptHeader(encoding string) string {
+	switch encoding {
+	case encodingProto:
+		return contentTypeProtobuf
+	case encodingJSON:
+		return contentTypeJSON
+	default:
+		return contentTypeProtobuf + ", " + contentTypeJSON + ";q=0.9"
+	}
+}
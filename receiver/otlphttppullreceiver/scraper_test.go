@@ -0,0 +1,184 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlphttppullreceiver
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/config/confighttp"
+	"go.opentelemetry.io/collector/config/configopaque"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scrapererror"
+)
+
+func testMetrics(service string) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", service)
+	m := rm.ScopeMetrics().AppendEmpty().Metrics().AppendEmpty()
+	m.SetName("otelcol_process_uptime")
+	m.SetEmptyGauge().DataPoints().AppendEmpty().SetDoubleValue(42)
+	return md
+}
+
+// newTestServer serves the metrics in the first content type accepted by the request.
+func newTestServer(t *testing.T, md pmetric.Metrics, accepts *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept := r.Header.Get("Accept")
+		if accepts != nil {
+			*accepts = append(*accepts, accept)
+		}
+		var (
+			body []byte
+			err  error
+		)
+		if accept == contentTypeJSON {
+			w.Header().Set("Content-Type", contentTypeJSON)
+			body, err = (&pmetric.JSONMarshaler{}).MarshalMetrics(md)
+		} else {
+			w.Header().Set("Content-Type", contentTypeProtobuf)
+			body, err = (&pmetric.ProtoMarshaler{}).MarshalMetrics(md)
+		}
+		require.NoError(t, err)
+		_, _ = w.Write(body)
+	}))
+}
+
+func newTestScraper(t *testing.T, targets ...TargetConfig) *scraper {
+	cfg := createDefaultConfig().(*Config)
+	cfg.Targets = targets
+	require.NoError(t, cfg.Validate())
+
+	s := newScraper(cfg, receivertest.NewNopCreateSettings())
+	require.NoError(t, s.start(context.Background(), componenttest.NewNopHost()))
+	return s
+}
+
+func newTarget(endpoint string, encoding string) TargetConfig {
+	return TargetConfig{
+		HTTPClientSettings: confighttp.HTTPClientSettings{
+			Endpoint: endpoint,
+			Timeout:  5 * time.Second,
+		},
+		Encoding: encoding,
+	}
+}
+
+func TestScrapeContentNegotiation(t *testing.T) {
+	var accepts []string
+	server := newTestServer(t, testMetrics("collector"), &accepts)
+	defer server.Close()
+
+	tests := []struct {
+		encoding string
+		accept   string
+	}{
+		{encoding: "", accept: "application/x-protobuf, application/json;q=0.9"},
+		{encoding: encodingProto, accept: contentTypeProtobuf},
+		{encoding: encodingJSON, accept: contentTypeJSON},
+	}
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			accepts = nil
+			s := newTestScraper(t, newTarget(server.URL, tt.encoding))
+
+			md, err := s.scrape(context.Background())
+			require.NoError(t, err)
+			assert.Equal(t, []string{tt.accept}, accepts)
+			assert.Equal(t, testMetrics("collector"), md)
+		})
+	}
+}
+
+func TestScrapeResourceAttributes(t *testing.T) {
+	server := newTestServer(t, testMetrics("collector"), nil)
+	defer server.Close()
+
+	target := newTarget(server.URL, encodingJSON)
+	target.ResourceAttributes = map[string]string{
+		"service.name":   "gateway",
+		"collector.zone": "eu-west-1",
+	}
+	s := newTestScraper(t, target)
+
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, 1, md.ResourceMetrics().Len())
+	assert.Equal(t, map[string]interface{}{
+		"service.name":   "gateway",
+		"collector.zone": "eu-west-1",
+	}, md.ResourceMetrics().At(0).Resource().Attributes().AsRaw())
+}
+
+func TestScrapeHeaders(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, err := (&pmetric.JSONMarshaler{}).MarshalMetrics(testMetrics("collector"))
+		require.NoError(t, err)
+		// no content type, the configured encoding is used
+		w.Header()["Content-Type"] = nil
+		_, _ = w.Write(body)
+	}))
+	defer server.Close()
+
+	target := newTarget(server.URL, encodingJSON)
+	s := newTestScraper(t, target)
+	_, err := s.scrape(context.Background())
+	assert.ErrorContains(t, err, `HTTP 401 "Unauthorized"`)
+
+	target.Headers = map[string]configopaque.String{"Authorization": "Bearer token"}
+	s = newTestScraper(t, target)
+	md, err := s.scrape(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, testMetrics("collector"), md)
+}
+
+func TestScrapePartialFailure(t *testing.T) {
+	server := newTestServer(t, testMetrics("collector"), nil)
+	defer server.Close()
+	failing := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer failing.Close()
+	unsupported := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = w.Write([]byte("otelcol_process_uptime 42"))
+	}))
+	defer unsupported.Close()
+
+	s := newTestScraper(t,
+		newTarget(server.URL, ""),
+		newTarget(failing.URL, ""),
+		newTarget(unsupported.URL, ""),
+	)
+
+	md, err := s.scrape(context.Background())
+	require.Error(t, err)
+	assert.True(t, scrapererror.IsPartialScrapeError(err))
+	assert.ErrorContains(t, err, `HTTP 503 "Service Unavailable"`)
+	assert.ErrorContains(t, err, `unsupported content type "text/plain"`)
+	assert.Equal(t, testMetrics("collector"), md)
+}
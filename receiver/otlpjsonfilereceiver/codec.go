@@ -0,0 +1,166 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/klauspost/compress/zstd"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+	"go.uber.org/zap"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/pkg/stanza/fileconsumer"
+)
+
+const (
+	formatJSON  = "json"
+	formatProto = "proto"
+
+	compressionZSTD = "zstd"
+
+	replayOriginal = "original"
+	replayNow      = "now"
+
+	// the size of the length prefix of the messages, see the file exporter
+	messageSizeLength = 4
+)
+
+var decoder, _ = zstd.NewReader(nil)
+
+// Validate checks the receiver configuration is valid.
+func (cfg *Config) Validate() error {
+	switch cfg.Format {
+	case formatJSON, formatProto:
+	default:
+		return fmt.Errorf("format %q is not supported, must be %s or %s", cfg.Format, formatJSON, formatProto)
+	}
+	if cfg.Compression != "" && cfg.Compression != compressionZSTD {
+		return fmt.Errorf("compression %q is not supported, must be %s", cfg.Compression, compressionZSTD)
+	}
+	switch cfg.Replay {
+	case replayOriginal, replayNow:
+	default:
+		return fmt.Errorf("replay %q is not supported, must be %s or %s", cfg.Replay, replayOriginal, replayNow)
+	}
+	return nil
+}
+
+// framed returns whether the messages of the files are prefixed with their size rather than
+// separated by new lines. The file exporter frames the protobuf and the compressed messages.
+func (cfg *Config) framed() bool {
+	return cfg.Format == formatProto || cfg.Compression != ""
+}
+
+// buildInput builds the file consumer emitting the messages of the files.
+func (cfg *Config) buildInput(logger *zap.SugaredLogger, emit fileconsumer.EmitFunc) (*fileconsumer.Manager, error) {
+	if !cfg.framed() {
+		return cfg.Config.Build(logger, emit)
+	}
+
+	consumerCfg := cfg.Config
+	// binary messages must be neither decoded as text nor flushed before being entirely written
+	consumerCfg.Splitter.EncodingConfig.Encoding = "nop"
+	consumerCfg.Splitter.Flusher.Period = 0
+	return consumerCfg.BuildWithSplitFunc(logger, emit, splitMessages)
+}
+
+// splitMessages splits the messages prefixed by their size as a 32 bits big endian integer.
+func splitMessages(data []byte, atEOF bool) (advance int, token []byte, err error) {
+	if len(data) < messageSizeLength {
+		return 0, nil, nil
+	}
+	size := int(binary.BigEndian.Uint32(data))
+	if len(data) < messageSizeLength+size {
+		return 0, nil, nil
+	}
+	return messageSizeLength + size, data[messageSizeLength : messageSizeLength+size], nil
+}
+
+func (cfg *Config) decompress(buf []byte) ([]byte, error) {
+	if cfg.Compression != compressionZSTD {
+		return buf, nil
+	}
+	buf, err := decoder.DecodeAll(buf, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress the message: %w", err)
+	}
+	return buf, nil
+}
+
+func (cfg *Config) unmarshalLogs() func([]byte) (plog.Logs, error) {
+	var unmarshaler plog.Unmarshaler = &plog.JSONUnmarshaler{}
+	if cfg.Format == formatProto {
+		unmarshaler = &plog.ProtoUnmarshaler{}
+	}
+	return func(buf []byte) (plog.Logs, error) {
+		buf, err := cfg.decompress(buf)
+		if err != nil {
+			return plog.Logs{}, err
+		}
+		ld, err := unmarshaler.UnmarshalLogs(buf)
+		if err != nil {
+			return plog.Logs{}, err
+		}
+		if cfg.Replay == replayNow {
+			shiftLogsTimestamps(ld, now())
+		}
+		return ld, nil
+	}
+}
+
+func (cfg *Config) unmarshalMetrics() func([]byte) (pmetric.Metrics, error) {
+	var unmarshaler pmetric.Unmarshaler = &pmetric.JSONUnmarshaler{}
+	if cfg.Format == formatProto {
+		unmarshaler = &pmetric.ProtoUnmarshaler{}
+	}
+	return func(buf []byte) (pmetric.Metrics, error) {
+		buf, err := cfg.decompress(buf)
+		if err != nil {
+			return pmetric.Metrics{}, err
+		}
+		md, err := unmarshaler.UnmarshalMetrics(buf)
+		if err != nil {
+			return pmetric.Metrics{}, err
+		}
+		if cfg.Replay == replayNow {
+			shiftMetricsTimestamps(md, now())
+		}
+		return md, nil
+	}
+}
+
+func (cfg *Config) unmarshalTraces() func([]byte) (ptrace.Traces, error) {
+	var unmarshaler ptrace.Unmarshaler = &ptrace.JSONUnmarshaler{}
+	if cfg.Format == formatProto {
+		unmarshaler = &ptrace.ProtoUnmarshaler{}
+	}
+	return func(buf []byte) (ptrace.Traces, error) {
+		buf, err := cfg.decompress(buf)
+		if err != nil {
+			return ptrace.Traces{}, err
+		}
+		td, err := unmarshaler.UnmarshalTraces(buf)
+		if err != nil {
+			return ptrace.Traces{}, err
+		}
+		if cfg.Replay == replayNow {
+			shiftTracesTimestamps(td, now())
+		}
+		return td, nil
+	}
+}
@@ -48,11 +48,24 @@ func NewFactory() rcvr.Factory {
 type Config struct {
 	fileconsumer.Config `mapstructure:",squash"`
 	StorageID           *component.ID `mapstructure:"storage"`
+
+	// Format is the format of the messages in the files, json or proto, as written by the file exporter.
+	Format string `mapstructure:"format"`
+
+	// Compression is the compression of the messages in the files, none or zstd.
+	Compression string `mapstructure:"compression"`
+
+	// Replay defines the timestamps of the replayed data: original keeps them as they are in
+	// the files and now shifts them, so that the most recent timestamp of each message is the
+	// time it's read.
+	Replay string `mapstructure:"replay"`
 }
 
 func createDefaultConfig() component.Config {
 	return &Config{
 		Config: *fileconsumer.NewConfig(),
+		Format: formatJSON,
+		Replay: replayOriginal,
 	}
 }
 
@@ -75,7 +88,6 @@ func (f *receiver) Shutdown(ctx context.Context) error {
 }
 
 func createLogsReceiver(_ context.Context, settings rcvr.CreateSettings, configuration component.Config, logs consumer.Logs) (rcvr.Logs, error) {
-	logsUnmarshaler := &plog.JSONUnmarshaler{}
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             settings.ID,
 		Transport:              transport,
@@ -85,10 +97,11 @@ func createLogsReceiver(_ context.Context, settings rcvr.CreateSettings, configu
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	unmarshalLogs := cfg.unmarshalLogs()
+	input, err := cfg.buildInput(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
 		ctx = obsrecv.StartLogsOp(ctx)
 		var l plog.Logs
-		l, err = logsUnmarshaler.UnmarshalLogs(token)
+		l, err = unmarshalLogs(token)
 		if err != nil {
 			obsrecv.EndLogsOp(ctx, typeStr, 0, err)
 		} else {
@@ -104,7 +117,6 @@ func createLogsReceiver(_ context.Context, settings rcvr.CreateSettings, configu
 }
 
 func createMetricsReceiver(_ context.Context, settings rcvr.CreateSettings, configuration component.Config, metrics consumer.Metrics) (rcvr.Metrics, error) {
-	metricsUnmarshaler := &pmetric.JSONUnmarshaler{}
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             settings.ID,
 		Transport:              transport,
@@ -114,10 +126,11 @@ func createMetricsReceiver(_ context.Context, settings rcvr.CreateSettings, conf
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	unmarshalMetrics := cfg.unmarshalMetrics()
+	input, err := cfg.buildInput(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
 		ctx = obsrecv.StartMetricsOp(ctx)
 		var m pmetric.Metrics
-		m, err = metricsUnmarshaler.UnmarshalMetrics(token)
+		m, err = unmarshalMetrics(token)
 		if err != nil {
 			obsrecv.EndMetricsOp(ctx, typeStr, 0, err)
 		} else {
@@ -133,7 +146,6 @@ func createMetricsReceiver(_ context.Context, settings rcvr.CreateSettings, conf
 }
 
 func createTracesReceiver(ctx context.Context, settings rcvr.CreateSettings, configuration component.Config, traces consumer.Traces) (rcvr.Traces, error) {
-	tracesUnmarshaler := &ptrace.JSONUnmarshaler{}
 	obsrecv, err := obsreport.NewReceiver(obsreport.ReceiverSettings{
 		ReceiverID:             settings.ID,
 		Transport:              transport,
@@ -143,10 +155,11 @@ func createTracesReceiver(ctx context.Context, settings rcvr.CreateSettings, con
 		return nil, err
 	}
 	cfg := configuration.(*Config)
-	input, err := cfg.Config.Build(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
+	unmarshalTraces := cfg.unmarshalTraces()
+	input, err := cfg.buildInput(settings.Logger.Sugar(), func(ctx context.Context, attrs *fileconsumer.FileAttributes, token []byte) {
 		ctx = obsrecv.StartTracesOp(ctx)
 		var t ptrace.Traces
-		t, err = tracesUnmarshaler.UnmarshalTraces(token)
+		t, err = unmarshalTraces(token)
 		if err != nil {
 			obsrecv.EndTracesOp(ctx, typeStr, 0, err)
 		} else {
@@ -16,11 +16,13 @@ package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-
 
 import (
 	"context"
+	"encoding/binary"
 	"os"
 	"path/filepath"
 	"testing"
 	"time"
 
+	"github.com/klauspost/compress/zstd"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component"
@@ -140,6 +142,8 @@ func testdataConfigYamlAsMap() *Config {
 				Exclude: []string{"/var/log/example.log"},
 			},
 		},
+		Format: formatJSON,
+		Replay: replayOriginal,
 	}
 }
 
@@ -155,3 +159,98 @@ func TestLoadConfig(t *testing.T) {
 
 	assert.Equal(t, testdataConfigYamlAsMap(), cfg)
 }
+
+func TestValidateConfig(t *testing.T) {
+	cfg := createDefaultConfig().(*Config)
+	assert.NoError(t, cfg.Validate())
+
+	cfg.Format = "text"
+	assert.EqualError(t, cfg.Validate(), `format "text" is not supported, must be json or proto`)
+
+	cfg.Format = formatProto
+	cfg.Compression = "gzip"
+	assert.EqualError(t, cfg.Validate(), `compression "gzip" is not supported, must be zstd`)
+
+	cfg.Compression = compressionZSTD
+	cfg.Replay = "later"
+	assert.EqualError(t, cfg.Validate(), `replay "later" is not supported, must be original or now`)
+}
+
+// frameMessages writes the messages as the file exporter does with the proto format or a compression.
+func frameMessages(t *testing.T, compression string, messages ...[]byte) []byte {
+	var encoder *zstd.Encoder
+	if compression == compressionZSTD {
+		var err error
+		encoder, err = zstd.NewWriter(nil)
+		require.NoError(t, err)
+	}
+
+	var data []byte
+	for _, msg := range messages {
+		if encoder != nil {
+			msg = encoder.EncodeAll(msg, nil)
+		}
+		size := make([]byte, 4)
+		binary.BigEndian.PutUint32(size, uint32(len(msg)))
+		data = append(data, size...)
+		data = append(data, msg...)
+	}
+	return data
+}
+
+func TestFileFramedReceiver(t *testing.T) {
+	tests := []struct {
+		format      string
+		compression string
+	}{
+		{format: formatProto},
+		{format: formatProto, compression: compressionZSTD},
+		{format: formatJSON, compression: compressionZSTD},
+	}
+	for _, tt := range tests {
+		t.Run(tt.format+"_"+tt.compression, func(t *testing.T) {
+			tempFolder := t.TempDir()
+			factory := NewFactory()
+			cfg := createDefaultConfig().(*Config)
+			cfg.Config.Include = []string{filepath.Join(tempFolder, "*")}
+			cfg.Config.StartAt = "beginning"
+			cfg.Format = tt.format
+			cfg.Compression = tt.compression
+			require.NoError(t, cfg.Validate())
+			sink := new(consumertest.MetricsSink)
+			receiver, err := factory.CreateMetricsReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+			require.NoError(t, err)
+			require.NoError(t, receiver.Start(context.Background(), nil))
+
+			var marshaler pmetric.Marshaler = &pmetric.JSONMarshaler{}
+			if tt.format == formatProto {
+				marshaler = &pmetric.ProtoMarshaler{}
+			}
+			md1 := testdata.GenerateMetricsManyMetricsSameResource(5)
+			md2 := testdata.GenerateMetricsOneCounterOneSummaryMetrics()
+			b1, err := marshaler.MarshalMetrics(md1)
+			require.NoError(t, err)
+			b2, err := marshaler.MarshalMetrics(md2)
+			require.NoError(t, err)
+
+			// the second message is incomplete, as if it was being written
+			data := frameMessages(t, tt.compression, b1, b2)
+			path := filepath.Join(tempFolder, "metrics.bin")
+			require.NoError(t, os.WriteFile(path, data[:len(data)-10], 0600))
+			time.Sleep(1 * time.Second)
+			require.Len(t, sink.AllMetrics(), 1)
+			assert.EqualValues(t, md1, sink.AllMetrics()[0])
+
+			f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0600)
+			require.NoError(t, err)
+			_, err = f.Write(data[len(data)-10:])
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			time.Sleep(1 * time.Second)
+			require.Len(t, sink.AllMetrics(), 2)
+			assert.EqualValues(t, md2, sink.AllMetrics()[1])
+
+			assert.NoError(t, receiver.Shutdown(context.Background()))
+		})
+	}
+}
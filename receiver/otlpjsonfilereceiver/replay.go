@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"time"
+
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+// now is the time the timestamps are shifted to, replaced in tests.
+var now = time.Now
+
+// timestamps is a visitor of the timestamps of a message.
+type timestamps func(ts pcommon.Timestamp) pcommon.Timestamp
+
+// shift calls visit twice: a first time to find the most recent timestamp, then to shift all
+// the timestamps so that it becomes t. The unset timestamps are left as they are.
+func shift(t time.Time, visit func(timestamps)) {
+	var latest pcommon.Timestamp
+	visit(func(ts pcommon.Timestamp) pcommon.Timestamp {
+		if ts > latest {
+			latest = ts
+		}
+		return ts
+	})
+	if latest == 0 {
+		return
+	}
+
+	delta := pcommon.NewTimestampFromTime(t) - latest
+	visit(func(ts pcommon.Timestamp) pcommon.Timestamp {
+		if ts == 0 {
+			return ts
+		}
+		return ts + delta
+	})
+}
+
+func shiftLogsTimestamps(ld plog.Logs, t time.Time) {
+	shift(t, func(visit timestamps) {
+		rls := ld.ResourceLogs()
+		for i := 0; i < rls.Len(); i++ {
+			sls := rls.At(i).ScopeLogs()
+			for j := 0; j < sls.Len(); j++ {
+				lrs := sls.At(j).LogRecords()
+				for k := 0; k < lrs.Len(); k++ {
+					lr := lrs.At(k)
+					lr.SetTimestamp(visit(lr.Timestamp()))
+					lr.SetObservedTimestamp(visit(lr.ObservedTimestamp()))
+				}
+			}
+		}
+	})
+}
+
+func shiftTracesTimestamps(td ptrace.Traces, t time.Time) {
+	shift(t, func(visit timestamps) {
+		rss := td.ResourceSpans()
+		for i := 0; i < rss.Len(); i++ {
+			sss := rss.At(i).ScopeSpans()
+			for j := 0; j < sss.Len(); j++ {
+				spans := sss.At(j).Spans()
+				for k := 0; k < spans.Len(); k++ {
+					span := spans.At(k)
+					span.SetStartTimestamp(visit(span.StartTimestamp()))
+					span.SetEndTimestamp(visit(span.EndTimestamp()))
+					events := span.Events()
+					for l := 0; l < events.Len(); l++ {
+						events.At(l).SetTimestamp(visit(events.At(l).Timestamp()))
+					}
+				}
+			}
+		}
+	})
+}
+
+func shiftMetricsTimestamps(md pmetric.Metrics, t time.Time) {
+	shift(t, func(visit timestamps) {
+		rms := md.ResourceMetrics()
+		for i := 0; i < rms.Len(); i++ {
+			sms := rms.At(i).ScopeMetrics()
+			for j := 0; j < sms.Len(); j++ {
+				metrics := sms.At(j).Metrics()
+				for k := 0; k < metrics.Len(); k++ {
+					visitMetricTimestamps(metrics.At(k), visit)
+				}
+			}
+		}
+	})
+}
+
+func visitMetricTimestamps(m pmetric.Metric, visit timestamps) {
+	switch m.Type() {
+	case pmetric.MetricTypeGauge:
+		visitNumberDataPointsTimestamps(m.Gauge().DataPoints(), visit)
+	case pmetric.MetricTypeSum:
+		visitNumberDataPointsTimestamps(m.Sum().DataPoints(), visit)
+	case pmetric.MetricTypeHistogram:
+		dps := m.Histogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(visit(dp.StartTimestamp()))
+			dp.SetTimestamp(visit(dp.Timestamp()))
+			visitExemplarsTimestamps(dp.Exemplars(), visit)
+		}
+	case pmetric.MetricTypeExponentialHistogram:
+		dps := m.ExponentialHistogram().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(visit(dp.StartTimestamp()))
+			dp.SetTimestamp(visit(dp.Timestamp()))
+			visitExemplarsTimestamps(dp.Exemplars(), visit)
+		}
+	case pmetric.MetricTypeSummary:
+		dps := m.Summary().DataPoints()
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			dp.SetStartTimestamp(visit(dp.StartTimestamp()))
+			dp.SetTimestamp(visit(dp.Timestamp()))
+		}
+	}
+}
+
+func visitNumberDataPointsTimestamps(dps pmetric.NumberDataPointSlice, visit timestamps) {
+	for i := 0; i < dps.Len(); i++ {
+		dp := dps.At(i)
+		dp.SetStartTimestamp(visit(dp.StartTimestamp()))
+		dp.SetTimestamp(visit(dp.Timestamp()))
+		visitExemplarsTimestamps(dp.Exemplars(), visit)
+	}
+}
+
+func visitExemplarsTimestamps(exemplars pmetric.ExemplarSlice, visit timestamps) {
+	for i := 0; i < exemplars.Len(); i++ {
+		exemplars.At(i).SetTimestamp(visit(exemplars.At(i).Timestamp()))
+	}
+}
@@ -0,0 +1,139 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package otlpjsonfilereceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/otlpjsonfilereceiver"
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/ptrace"
+)
+
+var (
+	replayStart = time.Date(2022, 12, 1, 10, 0, 0, 0, time.UTC)
+	replayNowTS = time.Date(2023, 1, 10, 12, 0, 0, 0, time.UTC)
+)
+
+func timestampAt(offset time.Duration) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(replayStart.Add(offset))
+}
+
+func nowAt(offset time.Duration) pcommon.Timestamp {
+	return pcommon.NewTimestampFromTime(replayNowTS.Add(offset))
+}
+
+func TestShiftLogsTimestamps(t *testing.T) {
+	ld := plog.NewLogs()
+	lrs := ld.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	lr := lrs.AppendEmpty()
+	lr.SetTimestamp(timestampAt(0))
+	lr.SetObservedTimestamp(timestampAt(2 * time.Second))
+	unset := lrs.AppendEmpty()
+	unset.SetObservedTimestamp(timestampAt(time.Second))
+
+	shiftLogsTimestamps(ld, replayNowTS)
+
+	assert.Equal(t, nowAt(-2*time.Second), lr.Timestamp())
+	assert.Equal(t, nowAt(0), lr.ObservedTimestamp())
+	assert.Equal(t, pcommon.Timestamp(0), unset.Timestamp())
+	assert.Equal(t, nowAt(-time.Second), unset.ObservedTimestamp())
+}
+
+func TestShiftTracesTimestamps(t *testing.T) {
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetStartTimestamp(timestampAt(0))
+	span.SetEndTimestamp(timestampAt(3 * time.Second))
+	event := span.Events().AppendEmpty()
+	event.SetTimestamp(timestampAt(time.Second))
+
+	shiftTracesTimestamps(td, replayNowTS)
+
+	assert.Equal(t, nowAt(-3*time.Second), span.StartTimestamp())
+	assert.Equal(t, nowAt(0), span.EndTimestamp())
+	assert.Equal(t, nowAt(-2*time.Second), event.Timestamp())
+}
+
+func TestShiftMetricsTimestamps(t *testing.T) {
+	md := pmetric.NewMetrics()
+	metrics := md.ResourceMetrics().AppendEmpty().ScopeMetrics().AppendEmpty().Metrics()
+
+	sum := metrics.AppendEmpty().SetEmptySum().DataPoints().AppendEmpty()
+	sum.SetStartTimestamp(timestampAt(0))
+	sum.SetTimestamp(timestampAt(10 * time.Second))
+	exemplar := sum.Exemplars().AppendEmpty()
+	exemplar.SetTimestamp(timestampAt(5 * time.Second))
+
+	gauge := metrics.AppendEmpty().SetEmptyGauge().DataPoints().AppendEmpty()
+	gauge.SetTimestamp(timestampAt(10 * time.Second))
+
+	histogram := metrics.AppendEmpty().SetEmptyHistogram().DataPoints().AppendEmpty()
+	histogram.SetStartTimestamp(timestampAt(0))
+	histogram.SetTimestamp(timestampAt(20 * time.Second))
+
+	expHistogram := metrics.AppendEmpty().SetEmptyExponentialHistogram().DataPoints().AppendEmpty()
+	expHistogram.SetStartTimestamp(timestampAt(0))
+	expHistogram.SetTimestamp(timestampAt(20 * time.Second))
+
+	summary := metrics.AppendEmpty().SetEmptySummary().DataPoints().AppendEmpty()
+	summary.SetStartTimestamp(timestampAt(0))
+	summary.SetTimestamp(timestampAt(15 * time.Second))
+
+	shiftMetricsTimestamps(md, replayNowTS)
+
+	assert.Equal(t, nowAt(-20*time.Second), sum.StartTimestamp())
+	assert.Equal(t, nowAt(-10*time.Second), sum.Timestamp())
+	assert.Equal(t, nowAt(-15*time.Second), exemplar.Timestamp())
+	assert.Equal(t, pcommon.Timestamp(0), gauge.StartTimestamp())
+	assert.Equal(t, nowAt(-10*time.Second), gauge.Timestamp())
+	assert.Equal(t, nowAt(-20*time.Second), histogram.StartTimestamp())
+	assert.Equal(t, nowAt(0), histogram.Timestamp())
+	assert.Equal(t, nowAt(-20*time.Second), expHistogram.StartTimestamp())
+	assert.Equal(t, nowAt(0), expHistogram.Timestamp())
+	assert.Equal(t, nowAt(-20*time.Second), summary.StartTimestamp())
+	assert.Equal(t, nowAt(-5*time.Second), summary.Timestamp())
+}
+
+func TestUnmarshalReplayNow(t *testing.T) {
+	now = func() time.Time { return replayNowTS }
+	defer func() { now = time.Now }()
+
+	td := ptrace.NewTraces()
+	span := td.ResourceSpans().AppendEmpty().ScopeSpans().AppendEmpty().Spans().AppendEmpty()
+	span.SetStartTimestamp(timestampAt(0))
+	span.SetEndTimestamp(timestampAt(time.Second))
+	buf, err := (&ptrace.ProtoMarshaler{}).MarshalTraces(td)
+	require.NoError(t, err)
+
+	cfg := createDefaultConfig().(*Config)
+	cfg.Format = formatProto
+	unmarshalTraces := cfg.unmarshalTraces()
+
+	replayed, err := unmarshalTraces(buf)
+	require.NoError(t, err)
+	assert.Equal(t, td, replayed)
+
+	cfg.Replay = replayNow
+	replayed, err = unmarshalTraces(buf)
+	require.NoError(t, err)
+	replayedSpan := replayed.ResourceSpans().At(0).ScopeSpans().At(0).Spans().At(0)
+	assert.Equal(t, nowAt(-time.Second), replayedSpan.StartTimestamp())
+	assert.Equal(t, nowAt(0), replayedSpan.EndTimestamp())
+}
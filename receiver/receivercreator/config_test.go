@@ -110,6 +110,9 @@ func TestLoadConfig(t *testing.T) {
 					observer.PortType:      {"port.key": "port.value"},
 					observer.HostPortType:  {"hostport.key": "hostport.value"},
 					observer.K8sNodeType:   {"k8s.node.key": "k8s.node.value"},
+					// Defaults of the endpoint types not in the configuration are kept.
+					observer.K8sServiceType: {"k8s.namespace.name": "`namespace`"},
+					observer.K8sIngressType: {"k8s.namespace.name": "`namespace`"},
 				},
 			},
 		},
@@ -103,6 +103,38 @@ var k8sNodeEndpoint = observer.Endpoint{
 	},
 }
 
+var k8sServiceEndpoint = observer.Endpoint{
+	ID:     "k8s.service-1",
+	Target: "10.0.0.10:80",
+	Details: &observer.K8sService{
+		Annotations: map[string]string{"prometheus.io/probe": "true"},
+		Labels:      map[string]string{"app": "web"},
+		Name:        "web",
+		Namespace:   "default",
+		UID:         "service-uid",
+		ServiceType: "ClusterIP",
+		ClusterIP:   "10.0.0.10",
+		PortName:    "http",
+		Port:        80,
+		Transport:   observer.ProtocolTCP,
+	},
+}
+
+var k8sIngressEndpoint = observer.Endpoint{
+	ID:     "k8s.ingress-1",
+	Target: "https://example.com/",
+	Details: &observer.K8sIngress{
+		Annotations: map[string]string{"probe": "true"},
+		Labels:      map[string]string{"app": "web"},
+		Name:        "web",
+		Namespace:   "default",
+		UID:         "ingress-uid",
+		Scheme:      "https",
+		Host:        "example.com",
+		Path:        "/",
+	},
+}
+
 var unsupportedEndpoint = observer.Endpoint{
 	ID:      "endpoint-1",
 	Target:  "localhost:1234",
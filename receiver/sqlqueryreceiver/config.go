@@ -29,6 +29,13 @@ type Config struct {
 	Driver                                  string  `mapstructure:"driver"`
 	DataSource                              string  `mapstructure:"datasource"`
 	Queries                                 []Query `mapstructure:"queries"`
+	// StorageID is the ID of the storage extension persisting the tracking values of the queries emitting logs.
+	StorageID *component.ID `mapstructure:"storage"`
+	// MaxOpenConns is the maximum number of open connections to the database, and so the maximum number
+	// of queries running concurrently. Zero means unlimited.
+	MaxOpenConns int `mapstructure:"max_open_conns"`
+	// ConnMaxLifetime is the maximum amount of time a connection may be reused. Zero means unlimited.
+	ConnMaxLifetime time.Duration `mapstructure:"conn_max_lifetime"`
 }
 
 func (c Config) Validate() error {
@@ -41,6 +48,12 @@ func (c Config) Validate() error {
 	if len(c.Queries) == 0 {
 		return errors.New("'queries' cannot be empty")
 	}
+	if c.MaxOpenConns < 0 {
+		return errors.New("'max_open_conns' cannot be negative")
+	}
+	if c.ConnMaxLifetime < 0 {
+		return errors.New("'conn_max_lifetime' cannot be negative")
+	}
 	for _, query := range c.Queries {
 		if err := query.Validate(); err != nil {
 			return err
@@ -52,6 +65,17 @@ func (c Config) Validate() error {
 type Query struct {
 	SQL     string      `mapstructure:"sql"`
 	Metrics []MetricCfg `mapstructure:"metrics"`
+	Logs    []LogsCfg   `mapstructure:"logs"`
+	// TrackingColumn is the column of the last emitted row whose value is passed as the parameter of the
+	// next execution of the query, so that only the newer rows are returned.
+	TrackingColumn string `mapstructure:"tracking_column"`
+	// TrackingStartValue is the parameter of the query until a row has been emitted.
+	TrackingStartValue string `mapstructure:"tracking_start_value"`
+	// CollectionInterval is the interval at which the query is executed. It defaults to the
+	// collection_interval of the receiver.
+	CollectionInterval time.Duration `mapstructure:"collection_interval"`
+	// Timeout is the maximum duration of an execution of the query. Zero means no timeout.
+	Timeout time.Duration `mapstructure:"timeout"`
 }
 
 func (q Query) Validate() error {
@@ -59,17 +83,46 @@ func (q Query) Validate() error {
 	if q.SQL == "" {
 		errs = multierr.Append(errs, errors.New("'query.sql' cannot be empty"))
 	}
-	if len(q.Metrics) == 0 {
-		errs = multierr.Append(errs, errors.New("'query.metrics' cannot be empty"))
+	if len(q.Metrics) == 0 && len(q.Logs) == 0 {
+		errs = multierr.Append(errs, errors.New("at least one of 'query.metrics' and 'query.logs' must be specified"))
 	}
 	for _, metric := range q.Metrics {
 		if err := metric.Validate(); err != nil {
 			errs = multierr.Append(errs, err)
 		}
 	}
+	for _, logs := range q.Logs {
+		if err := logs.Validate(); err != nil {
+			errs = multierr.Append(errs, err)
+		}
+	}
+	if q.TrackingColumn != "" && len(q.Metrics) > 0 {
+		errs = multierr.Append(errs, errors.New("'query.tracking_column' is not supported with 'query.metrics'"))
+	}
+	if q.TrackingStartValue != "" && q.TrackingColumn == "" {
+		errs = multierr.Append(errs, errors.New("'query.tracking_start_value' requires 'query.tracking_column'"))
+	}
+	if q.CollectionInterval < 0 {
+		errs = multierr.Append(errs, errors.New("'query.collection_interval' cannot be negative"))
+	}
+	if q.Timeout < 0 {
+		errs = multierr.Append(errs, errors.New("'query.timeout' cannot be negative"))
+	}
 	return errs
 }
 
+type LogsCfg struct {
+	BodyColumn       string   `mapstructure:"body_column"`
+	AttributeColumns []string `mapstructure:"attribute_columns"`
+}
+
+func (c LogsCfg) Validate() error {
+	if c.BodyColumn == "" {
+		return errors.New("'body_column' cannot be empty")
+	}
+	return nil
+}
+
 type MetricCfg struct {
 	MetricName       string            `mapstructure:"metric_name"`
 	ValueColumn      string            `mapstructure:"value_column"`
@@ -157,6 +210,14 @@ func (a MetricAggregation) Validate() error {
 	return fmt.Errorf("metric config has unsupported aggregation: '%s'", a)
 }
 
+// collectionInterval returns the interval at which the query is executed.
+func (q Query) collectionInterval(defaultInterval time.Duration) time.Duration {
+	if q.CollectionInterval > 0 {
+		return q.CollectionInterval
+	}
+	return defaultInterval
+}
+
 func createDefaultConfig() component.Config {
 	return &Config{
 		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
@@ -29,6 +29,8 @@ import (
 func TestLoadConfig(t *testing.T) {
 	t.Parallel()
 
+	storageID := component.NewID("file_storage")
+
 	tests := []struct {
 		fname        string
 		id           component.ID
@@ -63,6 +65,57 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id:    component.NewIDWithName(typeStr, ""),
+			fname: "config-logs.yaml",
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: 10 * time.Second,
+				},
+				Driver:     "mydriver",
+				DataSource: "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+				StorageID:  &storageID,
+				Queries: []Query{
+					{
+						SQL:                "select id, message, username from audit where id > ? order by id",
+						TrackingColumn:     "id",
+						TrackingStartValue: "100",
+						Logs: []LogsCfg{
+							{
+								BodyColumn:       "message",
+								AttributeColumns: []string{"username"},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			id:    component.NewIDWithName(typeStr, ""),
+			fname: "config-scheduling.yaml",
+			expected: &Config{
+				ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+					CollectionInterval: 10 * time.Second,
+				},
+				Driver:          "mydriver",
+				DataSource:      "host=localhost port=5432 user=me password=s3cr3t sslmode=disable",
+				MaxOpenConns:    2,
+				ConnMaxLifetime: 5 * time.Minute,
+				Queries: []Query{
+					{
+						SQL:                "select count(*) as count from mytable",
+						CollectionInterval: time.Minute,
+						Timeout:            20 * time.Second,
+						Metrics: []MetricCfg{
+							{
+								MetricName:  "val.count",
+								ValueColumn: "count",
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			fname:        "config-invalid-datatype.yaml",
 			id:           component.NewIDWithName(typeStr, ""),
@@ -106,7 +159,22 @@ func TestLoadConfig(t *testing.T) {
 		{
 			fname:        "config-invalid-missing-metrics.yaml",
 			id:           component.NewIDWithName(typeStr, ""),
-			errorMessage: "'query.metrics' cannot be empty",
+			errorMessage: "at least one of 'query.metrics' and 'query.logs' must be specified",
+		},
+		{
+			fname:        "config-invalid-missing-bodycolumn.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'body_column' cannot be empty",
+		},
+		{
+			fname:        "config-invalid-tracking-column-with-metrics.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'query.tracking_column' is not supported with 'query.metrics'",
+		},
+		{
+			fname:        "config-invalid-negative-timeout.yaml",
+			id:           component.NewIDWithName(typeStr, ""),
+			errorMessage: "'query.timeout' cannot be negative",
 		},
 		{
 			fname:        "config-invalid-missing-datasource.yaml",
@@ -147,6 +215,11 @@ func TestCreateDefaultConfig(t *testing.T) {
 	assert.Equal(t, 10*time.Second, cfg.ScraperControllerSettings.CollectionInterval)
 }
 
+func TestQueryCollectionInterval(t *testing.T) {
+	assert.Equal(t, 10*time.Second, Query{}.collectionInterval(10*time.Second))
+	assert.Equal(t, time.Minute, Query{CollectionInterval: time.Minute}.collectionInterval(10*time.Second))
+}
+
 func TestConfig_Validate_Multierr(t *testing.T) {
 	cm, err := confmaptest.LoadConf(filepath.Join("testdata", "config-invalid-multierr.yaml"))
 	require.NoError(t, err)
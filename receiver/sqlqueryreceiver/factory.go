@@ -17,6 +17,7 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 import (
 	"database/sql"
 
+	"go.opencensus.io/stats/view"
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/receiver"
 )
@@ -27,9 +28,12 @@ const (
 )
 
 func NewFactory() receiver.Factory {
+	_ = view.Register(MetricViews()...)
+
 	return receiver.NewFactory(
 		typeStr,
 		createDefaultConfig,
 		receiver.WithMetrics(createReceiverFunc(sql.Open, newDbClient), stability),
+		receiver.WithLogs(createLogsReceiverFunc(sql.Open, newDbClient), stability),
 	)
 }
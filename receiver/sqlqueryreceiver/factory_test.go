@@ -33,3 +33,14 @@ func TestNewFactory(t *testing.T) {
 	)
 	require.NoError(t, err)
 }
+
+func TestNewFactoryLogs(t *testing.T) {
+	factory := NewFactory()
+	_, err := factory.CreateLogsReceiver(
+		context.Background(),
+		receivertest.NewNopCreateSettings(),
+		factory.CreateDefaultConfig(),
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+}
@@ -0,0 +1,255 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/consumer"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver"
+	"go.uber.org/multierr"
+	"go.uber.org/zap"
+)
+
+const trackingValueKeyPrefix = "last_tracking_value:"
+
+func createLogsReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientProviderFunc) receiver.CreateLogsFunc {
+	return func(
+		ctx context.Context,
+		settings receiver.CreateSettings,
+		cfg component.Config,
+		consumer consumer.Logs,
+	) (receiver.Logs, error) {
+		sqlCfg := cfg.(*Config)
+		return &logsReceiver{
+			id:                 settings.ID,
+			config:             sqlCfg,
+			logger:             settings.TelemetrySettings.Logger,
+			pool:               acquireDBPool(sqlCfg, openDBFunc(sqlOpenerFunc, sqlCfg)),
+			clientProviderFunc: clientProviderFunc,
+			nextConsumer:       consumer,
+		}, nil
+	}
+}
+
+// logsReceiver runs the queries emitting logs at every collection interval.
+type logsReceiver struct {
+	id                 component.ID
+	config             *Config
+	logger             *zap.Logger
+	clientProviderFunc clientProviderFunc
+	nextConsumer       consumer.Logs
+	// pool is shared with the metrics receiver created from the same config
+	pool *dbPool
+
+	storageClient  storage.Client
+	queryReceivers []*logsQueryReceiver
+	cancel         context.CancelFunc
+	wg             sync.WaitGroup
+}
+
+func (r *logsReceiver) Start(ctx context.Context, host component.Host) error {
+	var err error
+	r.storageClient, err = getStorageClient(ctx, host, r.config.StorageID, r.id)
+	if err != nil {
+		return fmt.Errorf("failed to get storage client: %w", err)
+	}
+
+	db, err := r.pool.get()
+	if err != nil {
+		return fmt.Errorf("failed to open db connection: %w", err)
+	}
+
+	for i, query := range r.config.Queries {
+		if len(query.Logs) == 0 {
+			continue
+		}
+		qr := &logsQueryReceiver{
+			query:         query,
+			client:        r.clientProviderFunc(db, query.SQL, r.logger),
+			storageClient: r.storageClient,
+			trackingValue: query.TrackingStartValue,
+			telemetry:     newQueryTelemetry(r.id, i),
+		}
+		if err = qr.loadTrackingValue(ctx); err != nil {
+			return err
+		}
+		r.queryReceivers = append(r.queryReceivers, qr)
+	}
+
+	var collectCtx context.Context
+	collectCtx, r.cancel = context.WithCancel(context.Background())
+	// each query is collected by its own goroutine, so that a slow query doesn't delay the others
+	for _, qr := range r.queryReceivers {
+		r.wg.Add(1)
+		go r.run(collectCtx, qr)
+	}
+	return nil
+}
+
+func (r *logsReceiver) run(ctx context.Context, qr *logsQueryReceiver) {
+	defer r.wg.Done()
+
+	ticker := time.NewTicker(qr.query.collectionInterval(r.config.CollectionInterval))
+	defer ticker.Stop()
+
+	r.collect(ctx, qr)
+	for {
+		select {
+		case <-ticker.C:
+			r.collect(ctx, qr)
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (r *logsReceiver) collect(ctx context.Context, qr *logsQueryReceiver) {
+	logs, trackingValue, err := qr.collect(ctx)
+	if err != nil {
+		r.logger.Error("Error collecting logs", zap.String("query", qr.query.SQL), zap.Error(err))
+	}
+	if logs.LogRecordCount() == 0 {
+		return
+	}
+	if err = r.nextConsumer.ConsumeLogs(ctx, logs); err != nil {
+		// the tracking value isn't updated, the rows will be emitted again by the next collection
+		r.logger.Error("Error consuming logs", zap.String("query", qr.query.SQL), zap.Error(err))
+		return
+	}
+	if err = qr.storeTrackingValue(ctx, trackingValue); err != nil {
+		r.logger.Error("Error storing the tracking value", zap.String("query", qr.query.SQL), zap.Error(err))
+	}
+}
+
+func (r *logsReceiver) Shutdown(ctx context.Context) error {
+	if r.cancel != nil {
+		r.cancel()
+	}
+	r.wg.Wait()
+
+	errs := r.pool.release()
+	if r.storageClient != nil {
+		errs = multierr.Append(errs, r.storageClient.Close(ctx))
+	}
+	return errs
+}
+
+// logsQueryReceiver turns the rows returned by a query into log records. When the query has a tracking
+// column, the value of this column in the last emitted row is passed as the parameter of the query.
+type logsQueryReceiver struct {
+	query         Query
+	client        dbClient
+	storageClient storage.Client
+	trackingValue string
+	telemetry     queryTelemetry
+}
+
+// collect returns the logs built from the rows returned by the query, along with the tracking value to
+// store once the logs have been consumed.
+func (qr *logsQueryReceiver) collect(ctx context.Context) (logs plog.Logs, trackingValue string, err error) {
+	if qr.query.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, qr.query.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	defer func() {
+		qr.telemetry.record(time.Since(start), err)
+	}()
+
+	logs = plog.NewLogs()
+
+	var args []interface{}
+	if qr.query.TrackingColumn != "" {
+		args = append(args, qr.trackingValue)
+	}
+	rows, err := qr.client.queryRows(ctx, args...)
+	if err != nil {
+		return logs, qr.trackingValue, fmt.Errorf("logsQueryReceiver: %w", err)
+	}
+
+	ts := pcommon.NewTimestampFromTime(time.Now())
+	records := logs.ResourceLogs().AppendEmpty().ScopeLogs().AppendEmpty().LogRecords()
+	var errs error
+	for i, row := range rows {
+		for _, logsCfg := range qr.query.Logs {
+			if err = rowToLog(row, logsCfg, records.AppendEmpty(), ts); err != nil {
+				errs = multierr.Append(errs, fmt.Errorf("row %d: %w", i, err))
+			}
+		}
+	}
+
+	trackingValue = qr.trackingValue
+	if qr.query.TrackingColumn != "" && len(rows) > 0 {
+		value, found := rows[len(rows)-1][qr.query.TrackingColumn]
+		if !found {
+			return plog.NewLogs(), qr.trackingValue, fmt.Errorf("logsQueryReceiver: tracking_column '%s' not found in result set", qr.query.TrackingColumn)
+		}
+		trackingValue = value
+	}
+
+	if errs != nil {
+		errs = fmt.Errorf("logsQueryReceiver row conversion errors: %w", errs)
+	}
+	return logs, trackingValue, errs
+}
+
+func (qr *logsQueryReceiver) loadTrackingValue(ctx context.Context) error {
+	if qr.query.TrackingColumn == "" {
+		return nil
+	}
+	value, err := qr.storageClient.Get(ctx, trackingValueKeyPrefix+qr.query.SQL)
+	if err != nil {
+		return fmt.Errorf("failed to read the tracking value from storage: %w", err)
+	}
+	if value != nil {
+		qr.trackingValue = string(value)
+	}
+	return nil
+}
+
+func (qr *logsQueryReceiver) storeTrackingValue(ctx context.Context, value string) error {
+	if qr.query.TrackingColumn == "" || value == qr.trackingValue {
+		return nil
+	}
+	qr.trackingValue = value
+	return qr.storageClient.Set(ctx, trackingValueKeyPrefix+qr.query.SQL, []byte(value))
+}
+
+func getStorageClient(ctx context.Context, host component.Host, storageID *component.ID, id component.ID) (storage.Client, error) {
+	if storageID == nil {
+		return storage.NewNopClient(), nil
+	}
+
+	ext, ok := host.GetExtensions()[*storageID]
+	if !ok {
+		return nil, fmt.Errorf("storage extension '%s' not found", storageID)
+	}
+
+	storageExt, ok := ext.(storage.Extension)
+	if !ok {
+		return nil, fmt.Errorf("non-storage extension '%s' found", storageID)
+	}
+
+	return storageExt.GetClient(ctx, component.KindReceiver, id, "")
+}
@@ -0,0 +1,258 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/component"
+	"go.opentelemetry.io/collector/component/componenttest"
+	"go.opentelemetry.io/collector/consumer/consumertest"
+	"go.opentelemetry.io/collector/extension/experimental/storage"
+	"go.opentelemetry.io/collector/pdata/plog"
+	"go.opentelemetry.io/collector/receiver/receivertest"
+	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/zap"
+)
+
+func TestLogsQueryReceiverCollect(t *testing.T) {
+	client := &fakeDBClient{responses: [][]stringMap{
+		{
+			{"id": "101", "message": "login", "username": "jane"},
+			{"id": "102", "message": "logout", "username": "jane"},
+		},
+		{},
+	}}
+	qr := &logsQueryReceiver{
+		query: Query{
+			SQL:                "select * from audit where id > ? order by id",
+			TrackingColumn:     "id",
+			TrackingStartValue: "100",
+			Logs: []LogsCfg{{
+				BodyColumn:       "message",
+				AttributeColumns: []string{"username"},
+			}},
+		},
+		client:        client,
+		storageClient: newMemoryStorageClient(),
+		trackingValue: "100",
+	}
+
+	logs, trackingValue, err := qr.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "102", trackingValue)
+	require.Equal(t, 2, logs.LogRecordCount())
+	record := logs.ResourceLogs().At(0).ScopeLogs().At(0).LogRecords().At(1)
+	assert.Equal(t, "logout", record.Body().Str())
+	assert.Equal(t, map[string]interface{}{"username": "jane"}, record.Attributes().AsRaw())
+	assert.NotZero(t, record.ObservedTimestamp())
+
+	require.NoError(t, qr.storeTrackingValue(context.Background(), trackingValue))
+	logs, trackingValue, err = qr.collect(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 0, logs.LogRecordCount())
+	assert.Equal(t, "102", trackingValue)
+
+	assert.Equal(t, [][]interface{}{{"100"}, {"102"}}, client.args)
+}
+
+func TestLogsQueryReceiverCollectErrors(t *testing.T) {
+	qr := &logsQueryReceiver{
+		query: Query{
+			SQL:            "select * from audit where id > ?",
+			TrackingColumn: "id",
+			Logs:           []LogsCfg{{BodyColumn: "message"}},
+		},
+		client: &fakeDBClient{responses: [][]stringMap{
+			{{"id": "1", "msg": "login"}},
+			{{"message": "login"}},
+		}},
+	}
+
+	_, _, err := qr.collect(context.Background())
+	assert.EqualError(t, err, "logsQueryReceiver row conversion errors: row 0: rowToLog: body_column 'message' not found in result set")
+
+	logs, trackingValue, err := qr.collect(context.Background())
+	assert.EqualError(t, err, "logsQueryReceiver: tracking_column 'id' not found in result set")
+	assert.Equal(t, 0, logs.LogRecordCount())
+	assert.Equal(t, "", trackingValue)
+
+	qr.client = &fakeDBClient{err: errors.New("connection refused")}
+	_, _, err = qr.collect(context.Background())
+	assert.EqualError(t, err, "logsQueryReceiver: connection refused")
+}
+
+func TestLogsReceiverPersistsTrackingValue(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	host := &storageHost{Host: componenttest.NewNopHost(), extensions: map[component.ID]component.Component{
+		storageID: &memoryStorageExtension{client: newMemoryStorageClient()},
+	}}
+	cfg := &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			CollectionInterval: time.Minute,
+		},
+		Driver:     "mydriver",
+		DataSource: "my-datasource",
+		StorageID:  &storageID,
+		Queries: []Query{
+			{
+				SQL:     "select count(*) as count from audit",
+				Metrics: []MetricCfg{{MetricName: "audit.count", ValueColumn: "count"}},
+			},
+			{
+				SQL:                "select * from audit where id > ? order by id",
+				TrackingColumn:     "id",
+				TrackingStartValue: "0",
+				Logs:               []LogsCfg{{BodyColumn: "message"}},
+			},
+		},
+	}
+
+	clients := map[int]*fakeDBClient{}
+	createReceiver := createLogsReceiverFunc(fakeDBConnect, func(*sql.DB, string, *zap.Logger) dbClient {
+		client := &fakeDBClient{responses: [][]stringMap{{
+			{"id": "7", "message": "login"},
+			{"id": "8", "message": "logout"},
+		}}}
+		clients[len(clients)] = client
+		return client
+	})
+
+	// the first run emits the rows newer than the start value and stores the last id
+	sink := &consumertest.LogsSink{}
+	rcvr, err := createReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, sink)
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	require.Eventually(t, func() bool { return sink.LogRecordCount() == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	// the second run resumes from the stored id
+	rcvr, err = createReceiver(context.Background(), receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	require.NoError(t, rcvr.Start(context.Background(), host))
+	require.NoError(t, rcvr.Shutdown(context.Background()))
+
+	require.Len(t, clients, 2, "only the query with logs must be run")
+	assert.Equal(t, []interface{}{"0"}, clients[0].args[0])
+	assert.Equal(t, []interface{}{"8"}, clients[1].args[0])
+}
+
+func TestLogsReceiverConsumeError(t *testing.T) {
+	client := &fakeDBClient{responses: [][]stringMap{{{"id": "8", "message": "logout"}}}}
+	storageClient := newMemoryStorageClient()
+	r := &logsReceiver{
+		logger:        zap.NewNop(),
+		nextConsumer:  consumertest.NewErr(errors.New("queue is full")),
+		storageClient: storageClient,
+		queryReceivers: []*logsQueryReceiver{{
+			query: Query{
+				SQL:            "select * from audit where id > ? order by id",
+				TrackingColumn: "id",
+				Logs:           []LogsCfg{{BodyColumn: "message"}},
+			},
+			client:        client,
+			storageClient: storageClient,
+		}},
+	}
+
+	r.collect(context.Background(), r.queryReceivers[0])
+	assert.Equal(t, "", r.queryReceivers[0].trackingValue)
+	assert.Empty(t, storageClient.data)
+}
+
+func TestLogsReceiverMissingStorage(t *testing.T) {
+	storageID := component.NewID("file_storage")
+	cfg := createDefaultConfig().(*Config)
+	cfg.StorageID = &storageID
+
+	rcvr, err := createLogsReceiverFunc(fakeDBConnect, mkFakeClient)(context.Background(), receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	assert.EqualError(t, rcvr.Start(context.Background(), componenttest.NewNopHost()),
+		"failed to get storage client: storage extension 'file_storage' not found")
+}
+
+func TestRowToLog(t *testing.T) {
+	record := plog.NewLogRecord()
+	err := rowToLog(stringMap{"message": "login", "username": "jane"}, LogsCfg{
+		BodyColumn:       "message",
+		AttributeColumns: []string{"username", "ip"},
+	}, record, 0)
+	assert.EqualError(t, err, "rowToLog: attribute_column not found: 'ip'")
+}
+
+type storageHost struct {
+	component.Host
+	extensions map[component.ID]component.Component
+}
+
+func (h *storageHost) GetExtensions() map[component.ID]component.Component {
+	return h.extensions
+}
+
+type memoryStorageExtension struct {
+	component.StartFunc
+	component.ShutdownFunc
+	client *memoryStorageClient
+}
+
+func (e *memoryStorageExtension) GetClient(context.Context, component.Kind, component.ID, string) (storage.Client, error) {
+	return e.client, nil
+}
+
+type memoryStorageClient struct {
+	data map[string][]byte
+}
+
+func newMemoryStorageClient() *memoryStorageClient {
+	return &memoryStorageClient{data: map[string][]byte{}}
+}
+
+func (c *memoryStorageClient) Get(_ context.Context, key string) ([]byte, error) {
+	return c.data[key], nil
+}
+
+func (c *memoryStorageClient) Set(_ context.Context, key string, value []byte) error {
+	c.data[key] = value
+	return nil
+}
+
+func (c *memoryStorageClient) Delete(_ context.Context, key string) error {
+	delete(c.data, key)
+	return nil
+}
+
+func (c *memoryStorageClient) Batch(ctx context.Context, ops ...storage.Operation) error {
+	for _, op := range ops {
+		switch op.Type {
+		case storage.Get:
+			op.Value = c.data[op.Key]
+		case storage.Set:
+			c.data[op.Key] = op.Value
+		case storage.Delete:
+			delete(c.data, op.Key)
+		}
+	}
+	return nil
+}
+
+func (c *memoryStorageClient) Close(context.Context) error {
+	return nil
+}
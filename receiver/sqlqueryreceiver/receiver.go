@@ -18,11 +18,13 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"sync"
 
 	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/consumer"
 	"go.opentelemetry.io/collector/receiver"
 	"go.opentelemetry.io/collector/receiver/scraperhelper"
+	"go.uber.org/multierr"
 	"go.uber.org/zap"
 )
 
@@ -40,27 +42,145 @@ func createReceiverFunc(sqlOpenerFunc sqlOpenerFunc, clientProviderFunc clientPr
 		consumer consumer.Metrics,
 	) (receiver.Metrics, error) {
 		sqlCfg := cfg.(*Config)
-		var opts []scraperhelper.ScraperControllerOption
+		pool := acquireDBPool(sqlCfg, openDBFunc(sqlOpenerFunc, sqlCfg))
+		r := &metricsReceiver{pool: pool}
+		// each query has its own scraper controller, so that the queries are executed
+		// concurrently at their own interval, and don't fail each other's scrapes
 		for i, query := range sqlCfg.Queries {
+			if len(query.Metrics) == 0 {
+				continue
+			}
 			id := component.NewIDWithName("sqlqueryreceiver", fmt.Sprintf("query-%d: %s", i, query.SQL))
 			mp := &scraper{
-				id:        id,
-				query:     query,
-				scrapeCfg: sqlCfg.ScraperControllerSettings,
-				logger:    settings.TelemetrySettings.Logger,
-				dbProviderFunc: func() (*sql.DB, error) {
-					return sqlOpenerFunc(sqlCfg.Driver, sqlCfg.DataSource)
-				},
+				id:                 id,
+				query:              query,
+				scrapeCfg:          sqlCfg.ScraperControllerSettings,
+				logger:             settings.TelemetrySettings.Logger,
+				dbProviderFunc:     pool.get,
 				clientProviderFunc: clientProviderFunc,
+				telemetry:          newQueryTelemetry(settings.ID, i),
+			}
+			scrapeCfg := sqlCfg.ScraperControllerSettings
+			scrapeCfg.CollectionInterval = query.collectionInterval(sqlCfg.CollectionInterval)
+			controller, err := scraperhelper.NewScraperControllerReceiver(
+				&scrapeCfg,
+				settings,
+				consumer,
+				scraperhelper.AddScraper(mp),
+			)
+			if err != nil {
+				return nil, multierr.Append(err, pool.release())
 			}
-			opt := scraperhelper.AddScraper(mp)
-			opts = append(opts, opt)
+			r.controllers = append(r.controllers, controller)
+		}
+		return r, nil
+	}
+}
+
+// openDBFunc returns a dbProviderFunc opening the database with the connection pool settings of the config.
+func openDBFunc(sqlOpenerFunc sqlOpenerFunc, cfg *Config) dbProviderFunc {
+	return func() (*sql.DB, error) {
+		db, err := sqlOpenerFunc(cfg.Driver, cfg.DataSource)
+		if err != nil {
+			return nil, err
+		}
+		db.SetMaxOpenConns(cfg.MaxOpenConns)
+		db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+		return db, nil
+	}
+}
+
+// dbPools holds the databases shared by the metrics and logs receivers created from the same config, so that
+// max_open_conns bounds the connections of all the queries of a receiver whatever their signal.
+var dbPools = struct {
+	sync.Mutex
+	pools map[*Config]*dbPool
+}{pools: map[*Config]*dbPool{}}
+
+// acquireDBPool returns the pool of the config, which must be released once no longer used.
+func acquireDBPool(cfg *Config, open dbProviderFunc) *dbPool {
+	dbPools.Lock()
+	defer dbPools.Unlock()
+	pool, ok := dbPools.pools[cfg]
+	if !ok {
+		pool = &dbPool{cfg: cfg, open: open}
+		dbPools.pools[cfg] = pool
+	}
+	pool.refs++
+	return pool
+}
+
+// dbPool opens the database shared by the queries of a receiver on first use.
+type dbPool struct {
+	cfg  *Config
+	open dbProviderFunc
+	// refs is the number of receivers using the pool, guarded by dbPools.
+	refs int
+
+	mu sync.Mutex
+	db *sql.DB
+}
+
+func (p *dbPool) get() (*sql.DB, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.db == nil {
+		db, err := p.open()
+		if err != nil {
+			return nil, err
 		}
-		return scraperhelper.NewScraperControllerReceiver(
-			&sqlCfg.ScraperControllerSettings,
-			settings,
-			consumer,
-			opts...,
-		)
+		p.db = db
+	}
+	return p.db, nil
+}
+
+// release closes the database once the pool is no longer used by any receiver.
+func (p *dbPool) release() error {
+	dbPools.Lock()
+	p.refs--
+	unused := p.refs == 0
+	if unused {
+		delete(dbPools.pools, p.cfg)
+	}
+	dbPools.Unlock()
+	if !unused {
+		return nil
+	}
+	return p.close()
+}
+
+func (p *dbPool) close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.db == nil {
+		return nil
+	}
+	err := p.db.Close()
+	p.db = nil
+	return err
+}
+
+// metricsReceiver runs the scraper controllers of the queries emitting metrics.
+type metricsReceiver struct {
+	controllers []component.Component
+	pool        *dbPool
+}
+
+var _ receiver.Metrics = (*metricsReceiver)(nil)
+
+func (r *metricsReceiver) Start(ctx context.Context, host component.Host) error {
+	for _, controller := range r.controllers {
+		if err := controller.Start(ctx, host); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (r *metricsReceiver) Shutdown(ctx context.Context) error {
+	var errs error
+	for _, controller := range r.controllers {
+		errs = multierr.Append(errs, controller.Shutdown(ctx))
 	}
+	return multierr.Append(errs, r.pool.release())
 }
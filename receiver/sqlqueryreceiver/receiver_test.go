@@ -17,9 +17,12 @@ package sqlqueryreceiver
 import (
 	"context"
 	"database/sql"
+	"database/sql/driver"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/consumer/consumertest"
@@ -53,12 +56,102 @@ func TestCreateReceiver(t *testing.T) {
 	require.NoError(t, err)
 	err = receiver.Start(ctx, componenttest.NewNopHost())
 	require.NoError(t, err)
+	require.NoError(t, receiver.Shutdown(ctx))
+}
+
+func TestCreateReceiver_QueryPerController(t *testing.T) {
+	opened := 0
+	sqlOpener := func(driverName, dataSourceName string) (*sql.DB, error) {
+		opened++
+		return fakeDBConnect(driverName, dataSourceName)
+	}
+	createReceiver := createReceiverFunc(sqlOpener, mkFakeClient)
+	ctx := context.Background()
+	metric := MetricCfg{MetricName: "my-metric", ValueColumn: "my-column"}
+	rcvr, err := createReceiver(
+		ctx,
+		receivertest.NewNopCreateSettings(),
+		&Config{
+			ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+				CollectionInterval: 10 * time.Second,
+			},
+			Driver:       "mydriver",
+			DataSource:   "my-datasource",
+			MaxOpenConns: 2,
+			Queries: []Query{
+				{SQL: "select * from foo", Metrics: []MetricCfg{metric}},
+				{SQL: "select * from bar", Metrics: []MetricCfg{metric}, CollectionInterval: time.Minute},
+				{SQL: "select * from baz", Logs: []LogsCfg{{BodyColumn: "my-column"}}},
+			},
+		},
+		consumertest.NewNop(),
+	)
+	require.NoError(t, err)
+	assert.Len(t, rcvr.(*metricsReceiver).controllers, 2, "only the queries with metrics must be scraped")
+
+	require.NoError(t, rcvr.Start(ctx, componenttest.NewNopHost()))
+	db := rcvr.(*metricsReceiver).pool.db
+	require.NotNil(t, db)
+	assert.Equal(t, 2, db.Stats().MaxOpenConnections)
+	assert.Equal(t, 1, opened, "the queries must share the db")
+
+	require.NoError(t, rcvr.Shutdown(ctx))
+	assert.Nil(t, rcvr.(*metricsReceiver).pool.db)
+}
+
+func TestCreateReceiver_SharedDBAcrossSignals(t *testing.T) {
+	opened := 0
+	sqlOpener := func(driverName, dataSourceName string) (*sql.DB, error) {
+		opened++
+		return fakeDBConnect(driverName, dataSourceName)
+	}
+	ctx := context.Background()
+	cfg := &Config{
+		ScraperControllerSettings: scraperhelper.ScraperControllerSettings{
+			CollectionInterval: 10 * time.Second,
+		},
+		Driver:       "mydriver",
+		DataSource:   "my-datasource",
+		MaxOpenConns: 2,
+		Queries: []Query{
+			{SQL: "select * from foo", Metrics: []MetricCfg{{MetricName: "my-metric", ValueColumn: "my-column"}}},
+			{SQL: "select * from baz", Logs: []LogsCfg{{BodyColumn: "my-column"}}},
+		},
+	}
+	metricsRcvr, err := createReceiverFunc(sqlOpener, mkFakeClient)(ctx, receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+	logsRcvr, err := createLogsReceiverFunc(sqlOpener, mkFakeClient)(ctx, receivertest.NewNopCreateSettings(), cfg, consumertest.NewNop())
+	require.NoError(t, err)
+
+	pool := metricsRcvr.(*metricsReceiver).pool
+	assert.Same(t, pool, logsRcvr.(*logsReceiver).pool, "the signals must share the db")
+	require.NoError(t, metricsRcvr.Start(ctx, componenttest.NewNopHost()))
+	require.NoError(t, logsRcvr.Start(ctx, componenttest.NewNopHost()))
+	assert.Equal(t, 1, opened)
+
+	// the db is closed once both receivers are shut down
+	require.NoError(t, metricsRcvr.Shutdown(ctx))
+	assert.NotNil(t, pool.db)
+	require.NoError(t, logsRcvr.Shutdown(ctx))
+	assert.Nil(t, pool.db)
+	assert.NotContains(t, dbPools.pools, cfg)
 }
 
 func fakeDBConnect(string, string) (*sql.DB, error) {
-	return nil, nil
+	return sql.OpenDB(fakeConnector{}), nil
+}
+
+// fakeConnector backs the dbs of the tests, whose queries are run by fake clients.
+type fakeConnector struct{}
+
+func (fakeConnector) Connect(context.Context) (driver.Conn, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (fakeConnector) Driver() driver.Driver {
+	return nil
 }
 
 func mkFakeClient(db *sql.DB, s string, logger *zap.Logger) dbClient {
-	return &fakeDBClient{responses: [][]metricRow{{{"foo": "111"}}}}
+	return &fakeDBClient{responses: [][]stringMap{{{"foo": "111"}}}}
 }
@@ -16,7 +16,6 @@ package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-coll
 
 import (
 	"context"
-	"database/sql"
 	"fmt"
 	"time"
 
@@ -37,7 +36,7 @@ type scraper struct {
 	dbProviderFunc     dbProviderFunc
 	logger             *zap.Logger
 	client             dbClient
-	db                 *sql.DB
+	telemetry          queryTelemetry
 }
 
 var _ scraperhelper.Scraper = (*scraper)(nil)
@@ -47,20 +46,29 @@ func (s scraper) ID() component.ID {
 }
 
 func (s *scraper) Start(context.Context, component.Host) error {
-	var err error
-	s.db, err = s.dbProviderFunc()
+	db, err := s.dbProviderFunc()
 	if err != nil {
 		return fmt.Errorf("failed to open db connection: %w", err)
 	}
-	s.client = s.clientProviderFunc(s.db, s.query.SQL, s.logger)
+	s.client = s.clientProviderFunc(db, s.query.SQL, s.logger)
 	s.startTime = pcommon.NewTimestampFromTime(time.Now())
 
 	return nil
 }
 
-func (s scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
+func (s scraper) Scrape(ctx context.Context) (metrics pmetric.Metrics, err error) {
+	if s.query.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, s.query.Timeout)
+		defer cancel()
+	}
+	start := time.Now()
+	defer func() {
+		s.telemetry.record(time.Since(start), err)
+	}()
+
 	out := pmetric.NewMetrics()
-	rows, err := s.client.metricRows(ctx)
+	rows, err := s.client.queryRows(ctx)
 	ts := pcommon.NewTimestampFromTime(time.Now())
 	if err != nil {
 		return out, fmt.Errorf("scraper: %w", err)
@@ -85,6 +93,7 @@ func (s scraper) Scrape(ctx context.Context) (pmetric.Metrics, error) {
 	return out, errs
 }
 
+// Shutdown is a no-op, the db is shared by the scrapers and closed by the receiver.
 func (s scraper) Shutdown(ctx context.Context) error {
-	return s.db.Close()
+	return nil
 }
@@ -19,9 +19,13 @@ import (
 	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
 	"go.opentelemetry.io/collector/component/componenttest"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 )
@@ -47,9 +51,57 @@ func TestScraper_ClientErrorOnScrape(t *testing.T) {
 	require.Error(t, err)
 }
 
+func TestScraper_Timeout(t *testing.T) {
+	scrpr := scraper{
+		client: blockingDBClient{},
+		query: Query{
+			Timeout: 10 * time.Millisecond,
+		},
+	}
+	_, err := scrpr.Scrape(context.Background())
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestScraper_Telemetry(t *testing.T) {
+	views := MetricViews()
+	view.Unregister(views...)
+	require.NoError(t, view.Register(views...))
+	defer view.Unregister(views...)
+
+	id := component.NewID(typeStr)
+	scrpr := scraper{
+		client:    &fakeDBClient{err: errors.New("oops")},
+		telemetry: newQueryTelemetry(id, 1),
+	}
+	_, err := scrpr.Scrape(context.Background())
+	require.Error(t, err)
+
+	rows, err := view.RetrieveData(statQueryErrors.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.ElementsMatch(t, []tag.Tag{
+		{Key: tagReceiverKey, Value: id.String()},
+		{Key: tagQueryKey, Value: "query-1"},
+	}, rows[0].Tags)
+	assert.Equal(t, float64(1), rows[0].Data.(*view.SumData).Value)
+
+	rows, err = view.RetrieveData(statQueryDuration.Name())
+	require.NoError(t, err)
+	require.Len(t, rows, 1)
+	assert.Equal(t, int64(1), rows[0].Data.(*view.DistributionData).Count)
+}
+
+// blockingDBClient returns once the context of the query is done.
+type blockingDBClient struct{}
+
+func (blockingDBClient) queryRows(ctx context.Context, _ ...interface{}) ([]stringMap, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
 func TestScraper_RowToMetricErrorOnScrape_Float(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]stringMap{
 			{{"myfloat": "blah"}},
 		},
 	}
@@ -74,7 +126,7 @@ func TestScraper_RowToMetricErrorOnScrape_Float(t *testing.T) {
 
 func TestScraper_RowToMetricErrorOnScrape_Int(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]stringMap{
 			{{"myint": "blah"}},
 		},
 	}
@@ -99,7 +151,7 @@ func TestScraper_RowToMetricErrorOnScrape_Int(t *testing.T) {
 
 func TestScraper_RowToMetricMultiErrorsOnScrape(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{{
+		responses: [][]stringMap{{
 			{"myint": "foo"},
 			{"myint": "bar"},
 		}},
@@ -126,7 +178,7 @@ func TestScraper_RowToMetricMultiErrorsOnScrape(t *testing.T) {
 func TestScraper_SingleRow_MultiMetrics(t *testing.T) {
 	scrpr := scraper{
 		client: &fakeDBClient{
-			responses: [][]metricRow{{{
+			responses: [][]stringMap{{{
 				"count":    "42",
 				"foo_name": "baz",
 				"bar_name": "quux",
@@ -196,7 +248,7 @@ func TestScraper_SingleRow_MultiMetrics(t *testing.T) {
 
 func TestScraper_MultiRow(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{{
+		responses: [][]stringMap{{
 			{
 				"count": "42",
 				"genre": "action",
@@ -242,7 +294,7 @@ func TestScraper_MultiRow(t *testing.T) {
 
 func TestScraper_MultiResults_CumulativeSum(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]stringMap{
 			{{"count": "42"}},
 			{{"count": "43"}},
 		},
@@ -265,7 +317,7 @@ func TestScraper_MultiResults_CumulativeSum(t *testing.T) {
 
 func TestScraper_MultiResults_DeltaSum(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]stringMap{
 			{{"count": "42"}},
 			{{"count": "43"}},
 		},
@@ -302,7 +354,7 @@ func assertTransactionCount(t *testing.T, scrpr scraper, expected int, agg pmetr
 
 func TestScraper_Float(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]stringMap{
 			{{"myfloat": "123.4"}},
 		},
 	}
@@ -326,7 +378,7 @@ func TestScraper_Float(t *testing.T) {
 
 func TestScraper_DescriptionAndUnit(t *testing.T) {
 	client := &fakeDBClient{
-		responses: [][]metricRow{
+		responses: [][]stringMap{
 			{{"mycol": "123"}},
 		},
 	}
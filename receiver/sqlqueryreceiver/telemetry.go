@@ -0,0 +1,81 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//       http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package sqlqueryreceiver // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/sqlqueryreceiver"
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+	"go.opentelemetry.io/collector/component"
+)
+
+var (
+	tagReceiverKey, _ = tag.NewKey("receiver")
+	tagQueryKey, _    = tag.NewKey("query")
+
+	statQueryDuration = stats.Float64("sqlquery.duration", "Duration of the query executions", stats.UnitMilliseconds)
+	statQueryErrors   = stats.Int64("sqlquery.errors", "Number of failed query executions", stats.UnitDimensionless)
+)
+
+// MetricViews returns the metric views for the SQL query receiver.
+func MetricViews() []*view.View {
+	tagKeys := []tag.Key{tagReceiverKey, tagQueryKey}
+
+	queryDuration := &view.View{
+		Name:        statQueryDuration.Name(),
+		Measure:     statQueryDuration,
+		Description: statQueryDuration.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Distribution(1, 5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000),
+	}
+
+	queryErrors := &view.View{
+		Name:        statQueryErrors.Name(),
+		Measure:     statQueryErrors,
+		Description: statQueryErrors.Description(),
+		TagKeys:     tagKeys,
+		Aggregation: view.Sum(),
+	}
+
+	return []*view.View{
+		queryDuration,
+		queryErrors,
+	}
+}
+
+// queryTelemetry records the self-metrics of a query.
+type queryTelemetry struct {
+	mutators []tag.Mutator
+}
+
+func newQueryTelemetry(receiverID component.ID, queryIndex int) queryTelemetry {
+	return queryTelemetry{mutators: []tag.Mutator{
+		tag.Upsert(tagReceiverKey, receiverID.String()),
+		tag.Upsert(tagQueryKey, fmt.Sprintf("query-%d", queryIndex)),
+	}}
+}
+
+// record records the duration of a query execution, and whether it failed.
+func (t queryTelemetry) record(duration time.Duration, err error) {
+	measurements := []stats.Measurement{statQueryDuration.M(float64(duration) / float64(time.Millisecond))}
+	if err != nil {
+		measurements = append(measurements, statQueryErrors.M(1))
+	}
+	_ = stats.RecordWithTags(context.Background(), t.mutators, measurements...)
+}
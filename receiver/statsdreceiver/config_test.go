@@ -66,6 +66,26 @@ func TestLoadConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			id: component.NewIDWithName(typeStr, "dogstatsd"),
+			expected: &Config{
+				NetAddr: confignet.NetAddr{
+					Endpoint:  "/var/run/datadog/dsd.socket",
+					Transport: "unixgram",
+				},
+				AggregationInterval: 10 * time.Second,
+				TimerHistogramMapping: []protocol.TimerHistogramMapping{
+					{
+						StatsdType:   "timing",
+						ObserverType: "summary",
+					},
+					{
+						StatsdType:   "distribution",
+						ObserverType: "histogram",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
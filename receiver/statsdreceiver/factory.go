@@ -56,10 +56,11 @@ func createDefaultConfig() component.Config {
 			Endpoint:  defaultBindEndpoint,
 			Transport: defaultTransport,
 		},
-		AggregationInterval:   defaultAggregationInterval,
-		EnableMetricType:      defaultEnableMetricType,
-		IsMonotonicCounter:    defaultIsMonotonicCounter,
-		TimerHistogramMapping: defaultTimerHistogramMapping,
+		AggregationInterval: defaultAggregationInterval,
+		EnableMetricType:    defaultEnableMetricType,
+		IsMonotonicCounter:  defaultIsMonotonicCounter,
+		// Copy the defaults so unmarshaling a config cannot modify them.
+		TimerHistogramMapping: append([]protocol.TimerHistogramMapping(nil), defaultTimerHistogramMapping...),
 	}
 }
 
@@ -67,6 +67,20 @@ func buildGaugeMetric(parsedMetric statsDMetric, timeNow time.Time) pmetric.Scop
 	return ilm
 }
 
+func buildSetMetric(desc statsDMetricDescription, set setMetric, timeNow time.Time, ilm pmetric.ScopeMetrics) {
+	nm := ilm.Metrics().AppendEmpty()
+	nm.SetName(desc.name)
+	dp := nm.SetEmptyGauge().DataPoints().AppendEmpty()
+	dp.SetIntValue(int64(len(set.values)))
+	if !set.timestamp.IsZero() {
+		timeNow = set.timestamp
+	}
+	dp.SetTimestamp(pcommon.NewTimestampFromTime(timeNow))
+	for i := desc.attrs.Iter(); i.Next(); {
+		dp.Attributes().PutStr(string(i.Attribute().Key), i.Attribute().Value.AsString())
+	}
+}
+
 func buildSummaryMetric(desc statsDMetricDescription, summary summaryMetric, startTime, timeNow time.Time, percentiles []float64, ilm pmetric.ScopeMetrics) {
 	nm := ilm.Metrics().AppendEmpty()
 	nm.SetName(desc.name)
@@ -145,16 +159,20 @@ func buildHistogramMetric(desc statsDMetricDescription, histogram histogramMetri
 }
 
 func (s statsDMetric) counterValue() int64 {
-	x := s.asFloat
 	// Note statds counters are always represented as integers.
 	// There is no statsd specification that says what should or
 	// shouldn't be done here.  Rounding may occur for sample
 	// rates that are not integer reciprocals.  Recommendation:
 	// use integer reciprocal sampling rates.
+	return int64(s.scaledCounterValue())
+}
+
+func (s statsDMetric) scaledCounterValue() float64 {
+	x := s.asFloat
 	if 0 < s.sampleRate && s.sampleRate < 1 {
 		x /= s.sampleRate
 	}
-	return int64(x)
+	return x
 }
 
 func (s statsDMetric) gaugeValue() float64 {
@@ -162,6 +180,15 @@ func (s statsDMetric) gaugeValue() float64 {
 	return s.asFloat
 }
 
+// timestampOr returns the timestamp sent with the metric, or def if the
+// client did not send one.
+func (s statsDMetric) timestampOr(def time.Time) time.Time {
+	if s.timestamp.IsZero() {
+		return def
+	}
+	return s.timestamp
+}
+
 func (s statsDMetric) sampleValue() sampleValue {
 	count := 1.0
 	if 0 < s.sampleRate && s.sampleRate < 1 {
@@ -17,18 +17,22 @@ package protocol // import "github.com/open-telemetry/opentelemetry-collector-co
 import (
 	"errors"
 	"fmt"
+	"math"
 	"strconv"
 	"strings"
 	"time"
 
 	"github.com/lightstep/go-expohisto/structure"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
 )
 
 var (
 	errEmptyMetricName  = errors.New("empty metric name")
 	errEmptyMetricValue = errors.New("empty metric value")
+	errEmptyContainerID = errors.New("empty container id")
 )
 
 type (
@@ -38,18 +42,23 @@ type (
 )
 
 const (
-	tagMetricType = "metric_type"
-
-	CounterType   MetricType = "c"
-	GaugeType     MetricType = "g"
-	HistogramType MetricType = "h"
-	TimingType    MetricType = "ms"
-
-	CounterTypeName   TypeName = "counter"
-	GaugeTypeName     TypeName = "gauge"
-	HistogramTypeName TypeName = "histogram"
-	TimingTypeName    TypeName = "timing"
-	TimingAltTypeName TypeName = "timer"
+	tagMetricType  = "metric_type"
+	tagContainerID = "container.id"
+
+	CounterType      MetricType = "c"
+	GaugeType        MetricType = "g"
+	HistogramType    MetricType = "h"
+	TimingType       MetricType = "ms"
+	DistributionType MetricType = "d"
+	SetType          MetricType = "s"
+
+	CounterTypeName      TypeName = "counter"
+	GaugeTypeName        TypeName = "gauge"
+	HistogramTypeName    TypeName = "histogram"
+	TimingTypeName       TypeName = "timing"
+	TimingAltTypeName    TypeName = "timer"
+	DistributionTypeName TypeName = "distribution"
+	SetTypeName          TypeName = "set"
 
 	GaugeObserver     ObserverType = "gauge"
 	SummaryObserver   ObserverType = "summary"
@@ -82,6 +91,8 @@ var defaultObserverCategory = ObserverCategory{
 type StatsDParser struct {
 	gauges                 map[statsDMetricDescription]pmetric.ScopeMetrics
 	counters               map[statsDMetricDescription]pmetric.ScopeMetrics
+	counterValues          map[statsDMetricDescription]float64
+	sets                   map[statsDMetricDescription]setMetric
 	summaries              map[statsDMetricDescription]summaryMetric
 	histograms             map[statsDMetricDescription]histogramMetric
 	timersAndDistributions []pmetric.ScopeMetrics
@@ -89,7 +100,11 @@ type StatsDParser struct {
 	isMonotonicCounter     bool
 	timerEvents            ObserverCategory
 	histogramEvents        ObserverCategory
+	distributionEvents     ObserverCategory
 	lastIntervalTime       time.Time
+
+	// Logger logs the lines accepted with an ignored sample rate. Defaults to a no-op logger.
+	Logger *zap.Logger
 }
 
 type sampleValue struct {
@@ -102,6 +117,11 @@ type summaryMetric struct {
 	weights []float64
 }
 
+type setMetric struct {
+	values    map[string]struct{}
+	timestamp time.Time
+}
+
 type histogramStructure = structure.Histogram[float64]
 
 type histogramMetric struct {
@@ -114,6 +134,10 @@ type statsDMetric struct {
 	addition    bool
 	unit        string
 	sampleRate  float64
+	// invalidSampleRate is set when the sample rate is outside of (0, 1] and ignored.
+	invalidSampleRate bool
+	setValue          string
+	timestamp         time.Time
 }
 
 type statsDMetricDescription struct {
@@ -132,6 +156,10 @@ func (t MetricType) FullName() TypeName {
 		return TimingTypeName
 	case HistogramType:
 		return HistogramTypeName
+	case DistributionType:
+		return DistributionTypeName
+	case SetType:
+		return SetTypeName
 	}
 	return TypeName(fmt.Sprintf("unknown(%s)", t))
 }
@@ -140,6 +168,8 @@ func (p *StatsDParser) resetState(when time.Time) {
 	p.lastIntervalTime = when
 	p.gauges = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
 	p.counters = make(map[statsDMetricDescription]pmetric.ScopeMetrics)
+	p.counterValues = make(map[statsDMetricDescription]float64)
+	p.sets = make(map[statsDMetricDescription]setMetric)
 	p.timersAndDistributions = nil
 	p.summaries = make(map[statsDMetricDescription]summaryMetric)
 	p.histograms = make(map[statsDMetricDescription]histogramMetric)
@@ -152,6 +182,7 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 	p.timerEvents = defaultObserverCategory
 	p.enableMetricType = enableMetricType
 	p.isMonotonicCounter = isMonotonicCounter
+	distributionMapped := false
 	// Note: validation occurs in ("../".Config).validate()
 	for _, eachMap := range sendTimerHistogram {
 		switch eachMap.StatsdType {
@@ -161,8 +192,17 @@ func (p *StatsDParser) Initialize(enableMetricType bool, isMonotonicCounter bool
 		case TimingTypeName, TimingAltTypeName:
 			p.timerEvents.method = eachMap.ObserverType
 			p.timerEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
+		case DistributionTypeName:
+			distributionMapped = true
+			p.distributionEvents.method = eachMap.ObserverType
+			p.distributionEvents.histogramConfig = expoHistogramConfig(eachMap.Histogram)
 		}
 	}
+	// DogStatsD distributions are histograms computed server-side, so
+	// unless configured otherwise they follow the histogram mapping.
+	if !distributionMapped {
+		p.distributionEvents = p.histogramEvents
+	}
 	return nil
 }
 
@@ -193,6 +233,15 @@ func (p *StatsDParser) GetMetrics() pmetric.Metrics {
 
 	now := timeNowFunc()
 
+	for desc, setMetric := range p.sets {
+		buildSetMetric(
+			desc,
+			setMetric,
+			now,
+			rm.ScopeMetrics().AppendEmpty(),
+		)
+	}
+
 	for desc, summaryMetric := range p.summaries {
 		buildSummaryMetric(
 			desc,
@@ -225,6 +274,8 @@ func (p *StatsDParser) observerCategoryFor(t MetricType) ObserverCategory {
 		return p.histogramEvents
 	case TimingType:
 		return p.timerEvents
+	case DistributionType:
+		return p.distributionEvents
 	}
 	return defaultObserverCategory
 }
@@ -235,17 +286,23 @@ func (p *StatsDParser) Aggregate(line string) error {
 	if err != nil {
 		return err
 	}
+	if parsedMetric.invalidSampleRate && p.Logger != nil {
+		p.Logger.Debug("Ignoring the sample rate outside of (0, 1], the value is not scaled", zap.String("line", line))
+	}
 	switch parsedMetric.description.metricType {
 	case GaugeType:
 		_, ok := p.gauges[parsedMetric.description]
 		if !ok {
-			p.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timeNowFunc())
+			p.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, parsedMetric.timestampOr(timeNowFunc()))
 		} else {
 			if parsedMetric.addition {
 				point := p.gauges[parsedMetric.description].Metrics().At(0).Gauge().DataPoints().At(0)
 				point.SetDoubleValue(point.DoubleValue() + parsedMetric.gaugeValue())
+				if !parsedMetric.timestamp.IsZero() {
+					point.SetTimestamp(pcommon.NewTimestampFromTime(parsedMetric.timestamp))
+				}
 			} else {
-				p.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, timeNowFunc())
+				p.gauges[parsedMetric.description] = buildGaugeMetric(parsedMetric, parsedMetric.timestampOr(timeNowFunc()))
 			}
 		}
 
@@ -254,17 +311,34 @@ func (p *StatsDParser) Aggregate(line string) error {
 		if !ok {
 			timeNow := timeNowFunc()
 			p.counters[parsedMetric.description] = buildCounterMetric(parsedMetric, p.isMonotonicCounter, timeNow, p.lastIntervalTime)
+			p.counterValues[parsedMetric.description] = parsedMetric.scaledCounterValue()
 			p.lastIntervalTime = timeNow
 		} else {
+			// Accumulate the scaled values as floats so that sample rates that
+			// are not integer reciprocals do not lose their fractional part on
+			// every message.
+			total := p.counterValues[parsedMetric.description] + parsedMetric.scaledCounterValue()
+			p.counterValues[parsedMetric.description] = total
 			point := p.counters[parsedMetric.description].Metrics().At(0).Sum().DataPoints().At(0)
-			point.SetIntValue(point.IntValue() + parsedMetric.counterValue())
+			point.SetIntValue(int64(total))
 		}
 
-	case TimingType, HistogramType:
+	case SetType:
+		existing, ok := p.sets[parsedMetric.description]
+		if !ok {
+			existing = setMetric{values: make(map[string]struct{})}
+		}
+		existing.values[parsedMetric.setValue] = struct{}{}
+		if !parsedMetric.timestamp.IsZero() {
+			existing.timestamp = parsedMetric.timestamp
+		}
+		p.sets[parsedMetric.description] = existing
+
+	case TimingType, HistogramType, DistributionType:
 		category := p.observerCategoryFor(parsedMetric.description.metricType)
 		switch category.method {
 		case GaugeObserver:
-			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, timeNowFunc()))
+			p.timersAndDistributions = append(p.timersAndDistributions, buildGaugeMetric(parsedMetric, parsedMetric.timestampOr(timeNowFunc())))
 		case SummaryObserver:
 			raw := parsedMetric.sampleValue()
 			if existing, ok := p.summaries[parsedMetric.description]; !ok {
@@ -325,13 +399,10 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 	if valueStr == "" {
 		return result, errEmptyMetricValue
 	}
-	if strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+") {
-		result.addition = true
-	}
 
 	inType := MetricType(parts[1])
 	switch inType {
-	case CounterType, GaugeType, HistogramType, TimingType:
+	case CounterType, GaugeType, HistogramType, TimingType, DistributionType, SetType:
 		result.description.metricType = inType
 	default:
 		return result, fmt.Errorf("unsupported metric type: %s", inType)
@@ -350,6 +421,11 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 			if err != nil {
 				return result, fmt.Errorf("parse sample rate: %s", sampleRateStr)
 			}
+			// The rates outside of (0, 1] are ignored, as if the metric wasn't sampled.
+			if math.IsNaN(f) || f <= 0 || f > 1 {
+				result.invalidSampleRate = true
+				f = 1
+			}
 
 			result.sampleRate = f
 		case strings.HasPrefix(part, "#"):
@@ -366,14 +442,38 @@ func parseMessageToMetric(line string, enableMetricType bool) (statsDMetric, err
 				v := tagParts[1]
 				kvs = append(kvs, attribute.String(k, v))
 			}
+		case strings.HasPrefix(part, "c:"):
+			containerID := strings.TrimPrefix(part, "c:")
+			if containerID == "" {
+				return result, errEmptyContainerID
+			}
+			kvs = append(kvs, attribute.String(tagContainerID, containerID))
+		case strings.HasPrefix(part, "T"):
+			timestampStr := strings.TrimPrefix(part, "T")
+
+			secs, err := strconv.ParseInt(timestampStr, 10, 64)
+			if err != nil || secs <= 0 {
+				return result, fmt.Errorf("parse timestamp: %s", timestampStr)
+			}
+
+			result.timestamp = time.Unix(secs, 0)
 		default:
 			return result, fmt.Errorf("unrecognized message part: %s", part)
 		}
 	}
-	var err error
-	result.asFloat, err = strconv.ParseFloat(valueStr, 64)
-	if err != nil {
-		return result, fmt.Errorf("parse metric value string: %s", valueStr)
+	if result.description.metricType == SetType {
+		// Set members are opaque strings, only their distinct count is reported.
+		result.setValue = valueStr
+	} else {
+		if strings.HasPrefix(valueStr, "-") || strings.HasPrefix(valueStr, "+") {
+			result.addition = true
+		}
+
+		var err error
+		result.asFloat, err = strconv.ParseFloat(valueStr, 64)
+		if err != nil {
+			return result, fmt.Errorf("parse metric value string: %s", valueStr)
+		}
 	}
 
 	// add metric_type dimension for all metrics
@@ -21,8 +21,12 @@ import (
 
 	"github.com/lightstep/go-expohisto/mapping/logarithm"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
 	"go.opentelemetry.io/collector/pdata/pmetric"
 	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zaptest/observer"
 
 	"github.com/open-telemetry/opentelemetry-collector-contrib/internal/coreinternal/metricstestutil"
 )
@@ -231,6 +235,61 @@ func Test_ParseMessageToMetric(t *testing.T) {
 				false,
 				"h", 0, nil, nil),
 		},
+		{
+			name:  "distribution with sample rate",
+			input: "test.metric:-42.5|d|@0.5",
+			wantMetric: testStatsDMetric(
+				"test.metric",
+				-42.5,
+				true,
+				"d", 0.5, nil, nil),
+		},
+		{
+			name:  "set with string member",
+			input: "test.metric:user-1234|s|#key:value",
+			wantMetric: func() statsDMetric {
+				m := testStatsDMetric("test.metric", 0, false, "s", 0, []string{"key"}, []string{"value"})
+				m.setValue = "user-1234"
+				return m
+			}(),
+		},
+		{
+			name:  "container id and timestamp",
+			input: "test.metric:42|g|#key:value|c:abc123|T1656581400",
+			wantMetric: func() statsDMetric {
+				m := testStatsDMetric("test.metric", 42, false, "g", 0, []string{"key", "container.id"}, []string{"value", "abc123"})
+				m.timestamp = time.Unix(1656581400, 0)
+				return m
+			}(),
+		},
+		{
+			name:  "empty container id",
+			input: "test.metric:42|g|c:",
+			err:   errors.New("empty container id"),
+		},
+		{
+			name:  "invalid timestamp",
+			input: "test.metric:42|g|Tnow",
+			err:   errors.New("parse timestamp: now"),
+		},
+		{
+			name:  "sample rate above one",
+			input: "test.metric:42|c|@1.5",
+			wantMetric: func() statsDMetric {
+				m := testStatsDMetric("test.metric", 42, false, "c", 1, nil, nil)
+				m.invalidSampleRate = true
+				return m
+			}(),
+		},
+		{
+			name:  "zero sample rate",
+			input: "test.metric:42|c|@0",
+			wantMetric: func() statsDMetric {
+				m := testStatsDMetric("test.metric", 42, false, "c", 1, nil, nil)
+				m.invalidSampleRate = true
+				return m
+			}(),
+		},
 	}
 
 	for _, tt := range tests {
@@ -1000,6 +1059,91 @@ func TestStatsDParser_Mappings(t *testing.T) {
 	}
 }
 
+func TestStatsDParser_AggregateIgnoresInvalidSampleRate(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	core, logs := observer.New(zap.DebugLevel)
+	p := &StatsDParser{Logger: zap.New(core)}
+	assert.NoError(t, p.Initialize(false, false, nil))
+
+	for _, line := range []string{
+		"counter:2|c|@1.5",
+		"counter:3|c|@0",
+		"counter:5|c|@0.5",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	counter := p.counters[statsDMetricDescription{name: "counter", metricType: "c"}]
+	assert.Equal(t, int64(15), counter.Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestStatsDParser_AggregateDogStatsD(t *testing.T) {
+	timeNowFunc = func() time.Time {
+		return time.Unix(711, 0)
+	}
+
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{{StatsdType: "histogram", ObserverType: "summary"}}))
+	p.lastIntervalTime = time.Unix(611, 0)
+
+	for _, line := range []string{
+		"counter:1|c|@0.3",
+		"counter:1|c|@0.3",
+		"counter:1|c|@0.3",
+		"users:alice|s",
+		"users:bob|s",
+		"users:alice|s",
+		"users:carol|s|c:abc123",
+		"gauge:5|g|T1000",
+		"latency:10|d",
+		"latency:20|d|@0.5",
+	} {
+		assert.NoError(t, p.Aggregate(line))
+	}
+
+	// Fractional contributions from sample rates are kept across messages.
+	counter := p.counters[statsDMetricDescription{name: "counter", metricType: "c"}]
+	assert.Equal(t, int64(10), counter.Metrics().At(0).Sum().DataPoints().At(0).IntValue())
+
+	gauge := p.gauges[statsDMetricDescription{name: "gauge", metricType: "g"}]
+	assert.Equal(t, pcommon.NewTimestampFromTime(time.Unix(1000, 0)), gauge.Metrics().At(0).Gauge().DataPoints().At(0).Timestamp())
+
+	// Distributions follow the histogram mapping unless mapped explicitly.
+	assert.Equal(t, summaryMetric{
+		points:  []float64{10, 20},
+		weights: []float64{1, 2},
+	}, p.summaries[statsDMetricDescription{name: "latency", metricType: "d"}])
+
+	metrics := p.GetMetrics()
+	sets := map[string]int64{}
+	ilms := metrics.ResourceMetrics().At(0).ScopeMetrics()
+	for i := 0; i < ilms.Len(); i++ {
+		m := ilms.At(i).Metrics().At(0)
+		if m.Name() != "users" {
+			continue
+		}
+		require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+		dp := m.Gauge().DataPoints().At(0)
+		containerID, _ := dp.Attributes().Get("container.id")
+		sets[containerID.Str()] = dp.IntValue()
+	}
+	assert.Equal(t, map[string]int64{"": 2, "abc123": 1}, sets)
+}
+
+func TestStatsDParser_DistributionMapping(t *testing.T) {
+	p := &StatsDParser{}
+	assert.NoError(t, p.Initialize(false, false, []TimerHistogramMapping{
+		{StatsdType: "histogram", ObserverType: "summary"},
+		{StatsdType: "distribution", ObserverType: "histogram"},
+	}))
+	assert.Equal(t, SummaryObserver, p.histogramEvents.method)
+	assert.Equal(t, HistogramObserver, p.distributionEvents.method)
+}
+
 func TestTimeNowFunc(t *testing.T) {
 	timeNow := timeNowFunc()
 	assert.NotNil(t, timeNow)
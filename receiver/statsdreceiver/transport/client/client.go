@@ -18,6 +18,7 @@ import (
 	"fmt"
 	"io"
 	"net"
+	"strconv"
 )
 
 // StatsD defines the properties of a StatsD connection.
@@ -58,13 +59,15 @@ func (s *StatsD) connect(transport Transport) error {
 		cl.Close()
 	}
 
-	address := fmt.Sprintf("%s:%d", s.Host, s.Port)
+	address := net.JoinHostPort(s.Host, strconv.Itoa(s.Port))
 
 	var err error
 	switch transport {
 	case TCP:
-		// TODO: implement TCP support
-		return fmt.Errorf("TCP unsupported")
+		s.Conn, err = net.Dial("tcp", address)
+		if err != nil {
+			return err
+		}
 	case UDP:
 		var udpAddr *net.UDPAddr
 		udpAddr, err = net.ResolveUDPAddr("udp", address)
@@ -108,7 +111,7 @@ type Metric struct {
 	Type  string
 }
 
-// String formats a Metric into a StatsD message.
+// String formats a Metric into a newline terminated StatsD message.
 func (m Metric) String() string {
-	return fmt.Sprintf("%s:%s|%s", m.Name, m.Value, m.Type)
+	return fmt.Sprintf("%s:%s|%s\n", m.Name, m.Value, m.Type)
 }
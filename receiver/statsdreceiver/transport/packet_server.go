@@ -19,6 +19,7 @@ import (
 	"errors"
 	"io"
 	"net"
+	"os"
 	"strings"
 
 	"go.opentelemetry.io/collector/consumer"
@@ -26,27 +27,39 @@ import (
 	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
 )
 
-type udpServer struct {
+type packetServer struct {
+	network    string
 	packetConn net.PacketConn
 	reporter   Reporter
 }
 
-var _ (Server) = (*udpServer)(nil)
+var _ (Server) = (*packetServer)(nil)
 
 // NewUDPServer creates a transport.Server using UDP as its transport.
 func NewUDPServer(addr string) (Server, error) {
-	packetConn, err := net.ListenPacket("udp", addr)
+	return newPacketServer("udp", addr)
+}
+
+// NewUnixgramServer creates a transport.Server using a Unix domain datagram
+// socket bound to the given path as its transport.
+func NewUnixgramServer(path string) (Server, error) {
+	return newPacketServer("unixgram", path)
+}
+
+func newPacketServer(network string, addr string) (Server, error) {
+	packetConn, err := net.ListenPacket(network, addr)
 	if err != nil {
 		return nil, err
 	}
 
-	u := udpServer{
+	u := packetServer{
+		network:    network,
 		packetConn: packetConn,
 	}
 	return &u, nil
 }
 
-func (u *udpServer) ListenAndServe(
+func (u *packetServer) ListenAndServe(
 	parser protocol.Parser,
 	nextConsumer consumer.Metrics,
 	reporter Reporter,
@@ -58,16 +71,17 @@ func (u *udpServer) ListenAndServe(
 
 	u.reporter = reporter
 
-	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6)
+	buf := make([]byte, 65527) // max size for udp packet body (assuming ipv6), unixgram defaults are smaller
 	for {
 		n, _, err := u.packetConn.ReadFrom(buf)
 		if n > 0 {
 			bufCopy := make([]byte, n)
 			copy(bufCopy, buf)
-			u.handlePacket(bufCopy, transferChan)
+			handlePacket(bufCopy, transferChan)
 		}
 		if err != nil {
-			u.reporter.OnDebugf("UDP Transport (%s) - ReadFrom error: %v",
+			u.reporter.OnDebugf("%s Transport (%s) - ReadFrom error: %v",
+				u.network,
 				u.packetConn.LocalAddr(),
 				err)
 			var netErr net.Error
@@ -81,11 +95,20 @@ func (u *udpServer) ListenAndServe(
 	}
 }
 
-func (u *udpServer) Close() error {
-	return u.packetConn.Close()
+func (u *packetServer) Close() error {
+	err := u.packetConn.Close()
+	if u.network == "unixgram" {
+		// Unlike stream listeners, datagram sockets do not remove their
+		// file on close.
+		if rmErr := os.Remove(u.packetConn.LocalAddr().String()); rmErr != nil && !errors.Is(rmErr, os.ErrNotExist) && err == nil {
+			err = rmErr
+		}
+	}
+	return err
 }
 
-func (u *udpServer) handlePacket(
+// handlePacket splits a newline delimited buffer into metric lines.
+func handlePacket(
 	data []byte,
 	transferChan chan<- string,
 ) {
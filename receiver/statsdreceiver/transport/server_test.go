@@ -16,6 +16,7 @@ package transport
 
 import (
 	"net"
+	"path/filepath"
 	"runtime"
 	"strconv"
 	"sync"
@@ -35,43 +36,83 @@ func Test_Server_ListenAndServe(t *testing.T) {
 
 	tests := []struct {
 		name          string
+		addrFn        func(t *testing.T) string
 		buildServerFn func(addr string) (Server, error)
-		buildClientFn func(host string, port int) (*client.StatsD, error)
+		buildClientFn func(addr string) (*client.StatsD, error)
 	}{
 		{
-			name:          "udp",
+			name: "udp",
+			addrFn: func(t *testing.T) string {
+				addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
+
+				// Endpoint should be free.
+				ln0, err := net.ListenPacket("udp", addr)
+				require.NoError(t, err)
+				require.NotNil(t, ln0)
+
+				// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
+				ln1, err := net.ListenPacket("udp", addr)
+				require.Error(t, err)
+				require.Nil(t, ln1)
+
+				// Unbind the local address so the mock UDP service can use it
+				ln0.Close()
+				return addr
+			},
 			buildServerFn: NewUDPServer,
-			buildClientFn: func(host string, port int) (*client.StatsD, error) {
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
 				return client.NewStatsD(client.UDP, host, port)
 			},
 		},
+		{
+			name: "tcp",
+			addrFn: func(t *testing.T) string {
+				return testutil.GetAvailableLocalAddress(t)
+			},
+			buildServerFn: NewTCPServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				host, port := splitHostPort(t, addr)
+				return client.NewStatsD(client.TCP, host, port)
+			},
+		},
+		{
+			name: "unixgram",
+			addrFn: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "statsd.sock")
+			},
+			buildServerFn: NewUnixgramServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				conn, err := net.Dial("unixgram", addr)
+				if err != nil {
+					return nil, err
+				}
+				return &client.StatsD{Conn: conn}, nil
+			},
+		},
+		{
+			name: "unix",
+			addrFn: func(t *testing.T) string {
+				return filepath.Join(t.TempDir(), "statsd.sock")
+			},
+			buildServerFn: NewUnixServer,
+			buildClientFn: func(addr string) (*client.StatsD, error) {
+				conn, err := net.Dial("unix", addr)
+				if err != nil {
+					return nil, err
+				}
+				return &client.StatsD{Conn: conn}, nil
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			addr := testutil.GetAvailableLocalNetworkAddress(t, "udp")
-
-			// Endpoint should be free.
-			ln0, err := net.ListenPacket("udp", addr)
-			require.NoError(t, err)
-			require.NotNil(t, ln0)
-
-			// Ensure that the endpoint wasn't something like ":0" by checking that a second listener will fail.
-			ln1, err := net.ListenPacket("udp", addr)
-			require.Error(t, err)
-			require.Nil(t, ln1)
-
-			// Unbind the local address so the mock UDP service can use it
-			ln0.Close()
+			addr := tt.addrFn(t)
 
 			srv, err := tt.buildServerFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, srv)
 
-			host, portStr, err := net.SplitHostPort(addr)
-			require.NoError(t, err)
-			port, err := strconv.Atoi(portStr)
-			require.NoError(t, err)
-
 			mc := new(consumertest.MetricsSink)
 			p := &protocol.StatsDParser{}
 			require.NoError(t, err)
@@ -87,7 +128,7 @@ func Test_Server_ListenAndServe(t *testing.T) {
 
 			runtime.Gosched()
 
-			gc, err := tt.buildClientFn(host, port)
+			gc, err := tt.buildClientFn(addr)
 			require.NoError(t, err)
 			require.NotNil(t, gc)
 			err = gc.SendMetric(client.Metric{
@@ -114,3 +155,23 @@ func Test_Server_ListenAndServe(t *testing.T) {
 		})
 	}
 }
+
+func splitHostPort(t *testing.T, addr string) (string, int) {
+	host, portStr, err := net.SplitHostPort(addr)
+	require.NoError(t, err)
+	port, err := strconv.Atoi(portStr)
+	require.NoError(t, err)
+	return host, port
+}
+
+func Test_handlePacket(t *testing.T) {
+	transferChan := make(chan string, 10)
+	handlePacket([]byte("a:1|c\n\n  b:2|g  \nc:3|ms"), transferChan)
+	close(transferChan)
+
+	var lines []string
+	for line := range transferChan {
+		lines = append(lines, line)
+	}
+	assert.Equal(t, []string{"a:1|c", "b:2|g", "c:3|ms"}, lines)
+}
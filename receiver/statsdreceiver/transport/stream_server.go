@@ -0,0 +1,153 @@
+// Copyright The OpenTelemetry Authors
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package transport // import "github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/transport"
+
+import (
+	"bufio"
+	"errors"
+	"net"
+	"strings"
+	"sync"
+
+	"go.opentelemetry.io/collector/consumer"
+
+	"github.com/open-telemetry/opentelemetry-collector-contrib/receiver/statsdreceiver/protocol"
+)
+
+// maxLineSize bounds the length of a single newline terminated message
+// accepted on a stream connection.
+const maxLineSize = 64 * 1024
+
+type streamServer struct {
+	network  string
+	listener net.Listener
+	reporter Reporter
+
+	mu     sync.Mutex
+	closed bool
+	conns  map[net.Conn]struct{}
+	wg     sync.WaitGroup
+}
+
+var _ (Server) = (*streamServer)(nil)
+
+// NewTCPServer creates a transport.Server using TCP as its transport.
+// Messages are expected to be newline delimited.
+func NewTCPServer(addr string) (Server, error) {
+	return newStreamServer("tcp", addr)
+}
+
+// NewUnixServer creates a transport.Server using a Unix domain stream
+// socket bound to the given path as its transport. Messages are expected
+// to be newline delimited.
+func NewUnixServer(path string) (Server, error) {
+	return newStreamServer("unix", path)
+}
+
+func newStreamServer(network string, addr string) (Server, error) {
+	listener, err := net.Listen(network, addr)
+	if err != nil {
+		return nil, err
+	}
+
+	s := streamServer{
+		network:  network,
+		listener: listener,
+		conns:    make(map[net.Conn]struct{}),
+	}
+	return &s, nil
+}
+
+func (s *streamServer) ListenAndServe(
+	parser protocol.Parser,
+	nextConsumer consumer.Metrics,
+	reporter Reporter,
+	transferChan chan<- string,
+) error {
+	if parser == nil || nextConsumer == nil || reporter == nil {
+		return errNilListenAndServeParameters
+	}
+
+	s.reporter = reporter
+
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			s.reporter.OnDebugf("%s Transport (%s) - Accept error: %v",
+				s.network,
+				s.listener.Addr(),
+				err)
+			var netErr net.Error
+			if errors.As(err, &netErr) {
+				if netErr.Timeout() {
+					continue
+				}
+			}
+			return err
+		}
+
+		s.mu.Lock()
+		if s.closed {
+			s.mu.Unlock()
+			conn.Close()
+			return net.ErrClosed
+		}
+		s.conns[conn] = struct{}{}
+		s.wg.Add(1)
+		s.mu.Unlock()
+		go s.handleConn(conn, transferChan)
+	}
+}
+
+func (s *streamServer) handleConn(conn net.Conn, transferChan chan<- string) {
+	defer func() {
+		s.mu.Lock()
+		delete(s.conns, conn)
+		s.mu.Unlock()
+		conn.Close()
+		s.wg.Done()
+	}()
+
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 0, 4096), maxLineSize)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line != "" {
+			transferChan <- line
+		}
+	}
+	if err := scanner.Err(); err != nil && !errors.Is(err, net.ErrClosed) {
+		s.reporter.OnDebugf("%s Transport (%s) - Read error: %v",
+			s.network,
+			conn.RemoteAddr(),
+			err)
+	}
+}
+
+// Close stops accepting new connections, closes the open ones and waits
+// for the lines already read from them to be handed over.
+func (s *streamServer) Close() error {
+	err := s.listener.Close()
+
+	s.mu.Lock()
+	s.closed = true
+	for conn := range s.conns {
+		conn.Close()
+	}
+	s.mu.Unlock()
+
+	s.wg.Wait()
+	return err
+}